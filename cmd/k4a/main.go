@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/smart-fellas/k4a/internal/app"
 	"github.com/smart-fellas/k4a/internal/config"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
 )
 
 var (
@@ -19,6 +20,8 @@ var (
 func main() {
 	versionFlag := flag.Bool("version", false, "Print version information")
 	flag.BoolVar(versionFlag, "v", false, "Print version information (shorthand)")
+	recordFlag := flag.String("record", "", "Record every kafkactl run to this cassette file")
+	replayFlag := flag.String("replay", "", "Replay kafkactl runs from this cassette file instead of running kafkactl")
 	flag.Parse()
 
 	if *versionFlag {
@@ -30,12 +33,35 @@ func main() {
 
 	cfg, err := config.Load()
 	if err != nil {
-		fmt.Printf("Error loading config: %v\n", err)
+		if *replayFlag == "" {
+			fmt.Printf("Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+		// A cassette can be replayed without a kafkactl config
+		cfg = &config.Config{CurrentContext: "replay"}
+	}
+
+	settings, err := config.LoadSettings()
+	if err != nil {
+		fmt.Printf("Error loading settings: %v\n", err)
 		os.Exit(1)
 	}
 
+	var executor kafkactl.Executor = kafkactl.NewProcessExecutor()
+	switch {
+	case *replayFlag != "":
+		replay, replayErr := kafkactl.NewReplayExecutor(*replayFlag)
+		if replayErr != nil {
+			fmt.Printf("Error loading cassette: %v\n", replayErr)
+			os.Exit(1)
+		}
+		executor = replay
+	case *recordFlag != "":
+		executor = kafkactl.NewRecordingExecutor(executor, *recordFlag)
+	}
+
 	p := tea.NewProgram(
-		app.New(cfg),
+		app.NewWithExecutor(cfg, settings, executor),
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 	)
@@ -1,19 +1,34 @@
 package app
 
 import (
+	"context"
+	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/smart-fellas/k4a/internal/config"
+	"github.com/smart-fellas/k4a/internal/eventlog"
+	"github.com/smart-fellas/k4a/internal/eventstream"
 	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/columns"
 	"github.com/smart-fellas/k4a/internal/ui/components/command"
 	"github.com/smart-fellas/k4a/internal/ui/components/footer"
 	"github.com/smart-fellas/k4a/internal/ui/components/header"
 	"github.com/smart-fellas/k4a/internal/ui/components/help"
 	"github.com/smart-fellas/k4a/internal/ui/keys"
+	"github.com/smart-fellas/k4a/internal/ui/views/acls"
 	"github.com/smart-fellas/k4a/internal/ui/views/connectors"
+	"github.com/smart-fellas/k4a/internal/ui/views/consumers"
+	"github.com/smart-fellas/k4a/internal/ui/views/contexts"
+	"github.com/smart-fellas/k4a/internal/ui/views/events"
+	"github.com/smart-fellas/k4a/internal/ui/views/logs"
+	"github.com/smart-fellas/k4a/internal/ui/views/resources"
+	"github.com/smart-fellas/k4a/internal/ui/views/restore"
 	"github.com/smart-fellas/k4a/internal/ui/views/schemas"
+	"github.com/smart-fellas/k4a/internal/ui/views/streams"
 	"github.com/smart-fellas/k4a/internal/ui/views/topics"
 )
 
@@ -25,11 +40,21 @@ const (
 	ConnectorsView ViewType = "connectors"
 	ConsumersView  ViewType = "consumers"
 	ACLsView       ViewType = "acls"
+	StreamsView    ViewType = "streams"
+	RestoreView    ViewType = "restore"
+	ContextsView   ViewType = "contexts"
+	LogsView       ViewType = "log"
+	EventsView     ViewType = "events"
+	// ResourceView lists the resources of any other kind the API serves
+	ResourceView ViewType = "resource"
 )
 
 type Model struct {
 	config      *config.Config
+	settings    *config.Settings
 	client      *kafkactl.Client
+	log         *eventlog.Log
+	events      *eventstream.Stream
 	currentView ViewType
 	width       int
 	height      int
@@ -44,6 +69,38 @@ type Model struct {
 	topicsView     topics.Model
 	schemasView    schemas.Model
 	connectorsView connectors.Model
+	consumersView  consumers.Model
+	aclsView       acls.Model
+	streamsView    streams.Model
+	restoreView    restore.Model
+	contextsView   contexts.Model
+	logsView       logs.Model
+	eventsView     events.Model
+	resourceView   resources.Model
+
+	// Kinds the API serves, as listed by kafkactl api-resources, for
+	// :<kind> commands
+	apiResources []kafkactl.APIResource
+
+	// Column preset chosen for each view in the session, by the view's
+	// key in the settings
+	presets map[string]string
+
+	// State of the session, loaded once at start; the views save their
+	// sort orders to it
+	state *config.State
+
+	// Contexts of kafkactl runs: one per client, and one per visit of the
+	// current view
+	session       context.Context
+	cancelSession context.CancelFunc
+	cancelView    context.CancelFunc
+
+	// When the current view was last loaded by a switch or an
+	// auto-refresh
+	refreshed time.Time
+	// When the resources were last listed to record their changes
+	watched time.Time
 
 	// State
 	commandMode bool
@@ -51,8 +108,24 @@ type Model struct {
 	keys        keys.KeyMap
 }
 
-func New(cfg *config.Config) Model {
-	client := kafkactl.NewClient(cfg)
+func New(cfg *config.Config, settings *config.Settings) Model {
+	return NewWithExecutor(cfg, settings, kafkactl.NewProcessExecutor())
+}
+
+// NewWithExecutor creates the app with every kafkactl run going through
+// executor, e.g. to record or replay a session.
+func NewWithExecutor(cfg *config.Config, settings *config.Settings, executor kafkactl.Executor) Model {
+	log := eventlog.New()
+	if recorder, ok := executor.(*kafkactl.RecordingExecutor); ok {
+		recorder.LogTo(log)
+	}
+	client := kafkactl.NewClientWithExecutor(cfg, kafkactl.NewLoggingExecutor(executor, log))
+	client.SetSettings(settings)
+
+	state, err := config.LoadState()
+	if err != nil {
+		log.Error("Failed to load the state, sort orders are not kept: " + err.Error())
+	}
 
 	// Get current context details
 	ctx, err := cfg.GetCurrentContext()
@@ -65,18 +138,89 @@ func New(cfg *config.Config) Model {
 		api = ctx.Context.API
 	}
 
-	return Model{
-		config:         cfg,
-		client:         client,
-		currentView:    TopicsView,
-		header:         header.New(contextName, namespace, api),
-		footer:         footer.New(),
-		command:        command.New(),
-		help:           help.New(),
-		topicsView:     topics.New(client),
-		schemasView:    schemas.New(client),
-		connectorsView: connectors.New(client),
-		keys:           keys.DefaultKeyMap(),
+	m := Model{
+		config:      cfg,
+		settings:    settings,
+		log:         log,
+		state:       state,
+		currentView: TopicsView,
+		header:      header.New(contextName, namespace, api),
+		footer:      footer.New(),
+		command:     command.New(),
+		help:        help.New(),
+		keys:        keys.DefaultKeyMap(),
+		refreshed:   time.Now(),
+		presets:     map[string]string{},
+	}
+	m.initViews(client)
+
+	return m
+}
+
+// initViews binds the app and a fresh set of views to client. kafkactl
+// runs still in flight for the previous client are cancelled.
+func (m *Model) initViews(client *kafkactl.Client) {
+	if m.cancelSession != nil {
+		m.cancelSession()
+	}
+	m.session, m.cancelSession = context.WithCancel(context.Background())
+
+	m.client = client
+	stream, err := eventstream.Open(client.ContextName())
+	if err != nil {
+		m.log.Error("Failed to open the event stream: " + err.Error())
+		stream = eventstream.New()
+	}
+	m.events = stream
+	m.watched = time.Time{}
+	m.topicsView = topics.New(client, m.state)
+	m.schemasView = schemas.New(client, m.state)
+	m.connectorsView = connectors.New(client, m.state)
+	m.consumersView = consumers.New(client)
+	m.aclsView = acls.New(client)
+	m.streamsView = streams.New(client)
+	m.restoreView = restore.New(client)
+	m.contextsView = contexts.New(m.config, client.ContextName())
+	m.logsView = logs.New(m.log)
+	m.eventsView = events.New(m.events)
+	if resource := m.resourceView.Resource(); resource.Kind != "" {
+		m.resourceView = resources.New(client, m.state, resource, resources.ColumnsFor(resource.Kind))
+	}
+	m.apiResources = nil
+	for _, view := range []ViewType{TopicsView, SchemasView, ConnectorsView, ResourceView} {
+		m.restoreColumns(view)
+	}
+	m.bindView(m.currentView)
+}
+
+// bindView gives view a fresh context for its kafkactl runs and cancels the
+// context of the view visited before, so its pending loads are abandoned.
+func (m *Model) bindView(view ViewType) {
+	if m.cancelView != nil {
+		m.cancelView()
+	}
+
+	var ctx context.Context
+	ctx, m.cancelView = context.WithCancel(m.session)
+
+	switch view {
+	case TopicsView:
+		m.topicsView.SetContext(ctx)
+	case SchemasView:
+		m.schemasView.SetContext(ctx)
+	case ConnectorsView:
+		m.connectorsView.SetContext(ctx)
+	case ConsumersView:
+		m.consumersView.SetContext(ctx)
+	case ACLsView:
+		m.aclsView.SetContext(ctx)
+	case StreamsView:
+		m.streamsView.SetContext(ctx)
+	case RestoreView:
+		m.restoreView.SetContext(ctx)
+	case ResourceView:
+		m.resourceView.SetContext(ctx)
+	case ContextsView, LogsView, EventsView:
 	}
 }
 
@@ -84,12 +228,25 @@ func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.topicsView.Init(),
 		tea.EnterAltScreen,
+		tick(),
+		m.loadAPIResources(""),
 	)
 }
 
+// tickMsg is sent every second to move the header clock and to check
+// whether the current view is due for an auto-refresh and the resources
+// are due to be listed for the event stream.
+type tickMsg time.Time
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
+
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	m.footer = m.footer.Update(msg)
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -97,12 +254,49 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.header.SetWidth(m.width)
 		m.updateLayout()
 
+	case contexts.SelectedMsg:
+		cmd := m.useContext(msg.Name, msg.Save)
+		return m, cmd
+
+	case footer.MessageMsg:
+		cmd := m.notify(msg.Text, msg.Level)
+		return m, cmd
+
+	case tickMsg:
+		refresh := m.autoRefresh(time.Time(msg))
+		watch := m.watch(time.Time(msg))
+		return m, tea.Batch(tick(), refresh, watch)
+
+	case listedMsg:
+		cmd := m.observe(msg)
+		return m, cmd
+
+	case apiResourcesMsg:
+		if msg.client != m.client {
+			return m, nil
+		}
+		if msg.err != nil {
+			cmd := m.notify("Failed to load API resources: "+msg.err.Error(), footer.LevelError)
+			return m, cmd
+		}
+		m.apiResources = msg.resources
+		if msg.command != "" {
+			cmd := m.openKind(msg.command)
+			return m, cmd
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		// Handle command mode
 		if m.commandMode {
 			return m.handleCommandMode(msg)
 		}
 
+		// Let views that are collecting text input receive every key
+		if m.capturingInput() && msg.Type != tea.KeyCtrlC {
+			break
+		}
+
 		// Handle help toggle
 		if key.Matches(msg, m.keys.Help) {
 			m.helpVisible = !m.helpVisible
@@ -133,16 +327,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle direct view switching commands (when typed quickly)
 		msgStr := msg.String()
 		if strings.HasPrefix(msgStr, ":") {
+			var view ViewType
 			switch strings.TrimPrefix(msgStr, ":") {
 			case "topics", "topic":
-				m.switchView(TopicsView)
-				return m, nil
+				view = TopicsView
 			case "schemas", "schema":
-				m.switchView(SchemasView)
-				return m, nil
+				view = SchemasView
 			case "connectors", "connector":
-				m.switchView(ConnectorsView)
-				return m, nil
+				view = ConnectorsView
+			case "consumers", "consumer":
+				view = ConsumersView
+			case "acls", "acl":
+				view = ACLsView
+			case "streams", "stream":
+				view = StreamsView
+			case "restore":
+				view = RestoreView
+			case "ctx", "context", "contexts":
+				view = ContextsView
+			case "log", "logs":
+				view = LogsView
+			case "events", "event":
+				view = EventsView
+			}
+			if view != "" {
+				cmd := m.switchView(view)
+				return m, cmd
 			}
 		}
 	}
@@ -174,8 +384,66 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.connectorsView = cv
 		}
 		cmds = append(cmds, cmd)
+
+	case ConsumersView:
+		newView, cmd := m.consumersView.Update(msg)
+		if cv, ok := newView.(consumers.Model); ok {
+			m.consumersView = cv
+		}
+		cmds = append(cmds, cmd)
+
+	case ACLsView:
+		newView, cmd := m.aclsView.Update(msg)
+		if av, ok := newView.(acls.Model); ok {
+			m.aclsView = av
+		}
+		cmds = append(cmds, cmd)
+
+	case StreamsView:
+		newView, cmd := m.streamsView.Update(msg)
+		if sv, ok := newView.(streams.Model); ok {
+			m.streamsView = sv
+		}
+		cmds = append(cmds, cmd)
+
+	case RestoreView:
+		newView, cmd := m.restoreView.Update(msg)
+		if rv, ok := newView.(restore.Model); ok {
+			m.restoreView = rv
+		}
+		cmds = append(cmds, cmd)
+
+	case ContextsView:
+		newView, cmd := m.contextsView.Update(msg)
+		if cv, ok := newView.(contexts.Model); ok {
+			m.contextsView = cv
+		}
+		cmds = append(cmds, cmd)
+
+	case LogsView:
+		newView, cmd := m.logsView.Update(msg)
+		if lv, ok := newView.(logs.Model); ok {
+			m.logsView = lv
+		}
+		cmds = append(cmds, cmd)
+
+	case EventsView:
+		newView, cmd := m.eventsView.Update(msg)
+		if ev, ok := newView.(events.Model); ok {
+			m.eventsView = ev
+		}
+		cmds = append(cmds, cmd)
+
+	case ResourceView:
+		newView, cmd := m.resourceView.Update(msg)
+		if rv, ok := newView.(resources.Model); ok {
+			m.resourceView = rv
+		}
+		cmds = append(cmds, cmd)
 	}
 
+	m.updateFilterStatus()
+
 	return m, tea.Batch(cmds...)
 }
 
@@ -198,15 +466,40 @@ func (m Model) View() string {
 			content = m.schemasView.View()
 		case ConnectorsView:
 			content = m.connectorsView.View()
+		case ConsumersView:
+			content = m.consumersView.View()
+		case ACLsView:
+			content = m.aclsView.View()
+		case StreamsView:
+			content = m.streamsView.View()
+		case RestoreView:
+			content = m.restoreView.View()
+		case ContextsView:
+			content = m.contextsView.View()
+		case LogsView:
+			content = m.logsView.View()
+		case EventsView:
+			content = m.eventsView.View()
+		case ResourceView:
+			content = m.resourceView.View()
 		}
 	}
 
 	return m.header.View() + "\n" + content + "\n" + m.footer.View()
 }
 
-func (m *Model) switchView(view ViewType) {
+// switchView makes view the current view and returns the command that
+// (re)loads its resources.
+func (m *Model) switchView(view ViewType) tea.Cmd {
 	m.currentView = view
-	m.header.SetView(string(view))
+	if view == ResourceView {
+		m.header.SetView(m.resourceView.Resource().ResourceType())
+	} else {
+		m.header.SetView(string(view))
+	}
+	m.updateFilterStatus()
+	m.bindView(view)
+	m.refreshed = time.Now()
 
 	// Update footer keybindings based on view
 	switch view {
@@ -222,9 +515,289 @@ func (m *Model) switchView(view ViewType) {
 			{Key: "?", Desc: "help"},
 			{Key: "q", Desc: "quit"},
 		})
+	case ConsumersView:
+		m.footer.SetKeybindings([]footer.Keybinding{
+			{Key: "↑↓", Desc: "navigate"},
+			{Key: "enter", Desc: "lag"},
+			{Key: "d", Desc: "describe"},
+			{Key: "o", Desc: "reset offsets"},
+			{Key: "r", Desc: "refresh"},
+			{Key: ":", Desc: "command"},
+			{Key: "?", Desc: "help"},
+			{Key: "q", Desc: "quit"},
+		})
+	case ACLsView:
+		m.footer.SetKeybindings([]footer.Keybinding{
+			{Key: "↑↓", Desc: "navigate"},
+			{Key: "d", Desc: "describe"},
+			{Key: "g", Desc: "grant"},
+			{Key: "x", Desc: "revoke"},
+			{Key: "r", Desc: "refresh"},
+			{Key: ":", Desc: "command"},
+			{Key: "?", Desc: "help"},
+			{Key: "q", Desc: "quit"},
+		})
+	case RestoreView:
+		m.footer.SetKeybindings([]footer.Keybinding{
+			{Key: "↑↓", Desc: "navigate"},
+			{Key: "enter", Desc: "restore"},
+			{Key: "d", Desc: "show manifest"},
+			{Key: "r", Desc: "refresh"},
+			{Key: ":", Desc: "command"},
+			{Key: "?", Desc: "help"},
+			{Key: "q", Desc: "quit"},
+		})
+	case ContextsView:
+		m.footer.SetKeybindings([]footer.Keybinding{
+			{Key: "↑↓", Desc: "navigate"},
+			{Key: "enter", Desc: "switch"},
+			{Key: "s", Desc: "switch and save"},
+			{Key: ":", Desc: "command"},
+			{Key: "?", Desc: "help"},
+			{Key: "q", Desc: "quit"},
+		})
+	case LogsView:
+		m.footer.SetKeybindings([]footer.Keybinding{
+			{Key: "↑↓", Desc: "navigate"},
+			{Key: "enter", Desc: "details"},
+			{Key: "r", Desc: "refresh"},
+			{Key: ":", Desc: "command"},
+			{Key: "?", Desc: "help"},
+			{Key: "q", Desc: "quit"},
+		})
+	case EventsView:
+		m.footer.SetKeybindings([]footer.Keybinding{
+			{Key: "↑↓", Desc: "navigate"},
+			{Key: "enter", Desc: "diff"},
+			{Key: "r", Desc: "refresh"},
+			{Key: ":", Desc: "command"},
+			{Key: "?", Desc: "help"},
+			{Key: "q", Desc: "quit"},
+		})
+	case ResourceView:
+		m.footer.SetKeybindings([]footer.Keybinding{
+			{Key: "↑↓", Desc: "navigate"},
+			{Key: "d", Desc: "describe"},
+			{Key: "/", Desc: "filter"},
+			{Key: "s", Desc: "sort"},
+			{Key: "r", Desc: "refresh"},
+			{Key: ":", Desc: "command"},
+			{Key: "?", Desc: "help"},
+			{Key: "q", Desc: "quit"},
+		})
 	default:
 		m.footer.SetKeybindings(footer.DefaultKeybindings())
 	}
+
+	switch view {
+	case TopicsView:
+		return m.topicsView.Init()
+	case SchemasView:
+		return m.schemasView.Init()
+	case ConnectorsView:
+		return m.connectorsView.Init()
+	case ConsumersView:
+		return m.consumersView.Init()
+	case ACLsView:
+		return m.aclsView.Init()
+	case StreamsView:
+		return m.streamsView.Init()
+	case RestoreView:
+		return m.restoreView.Init()
+	case ContextsView:
+		return m.contextsView.Init()
+	case LogsView:
+		return m.logsView.Init()
+	case EventsView:
+		return m.eventsView.Init()
+	case ResourceView:
+		return m.resourceView.Init()
+	default:
+		return nil
+	}
+}
+
+// useContext binds the app to another kafkactl context: the client, the
+// header and every view are rebuilt, and the current view is reloaded.
+// With save, the context also becomes kafkactl's current-context.
+func (m *Model) useContext(name string, save bool) tea.Cmd {
+	if err := m.config.UseContext(name); err != nil {
+		return m.notify(err.Error(), footer.LevelError)
+	}
+
+	message := "Switched to context " + name
+	level := footer.LevelSuccess
+	if save {
+		if err := m.config.Save(); err != nil {
+			message += " (not saved: " + err.Error() + ")"
+			level = footer.LevelError
+		} else {
+			message += " (saved)"
+		}
+	}
+
+	client := kafkactl.NewClientWithExecutor(m.config, m.client.Executor())
+	client.SetSettings(m.settings)
+	m.initViews(client)
+	m.header.SetContext(name)
+	m.header.SetNamespace(m.client.Namespace())
+	if ctx, err := m.config.GetCurrentContext(); err == nil {
+		m.header.SetAPI(ctx.Context.API)
+	}
+	m.updateLayout()
+
+	view := m.currentView
+	if view == ContextsView {
+		view = TopicsView
+	}
+	return tea.Batch(m.switchView(view), m.notify(message, level), m.loadAPIResources(""))
+}
+
+// useNamespace switches to the context bound to namespace; in ns4kafka a
+// namespace is reached through the context holding its token.
+func (m *Model) useNamespace(namespace string, save bool) tea.Cmd {
+	api := ""
+	if ctx, err := m.config.GetCurrentContext(); err == nil {
+		api = ctx.Context.API
+	}
+	ctx, err := m.config.ContextForNamespace(namespace, api)
+	if err != nil {
+		return m.notify(err.Error(), footer.LevelError)
+	}
+	return m.useContext(ctx.Name, save)
+}
+
+// autoRefresh reloads the current view once its refresh interval has
+// passed since it was last loaded. It holds off while help or the command
+// line is open; views hold off while a dialog or form is open.
+func (m *Model) autoRefresh(now time.Time) tea.Cmd {
+	interval := m.settings.RefreshInterval(string(m.currentView))
+	if interval == 0 || now.Sub(m.refreshed) < interval || m.helpVisible || m.commandMode {
+		return nil
+	}
+
+	var cmd tea.Cmd
+	switch m.currentView {
+	case TopicsView:
+		cmd = m.topicsView.AutoRefresh()
+	case SchemasView:
+		cmd = m.schemasView.AutoRefresh()
+	case ConnectorsView:
+		cmd = m.connectorsView.AutoRefresh()
+	default:
+		// Other views are only reloaded with r; the events view reloads
+		// as changes are observed
+	}
+	if cmd != nil {
+		m.refreshed = now
+	}
+	return cmd
+}
+
+// listedMsg carries a listing of a kind of resources for the event stream.
+type listedMsg struct {
+	stream *eventstream.Stream
+	kind   string
+	docs   []map[string]any
+	err    error
+}
+
+// watch lists every watched kind of resources in the background once the
+// events interval has passed since they were last listed. The listings are
+// bound to the session, so a context switch abandons them.
+func (m *Model) watch(now time.Time) tea.Cmd {
+	interval := m.settings.RefreshInterval(string(EventsView))
+	if interval == 0 || now.Sub(m.watched) < interval {
+		return nil
+	}
+	m.watched = now
+
+	cmds := make([]tea.Cmd, 0, len(eventstream.Kinds))
+	for _, kind := range eventstream.Kinds {
+		client, stream, ctx := m.client, m.events, m.session
+		cmds = append(cmds, func() tea.Msg {
+			docs, err := eventstream.Poll(ctx, client, kind)
+			return listedMsg{stream: stream, kind: kind, docs: docs, err: err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// observe records the changes of a listing in the event stream, and reloads
+// the events view if it is shown. Failed listings are skipped: kafkactl
+// failures are already in the event log.
+func (m *Model) observe(msg listedMsg) tea.Cmd {
+	if msg.err != nil || msg.stream != m.events {
+		return nil
+	}
+
+	observed, err := m.events.Observe(msg.kind, msg.docs, time.Now())
+	if err != nil {
+		m.log.Error(err.Error())
+	}
+	if len(observed) == 0 || m.currentView != EventsView {
+		return nil
+	}
+	return m.eventsView.Init()
+}
+
+// notify shows a message in the footer and records it in the event log.
+func (m *Model) notify(text string, level footer.Level) tea.Cmd {
+	if level == footer.LevelError {
+		m.log.Error(text)
+	} else {
+		m.log.Info(text)
+	}
+
+	return m.footer.SetMessage(text, level)
+}
+
+// capturingInput reports whether the current view is running a form or
+// workflow that must receive keys that are otherwise global shortcuts.
+func (m Model) capturingInput() bool {
+	switch m.currentView {
+	case TopicsView:
+		return m.topicsView.CapturingInput()
+	case SchemasView:
+		return m.schemasView.CapturingInput()
+	case ConnectorsView:
+		return m.connectorsView.CapturingInput()
+	case ConsumersView:
+		return m.consumersView.CapturingInput()
+	case ACLsView:
+		return m.aclsView.CapturingInput()
+	case StreamsView:
+		return m.streamsView.CapturingInput()
+	case RestoreView:
+		return m.restoreView.CapturingInput()
+	case ContextsView:
+		return m.contextsView.CapturingInput()
+	case LogsView:
+		return m.logsView.CapturingInput()
+	case EventsView:
+		return m.eventsView.CapturingInput()
+	case ResourceView:
+		return m.resourceView.CapturingInput()
+	default:
+		return false
+	}
+}
+
+// updateFilterStatus shows the current view's filter in the header. Each
+// view keeps its own filter, so it is restored when switching back.
+func (m *Model) updateFilterStatus() {
+	switch m.currentView {
+	case TopicsView:
+		m.header.SetFilter(m.topicsView.FilterStatus())
+	case SchemasView:
+		m.header.SetFilter(m.schemasView.FilterStatus())
+	case ConnectorsView:
+		m.header.SetFilter(m.connectorsView.FilterStatus())
+	case ResourceView:
+		m.header.SetFilter(m.resourceView.FilterStatus())
+	default:
+		m.header.SetFilter("", 0, 0)
+	}
 }
 
 func (m *Model) updateLayout() {
@@ -239,6 +812,14 @@ func (m *Model) updateLayout() {
 	m.topicsView.SetSize(m.width, contentHeight)
 	m.schemasView.SetSize(m.width, contentHeight)
 	m.connectorsView.SetSize(m.width, contentHeight)
+	m.consumersView.SetSize(m.width, contentHeight)
+	m.aclsView.SetSize(m.width, contentHeight)
+	m.streamsView.SetSize(m.width, contentHeight)
+	m.restoreView.SetSize(m.width, contentHeight)
+	m.contextsView.SetSize(m.width, contentHeight)
+	m.logsView.SetSize(m.width, contentHeight)
+	m.eventsView.SetSize(m.width, contentHeight)
+	m.resourceView.SetSize(m.width, contentHeight)
 }
 
 func (m Model) handleCommandMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -259,22 +840,224 @@ func (m Model) handleCommandMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.commandMode = false
 		m.command.Reset()
 
+		// Commands with arguments
+		if fields := strings.Fields(cmdText); len(fields) > 1 {
+			switch fields[0] {
+			case "ctx", "context":
+				cmd = m.useContext(fields[1], len(fields) > 2 && fields[2] == "--save")
+				return m, cmd
+			case "ns", "namespace":
+				cmd = m.useNamespace(fields[1], len(fields) > 2 && fields[2] == "--save")
+				return m, cmd
+			case "columns":
+				cmd = m.switchColumns(fields[1])
+				return m, cmd
+			case "schema", "schemas":
+				if fields[1] == "apply" && len(fields) > 2 {
+					cmd := m.switchView(SchemasView)
+					apply := m.schemasView.StartApply(strings.Join(fields[2:], " "), "")
+					return m, tea.Batch(cmd, apply)
+				}
+			}
+		}
+
 		// Process command
+		var view ViewType
 		switch cmdText {
 		case "topics", "topic":
-			m.switchView(TopicsView)
+			view = TopicsView
 		case "schemas", "schema":
-			m.switchView(SchemasView)
+			view = SchemasView
 		case "connectors", "connector":
-			m.switchView(ConnectorsView)
+			view = ConnectorsView
 		case "consumers", "consumer":
-			m.switchView(ConsumersView)
+			view = ConsumersView
 		case "acls", "acl":
-			m.switchView(ACLsView)
+			view = ACLsView
+		case "streams", "stream":
+			view = StreamsView
+		case "restore":
+			view = RestoreView
+		case "ctx", "context", "contexts", "ns", "namespace", "namespaces":
+			view = ContextsView
+		case "log", "logs":
+			view = LogsView
+		case "events", "event":
+			view = EventsView
+		case "columns":
+			cmd = m.listColumnPresets()
+			return m, cmd
 		case "q", "quit":
 			return m, tea.Quit
 		}
+		if view != "" {
+			cmd = m.switchView(view)
+			return m, cmd
+		}
+
+		// Any other kind the API serves, once the kinds are loaded
+		if cmdText == "" {
+			return m, cmd
+		}
+		if m.apiResources == nil {
+			cmd = m.notify("Loading API resources…", footer.LevelInfo)
+			return m, tea.Batch(cmd, m.loadAPIResources(cmdText))
+		}
+		cmd = m.openKind(cmdText)
+		return m, cmd
 	}
 
 	return m, cmd
 }
+
+// apiResourcesMsg carries the kinds the API serves for a client, and the
+// command naming a kind to open once they are loaded, if any.
+type apiResourcesMsg struct {
+	client    *kafkactl.Client
+	command   string
+	resources []kafkactl.APIResource
+	err       error
+}
+
+// loadAPIResources lists the kinds the API serves, in the background, and
+// opens the kind command names, if any, once they are loaded.
+func (m Model) loadAPIResources(command string) tea.Cmd {
+	client, ctx := m.client, m.session
+	return func() tea.Msg {
+		resources, err := client.ListAPIResources(ctx)
+		return apiResourcesMsg{client: client, command: command, resources: resources, err: err}
+	}
+}
+
+// openKind opens the kind a command names, or reports that no kind the
+// API serves has that name.
+func (m *Model) openKind(command string) tea.Cmd {
+	if resource, ok := m.lookupResource(command); ok {
+		return m.openResource(resource)
+	}
+	return m.notify("Unknown command: "+command, footer.LevelError)
+}
+
+// lookupResource finds the kind a command names, by kind or by any of the
+// names kafkactl accepts for it.
+func (m Model) lookupResource(name string) (kafkactl.APIResource, bool) {
+	for _, resource := range m.apiResources {
+		if resource.Matches(name) {
+			return resource, true
+		}
+	}
+	return kafkactl.APIResource{}, false
+}
+
+// openResource lists the resources of a kind in the generic resource view.
+func (m *Model) openResource(resource kafkactl.APIResource) tea.Cmd {
+	m.resourceView = resources.New(m.client, m.state, resource, resources.ColumnsFor(resource.Kind))
+	m.restoreColumns(ResourceView)
+	m.updateLayout()
+	return m.switchView(ResourceView)
+}
+
+// columnsKey returns the key of a view's column presets in the settings:
+// the view name, or for another kind, the first key naming the kind.
+func (m Model) columnsKey(view ViewType) string {
+	if view != ResourceView {
+		return string(view)
+	}
+
+	resource := m.resourceView.Resource()
+	if m.settings != nil {
+		keys := make([]string, 0, len(m.settings.Columns))
+		for key := range m.settings.Columns {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if resource.Matches(key) {
+				return key
+			}
+		}
+	}
+	return resource.ResourceType()
+}
+
+// setColumns applies a column preset to a view; builtin restores the
+// built-in columns.
+func (m *Model) setColumns(view ViewType, preset string) error {
+	key := m.columnsKey(view)
+
+	var extra []columns.Column
+	replace := false
+	if preset != config.BuiltinColumns {
+		spec, ok := m.settings.ColumnPresets(key)[preset]
+		if !ok {
+			return fmt.Errorf("no column preset %s for %s", preset, key)
+		}
+		var err error
+		if extra, err = columns.FromPreset(spec); err != nil {
+			return fmt.Errorf("column preset %s of %s: %w", preset, key, err)
+		}
+		replace = spec.Replace
+	}
+
+	switch view {
+	case TopicsView:
+		m.topicsView.SetColumns(extra, replace)
+	case SchemasView:
+		m.schemasView.SetColumns(extra, replace)
+	case ConnectorsView:
+		m.connectorsView.SetColumns(extra, replace)
+	case ResourceView:
+		m.resourceView.SetColumns(extra, replace)
+	default:
+		return fmt.Errorf("the %s view has no configurable columns", view)
+	}
+
+	m.presets[key] = preset
+	return nil
+}
+
+// restoreColumns applies to a new view the preset chosen for it in the
+// session, or else its default preset.
+func (m *Model) restoreColumns(view ViewType) {
+	if view == ResourceView && m.resourceView.Resource().Kind == "" {
+		return
+	}
+
+	key := m.columnsKey(view)
+	preset, chosen := m.presets[key]
+	if !chosen {
+		if _, ok := m.settings.ColumnPresets(key)["default"]; !ok {
+			return
+		}
+		preset = "default"
+	}
+
+	if err := m.setColumns(view, preset); err != nil {
+		m.log.Error(err.Error())
+	}
+}
+
+// switchColumns applies a column preset to the current view.
+func (m *Model) switchColumns(preset string) tea.Cmd {
+	if err := m.setColumns(m.currentView, preset); err != nil {
+		return m.notify(err.Error(), footer.LevelError)
+	}
+	return m.notify(fmt.Sprintf("Columns of %s: %s", m.columnsKey(m.currentView), preset), footer.LevelSuccess)
+}
+
+// listColumnPresets shows the column presets of the current view.
+func (m *Model) listColumnPresets() tea.Cmd {
+	key := m.columnsKey(m.currentView)
+	presets := m.settings.ColumnPresets(key)
+	if len(presets) == 0 {
+		return m.notify("No column presets for "+key+" in the settings", footer.LevelInfo)
+	}
+
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return m.notify(fmt.Sprintf("Column presets of %s: %s (%s restores the built-in columns)",
+		key, strings.Join(names, ", "), config.BuiltinColumns), footer.LevelInfo)
+}
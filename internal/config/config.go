@@ -72,6 +72,46 @@ func (c *Config) GetCurrentContext() (*Context, error) {
 	return nil, fmt.Errorf("current context %s not found", c.CurrentContext)
 }
 
+// GetContext returns the context with the given name.
+func (c *Config) GetContext(name string) (*Context, error) {
+	for _, ctx := range c.Contexts {
+		if ctx.Name == name {
+			return &ctx, nil
+		}
+	}
+	return nil, fmt.Errorf("context %s not found", name)
+}
+
+// ContextForNamespace returns the context bound to namespace, preferring
+// one on the given API when several contexts share the namespace.
+func (c *Config) ContextForNamespace(namespace, api string) (*Context, error) {
+	var found *Context
+	for _, ctx := range c.Contexts {
+		if ctx.Context.Namespace != namespace {
+			continue
+		}
+		if ctx.Context.API == api {
+			return &ctx, nil
+		}
+		if found == nil {
+			found = &ctx
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no context for namespace %s", namespace)
+	}
+	return found, nil
+}
+
+// UseContext makes name the current context. It does not save the config.
+func (c *Config) UseContext(name string) error {
+	if _, err := c.GetContext(name); err != nil {
+		return err
+	}
+	c.CurrentContext = name
+	return nil
+}
+
 func getConfigPath() string {
 	// Check for environment variable override
 	if configPath := os.Getenv("KAFKACTL_CONFIG"); configPath != "" {
@@ -87,6 +127,8 @@ func getConfigPath() string {
 	return filepath.Join(homeDir, ".kafkactl", "config.yml")
 }
 
+// Save writes the current context and contexts back to the kafkactl config
+// file. Any other settings already in the file are preserved.
 func (c *Config) Save() error {
 	configPath := getConfigPath()
 
@@ -96,12 +138,25 @@ func (c *Config) Save() error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	// Wrap config in kafkactl key
-	wrapped := map[string]any{
-		"kafkactl": c,
+	rawConfig := map[string]any{}
+	if existing, err := os.ReadFile(configPath); err == nil {
+		if err = yaml.Unmarshal(existing, &rawConfig); err != nil {
+			return fmt.Errorf("failed to parse config: %w", err)
+		}
+		if rawConfig == nil {
+			rawConfig = map[string]any{}
+		}
+	}
+
+	kafkactlConfig, ok := rawConfig["kafkactl"].(map[string]any)
+	if !ok {
+		kafkactlConfig = map[string]any{}
 	}
+	kafkactlConfig["current-context"] = c.CurrentContext
+	kafkactlConfig["contexts"] = c.Contexts
+	rawConfig["kafkactl"] = kafkactlConfig
 
-	data, err := yaml.Marshal(wrapped)
+	data, err := yaml.Marshal(rawConfig)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
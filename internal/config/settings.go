@@ -0,0 +1,241 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/smart-fellas/k4a/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultTimeout bounds kafkactl runs that have no timeout of their own.
+const DefaultTimeout = 30 * time.Second
+
+// DefaultRefresh is the auto-refresh interval of views that have none of
+// their own.
+const DefaultRefresh = 30 * time.Second
+
+// DefaultParallelism is the number of kafkactl runs a bulk action runs at
+// once.
+const DefaultParallelism = 4
+
+// Settings are k4a's own settings, kept apart from the kafkactl config in
+// ~/.k4a/config.yml.
+type Settings struct {
+	// Timeouts bounds each kafkactl run by subcommand, e.g. "get" or
+	// "apply". The "default" entry applies to every other subcommand.
+	Timeouts map[string]string `yaml:"timeouts"`
+	// Refresh is the auto-refresh interval of each view by view name, e.g.
+	// "connectors". The "default" entry applies to every other view; off
+	// turns auto-refresh off. The "events" entry is how often resources are
+	// listed in the background to record their changes.
+	Refresh map[string]string `yaml:"refresh"`
+	// Parallelism bounds the kafkactl runs a bulk action runs at once
+	Parallelism int `yaml:"parallelism,omitempty"`
+	// Columns holds the column presets of each view by view name, e.g.
+	// "topics" or "rolebindings", and then by preset name. The "default"
+	// preset applies when the view opens.
+	Columns map[string]map[string]ColumnPreset `yaml:"columns,omitempty"`
+
+	timeouts map[string]time.Duration
+	refresh  map[string]time.Duration
+}
+
+// BuiltinColumns is the preset name that restores the built-in columns of
+// a view.
+const BuiltinColumns = "builtin"
+
+// ColumnPreset is a set of columns of a view. Its columns are added after
+// the built-in ones, or with Replace, replace every built-in column but
+// the name.
+type ColumnPreset struct {
+	Replace bool         `yaml:"replace,omitempty"`
+	Columns []ColumnSpec `yaml:"columns"`
+}
+
+// ColumnSpec is a column showing the value at Path in each manifest, e.g.
+// spec.configs.min.insync.replicas, in a named format: duration, bytes or
+// truncate:N. Numeric columns are sorted as numbers, as are those in
+// duration and bytes.
+type ColumnSpec struct {
+	Title   string `yaml:"title"`
+	Path    string `yaml:"path"`
+	Width   int    `yaml:"width,omitempty"`
+	Format  string `yaml:"format,omitempty"`
+	Numeric bool   `yaml:"numeric,omitempty"`
+}
+
+// DefaultSettings returns the settings used when no settings file exists.
+func DefaultSettings() *Settings {
+	s := &Settings{
+		Timeouts: map[string]string{
+			"default":       DefaultTimeout.String(),
+			"apply":         "1m0s",
+			"delete":        "1m0s",
+			"reset-offsets": "1m0s",
+		},
+		Refresh: map[string]string{
+			"default":    DefaultRefresh.String(),
+			"connectors": "10s",
+			"events":     "1m0s",
+		},
+		Parallelism: DefaultParallelism,
+	}
+	_ = s.parse()
+	return s
+}
+
+// LoadSettings reads the settings file. A missing file yields the defaults;
+// entries in the file override the matching defaults.
+func LoadSettings() (*Settings, error) {
+	settings := DefaultSettings()
+
+	data, err := os.ReadFile(getSettingsPath())
+	if os.IsNotExist(err) {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings file: %w", err)
+	}
+
+	var file Settings
+	if err = yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	for operation, timeout := range file.Timeouts {
+		settings.Timeouts[operation] = timeout
+	}
+	for view, interval := range file.Refresh {
+		settings.Refresh[view] = interval
+	}
+	if file.Parallelism < 0 {
+		return nil, fmt.Errorf("invalid parallelism %d", file.Parallelism)
+	}
+	if file.Parallelism > 0 {
+		settings.Parallelism = file.Parallelism
+	}
+	settings.Columns = file.Columns
+
+	if err = settings.parse(); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func (s *Settings) parse() error {
+	s.timeouts = make(map[string]time.Duration, len(s.Timeouts))
+	for operation, value := range s.Timeouts {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return fmt.Errorf("invalid timeout %q for %s", value, operation)
+		}
+		s.timeouts[operation] = timeout
+	}
+
+	s.refresh = make(map[string]time.Duration, len(s.Refresh))
+	for view, value := range s.Refresh {
+		if value == "off" || value == "0" {
+			s.refresh[view] = 0
+			continue
+		}
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval < time.Second {
+			return fmt.Errorf("invalid refresh interval %q for %s", value, view)
+		}
+		s.refresh[view] = interval
+	}
+
+	for view, presets := range s.Columns {
+		for name, preset := range presets {
+			if err := preset.validate(); err != nil {
+				return fmt.Errorf("invalid column preset %s of %s: %w", name, view, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (p ColumnPreset) validate() error {
+	titles := map[string]bool{}
+	for _, column := range p.Columns {
+		switch {
+		case column.Title == "" || column.Path == "":
+			return errors.New("every column needs a title and a path")
+		case titles[column.Title]:
+			return fmt.Errorf("column %s is defined twice", column.Title)
+		case column.Width < 0:
+			return fmt.Errorf("invalid width %d of column %s", column.Width, column.Title)
+		}
+		if _, err := utils.NamedFormatter(column.Format); err != nil {
+			return fmt.Errorf("column %s: %w", column.Title, err)
+		}
+		titles[column.Title] = true
+	}
+	return nil
+}
+
+// ColumnPresets returns the column presets of a view by preset name.
+func (s *Settings) ColumnPresets(view string) map[string]ColumnPreset {
+	if s == nil {
+		return nil
+	}
+	return s.Columns[view]
+}
+
+// Timeout returns the timeout of a kafkactl subcommand.
+func (s *Settings) Timeout(operation string) time.Duration {
+	if s == nil {
+		return DefaultTimeout
+	}
+	if timeout, ok := s.timeouts[operation]; ok {
+		return timeout
+	}
+	if timeout, ok := s.timeouts["default"]; ok {
+		return timeout
+	}
+	return DefaultTimeout
+}
+
+// RefreshInterval returns the auto-refresh interval of a view, or 0 if it
+// is not refreshed automatically.
+func (s *Settings) RefreshInterval(view string) time.Duration {
+	if s == nil {
+		return DefaultRefresh
+	}
+	if interval, ok := s.refresh[view]; ok {
+		return interval
+	}
+	if interval, ok := s.refresh["default"]; ok {
+		return interval
+	}
+	return DefaultRefresh
+}
+
+// SettingsDir returns the k4a directory holding the settings file and
+// other state, ~/.k4a unless K4A_HOME is set.
+func SettingsDir() string {
+	if dir := os.Getenv("K4A_HOME"); dir != "" {
+		return dir
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ".k4a"
+	}
+
+	return filepath.Join(homeDir, ".k4a")
+}
+
+func getSettingsPath() string {
+	// Check for environment variable override
+	if settingsPath := os.Getenv("K4A_CONFIG"); settingsPath != "" {
+		return settingsPath
+	}
+
+	return filepath.Join(SettingsDir(), "config.yml")
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// State is what k4a remembers between sessions, such as the sort order of
+// each view. It is written by k4a itself to ~/.k4a/state.yml, apart from
+// the settings users edit.
+type State struct {
+	// Sort holds the sort order of each view by view name
+	Sort map[string]SortOrder `yaml:"sort,omitempty"`
+}
+
+// SortOrder is the column a view is sorted by, by title, and its
+// direction.
+type SortOrder struct {
+	Column     string `yaml:"column"`
+	Descending bool   `yaml:"descending,omitempty"`
+}
+
+// LoadState reads the state file. A missing file yields an empty state.
+func LoadState() (*State, error) {
+	state := &State{Sort: map[string]SortOrder{}}
+
+	data, err := os.ReadFile(getStatePath())
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	if err = yaml.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse state: %w", err)
+	}
+	if state.Sort == nil {
+		state.Sort = map[string]SortOrder{}
+	}
+
+	return state, nil
+}
+
+// Save writes the state file.
+func (s *State) Save() error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	path := getStatePath()
+	if err = os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+
+	return nil
+}
+
+func getStatePath() string {
+	return filepath.Join(SettingsDir(), "state.yml")
+}
@@ -0,0 +1,83 @@
+package eventlog
+
+import (
+	"sync"
+	"time"
+)
+
+// MaxEntries is the number of entries a log keeps; older entries are
+// dropped first.
+const MaxEntries = 1000
+
+// Level tells events apart from errors.
+type Level int
+
+const (
+	Info Level = iota
+	Error
+)
+
+func (l Level) String() string {
+	if l == Error {
+		return "ERROR"
+	}
+	return "INFO"
+}
+
+// Entry is an event of the session: a kafkactl run or the outcome of an
+// action. Args, Stderr, ExitCode and Duration are only set for kafkactl
+// runs.
+type Entry struct {
+	Time     time.Time
+	Level    Level
+	Message  string
+	Args     []string
+	Stderr   string
+	ExitCode int
+	Duration time.Duration
+}
+
+// Log holds the events of the session. It is safe for concurrent use, as
+// kafkactl runs are logged from commands running in the background.
+type Log struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// New returns an empty log.
+func New() *Log {
+	return &Log{}
+}
+
+// Add appends an entry, stamping it with the current time if it has none.
+func (l *Log) Add(entry Entry) {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries = append(l.entries, entry)
+	if len(l.entries) > MaxEntries {
+		l.entries = append([]Entry(nil), l.entries[len(l.entries)-MaxEntries:]...)
+	}
+}
+
+// Info records an event that is not a kafkactl run.
+func (l *Log) Info(message string) {
+	l.Add(Entry{Level: Info, Message: message})
+}
+
+// Error records a failure that is not a kafkactl run.
+func (l *Log) Error(message string) {
+	l.Add(Entry{Level: Error, Message: message})
+}
+
+// Entries returns the entries, oldest first.
+func (l *Log) Entries() []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]Entry(nil), l.entries...)
+}
@@ -0,0 +1,46 @@
+package eventstream
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+)
+
+// Kinds are the kinds of resources a stream watches.
+var Kinds = []string{"topics", "connectors", "schemas", "consumer-groups"}
+
+// Poll lists the resources of a kind to observe. Consumer groups are
+// reduced to their state, members and topics, as their offsets move with
+// every record consumed.
+func Poll(ctx context.Context, client *kafkactl.Client, kind string) ([]map[string]any, error) {
+	switch kind {
+	case "topics", "connectors", "schemas":
+		// The manifests are observed as they are, so one that does not fit
+		// its model is still seen
+		return client.GetResources(ctx, kind)
+	case "consumer-groups":
+		groups, err := client.GetAllConsumerGroups(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		docs := make([]map[string]any, 0, len(groups))
+		for _, group := range groups {
+			topics := make([]any, 0, len(group.Status.Topics))
+			for _, topic := range group.Status.Topics {
+				topics = append(topics, topic)
+			}
+			docs = append(docs, map[string]any{
+				"metadata": map[string]any{"name": group.Metadata.Name},
+				"status": map[string]any{
+					"state":   group.Status.State,
+					"members": group.Status.Members,
+					"topics":  topics,
+				},
+			})
+		}
+		return docs, nil
+	}
+	return nil, fmt.Errorf("unknown kind %q", kind)
+}
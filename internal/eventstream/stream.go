@@ -0,0 +1,404 @@
+// Package eventstream records the changes observed between successive
+// listings of the resources of a namespace: resources created and deleted,
+// fields changed and state transitions. Events are kept in memory and,
+// per kafkactl context, in ~/.k4a/events, so they outlive the session.
+package eventstream
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/smart-fellas/k4a/internal/config"
+	"github.com/smart-fellas/k4a/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// MaxEvents is the number of events a stream keeps; older events are
+// dropped first. They are dropped from memory at once, and from disk when
+// the file is compacted, once it holds twice as many.
+const MaxEvents = 1000
+
+// Type tells apart the changes an event records.
+type Type string
+
+const (
+	Created Type = "created"
+	Deleted Type = "deleted"
+	Changed Type = "changed"
+	// StateChanged is a change of status.state, e.g. a connector going
+	// from RUNNING to FAILED.
+	StateChanged Type = "state"
+)
+
+// none stands for a field that a resource does not set.
+const none = "(none)"
+
+// volatile are the fields that change without the resource changing.
+var volatile = map[string]bool{
+	"metadata.creationTimestamp": true,
+	"metadata.generation":        true,
+	"metadata.resourceVersion":   true,
+	"status.lastUpdateTime":      true,
+}
+
+// Event is a change of one resource. Field, From and To are set for
+// changed fields and state transitions; Before and After hold the
+// manifests of the resource on both sides of the change, as YAML. The
+// events of the fields changed together share their manifests, which are
+// written to disk once.
+type Event struct {
+	Time   time.Time `json:"time"`
+	Kind   string    `json:"kind"`
+	Name   string    `json:"name"`
+	Type   Type      `json:"type"`
+	Field  string    `json:"field,omitempty"`
+	From   string    `json:"from,omitempty"`
+	To     string    `json:"to,omitempty"`
+	Before string    `json:"before,omitempty"`
+	After  string    `json:"after,omitempty"`
+}
+
+// Summary describes the change in a line, e.g. "spec.partitions changed
+// from 3 to 6".
+func (e Event) Summary() string {
+	switch e.Type {
+	case Created:
+		return "created"
+	case Deleted:
+		return "deleted"
+	case StateChanged:
+		return fmt.Sprintf("state %s → %s", e.From, e.To)
+	case Changed:
+	}
+	return fmt.Sprintf("%s changed from %s to %s", e.Field, e.From, e.To)
+}
+
+// Stream holds the events of a kafkactl context and the last listing of
+// each kind, to compare the next listing with. It is safe for concurrent
+// use, as listings are observed from commands running in the background.
+type Stream struct {
+	mu     sync.Mutex
+	path   string
+	events []Event
+	// written is the number of events in the file, including those
+	// dropped from memory since it was last compacted
+	written int
+	last    map[string]map[string]map[string]any
+}
+
+// New returns a stream that keeps its events in memory only.
+func New() *Stream {
+	return &Stream{last: map[string]map[string]map[string]any{}}
+}
+
+// Dir returns the directory of the event files, one per kafkactl context.
+func Dir() string {
+	return filepath.Join(config.SettingsDir(), "events")
+}
+
+// Open returns the stream of a kafkactl context with the events recorded
+// in earlier sessions. New events are appended to its file.
+func Open(context string) (*Stream, error) {
+	s := New()
+	s.path = filepath.Join(Dir(), utils.SafeFileName(context)+".jsonl")
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		var event Event
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		if err = json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("failed to parse events: %w", err)
+		}
+		// Only the first event of a change holds the manifests
+		if last := len(s.events) - 1; last >= 0 && sameChange(s.events[last], event) &&
+			event.Before == "" && event.After == "" {
+			event.Before, event.After = s.events[last].Before, s.events[last].After
+		}
+		s.events = append(s.events, event)
+	}
+	s.written = len(s.events)
+
+	if len(s.events) > MaxEvents {
+		s.events = slices.Clone(s.events[len(s.events)-MaxEvents:])
+		if err = s.rewrite(); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Events returns the events, oldest first.
+func (s *Stream) Events() []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.events)
+}
+
+// Observe compares a listing of a kind, e.g. "topics", with the previous
+// one and records an event per resource created or deleted and per field
+// changed. The first listing of a kind only sets the baseline. The events
+// are returned; the error reports a failure to write them to disk, in
+// which case they are still kept in memory.
+func (s *Stream) Observe(kind string, docs []map[string]any, now time.Time) ([]Event, error) {
+	current := make(map[string]map[string]any, len(docs))
+	for _, doc := range docs {
+		if name := name(doc); name != "" {
+			current[name] = doc
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, seen := s.last[kind]
+	s.last[kind] = current
+	if !seen {
+		return nil, nil
+	}
+
+	var events []Event
+	for _, name := range sortedNames(previous, current) {
+		before, existed := previous[name]
+		after, exists := current[name]
+		event := Event{Time: now, Kind: kind, Name: name}
+
+		switch {
+		case !existed:
+			event.Type = Created
+			event.After = manifest(after)
+			events = append(events, event)
+		case !exists:
+			event.Type = Deleted
+			event.Before = manifest(before)
+			events = append(events, event)
+		default:
+			events = append(events, fieldChanges(event, before, after)...)
+		}
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	s.events = append(s.events, events...)
+	if len(s.events) > MaxEvents {
+		s.events = slices.Clone(s.events[len(s.events)-MaxEvents:])
+	}
+
+	return events, s.append(events)
+}
+
+// fieldChanges returns an event per field that differs between two
+// manifests of a resource.
+func fieldChanges(event Event, before, after map[string]any) []Event {
+	old := flatten(before)
+	updated := flatten(after)
+	beforeManifest, afterManifest := "", ""
+
+	var fields []string
+	for field := range old {
+		fields = append(fields, field)
+	}
+	for field := range updated {
+		if _, ok := old[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	slices.Sort(fields)
+
+	var events []Event
+	for _, field := range fields {
+		from, hadField := old[field]
+		to, hasField := updated[field]
+		if volatile[field] || (hadField && hasField && reflect.DeepEqual(from, to)) {
+			continue
+		}
+
+		change := event
+		change.Type = Changed
+		if field == "status.state" {
+			change.Type = StateChanged
+		}
+		change.Field = field
+		change.From = display(from, hadField)
+		change.To = display(to, hasField)
+		if beforeManifest == "" {
+			beforeManifest, afterManifest = manifest(before), manifest(after)
+		}
+		change.Before = beforeManifest
+		change.After = afterManifest
+		events = append(events, change)
+	}
+	return events
+}
+
+// flatten maps the leaves of a manifest by their dotted path, with list
+// items as [i], e.g. status.tasks[0].state. Empty maps and lists are
+// leaves.
+func flatten(doc map[string]any) map[string]any {
+	leaves := map[string]any{}
+	var walk func(path string, value any)
+	walk = func(path string, value any) {
+		switch value := value.(type) {
+		case map[string]any:
+			if len(value) == 0 && path != "" {
+				leaves[path] = value
+			}
+			for key, child := range value {
+				if path == "" {
+					walk(key, child)
+				} else {
+					walk(path+"."+key, child)
+				}
+			}
+		case []any:
+			if len(value) == 0 {
+				leaves[path] = value
+			}
+			for i, child := range value {
+				walk(fmt.Sprintf("%s[%d]", path, i), child)
+			}
+		default:
+			leaves[path] = value
+		}
+	}
+	walk("", doc)
+	return leaves
+}
+
+func display(value any, set bool) string {
+	switch value := value.(type) {
+	case nil:
+		if !set {
+			return none
+		}
+		return "null"
+	case map[string]any:
+		return "{}"
+	case []any:
+		return "[]"
+	case string:
+		if value == "" {
+			return `""`
+		}
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func manifest(doc map[string]any) string {
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return ""
+	}
+	return b.String()
+}
+
+func name(doc map[string]any) string {
+	metadata, _ := doc["metadata"].(map[string]any)
+	name, _ := metadata["name"].(string)
+	return name
+}
+
+func sortedNames(previous, current map[string]map[string]any) []string {
+	names := make([]string, 0, len(previous)+len(current))
+	for name := range previous {
+		names = append(names, name)
+	}
+	for name := range current {
+		if _, ok := previous[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// sameChange reports whether two events are fields of the same change of
+// a resource.
+func sameChange(a, b Event) bool {
+	fieldChange := func(t Type) bool { return t == Changed || t == StateChanged }
+	return fieldChange(a.Type) && fieldChange(b.Type) && a.Time.Equal(b.Time) && a.Kind == b.Kind && a.Name == b.Name
+}
+
+// append writes events at the end of the stream's file, if it has one. A
+// file holding twice the events kept is compacted instead.
+func (s *Stream) append(events []Event) error {
+	if s.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create events directory: %w", err)
+	}
+	if s.written+len(events) > 2*MaxEvents {
+		return s.rewrite()
+	}
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open events file: %w", err)
+	}
+	defer file.Close()
+
+	data, err := encode(events)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(data); err != nil {
+		return fmt.Errorf("failed to write events: %w", err)
+	}
+	s.written += len(events)
+	return nil
+}
+
+// rewrite replaces the stream's file with the events kept in memory.
+func (s *Stream) rewrite() error {
+	data, err := encode(s.events)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write events: %w", err)
+	}
+	s.written = len(s.events)
+	return nil
+}
+
+// encode writes events as JSON lines, with the manifests of a change on
+// its first event only.
+func encode(events []Event) ([]byte, error) {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	for i, event := range events {
+		if i > 0 && sameChange(events[i-1], event) {
+			event.Before, event.After = "", ""
+		}
+		if err := encoder.Encode(event); err != nil {
+			return nil, fmt.Errorf("failed to encode event: %w", err)
+		}
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,521 @@
+package evolution
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Avro primitive types and the types each one is promoted to when read
+var avroPromotions = map[string][]string{
+	"null":    nil,
+	"boolean": nil,
+	"int":     {"long", "float", "double"},
+	"long":    {"float", "double"},
+	"float":   {"double"},
+	"double":  nil,
+	"bytes":   {"string"},
+	"string":  {"bytes"},
+}
+
+// avroSchema is a parsed Avro schema with its named types indexed by full
+// and short name.
+type avroSchema struct {
+	root  any
+	names map[string]map[string]any
+}
+
+type avroAnalyzer struct {
+	older, newer *avroSchema
+	changes      []Change
+	// Record pairs already compared, for recursive types
+	seen map[string]bool
+}
+
+func analyzeAvro(older, newer string) ([]Change, error) {
+	o, err := parseAvro(older)
+	if err != nil {
+		return nil, fmt.Errorf("old schema: %w", err)
+	}
+	n, err := parseAvro(newer)
+	if err != nil {
+		return nil, fmt.Errorf("new schema: %w", err)
+	}
+
+	a := &avroAnalyzer{older: o, newer: n, seen: map[string]bool{}}
+	a.compareType("", o.root, n.root)
+	return a.changes, nil
+}
+
+func parseAvro(schema string) (*avroSchema, error) {
+	var root any
+	if err := json.Unmarshal([]byte(schema), &root); err != nil {
+		return nil, fmt.Errorf("invalid Avro schema: %w", err)
+	}
+
+	s := &avroSchema{root: root, names: map[string]map[string]any{}}
+	s.index(root, "")
+	return s, nil
+}
+
+// index records the named types defined in t.
+func (s *avroSchema) index(t any, namespace string) {
+	switch t := t.(type) {
+	case []any:
+		for _, branch := range t {
+			s.index(branch, namespace)
+		}
+	case map[string]any:
+		name, _ := t["name"].(string)
+		if ns, ok := t["namespace"].(string); ok {
+			namespace = ns
+		}
+		switch t["type"] {
+		case "record", "error", "enum", "fixed":
+			full := name
+			if !strings.Contains(name, ".") && namespace != "" {
+				full = namespace + "." + name
+			}
+			if i := strings.LastIndex(full, "."); i >= 0 {
+				namespace = full[:i]
+			}
+			s.names[full] = t
+			s.names[shortName(full)] = t
+		}
+		for _, field := range fields(t) {
+			s.index(field["type"], namespace)
+		}
+		s.index(t["items"], namespace)
+		s.index(t["values"], namespace)
+	}
+}
+
+// resolve returns the definition of a named type reference and unwraps
+// primitive types written as {"type": "int"}.
+func (s *avroSchema) resolve(t any) any {
+	switch v := t.(type) {
+	case string:
+		if def, ok := s.names[v]; ok {
+			return def
+		}
+		if def, ok := s.names[shortName(v)]; ok {
+			return def
+		}
+	case map[string]any:
+		if inner, ok := v["type"].(string); ok && v["logicalType"] == nil {
+			if _, primitive := avroPromotions[inner]; primitive {
+				return inner
+			}
+		}
+	}
+	return t
+}
+
+func (a *avroAnalyzer) add(change Change) {
+	a.changes = append(a.changes, change)
+}
+
+// compareType compares the old and new type of the value at path.
+func (a *avroAnalyzer) compareType(path string, older, newer any) {
+	o, n := a.older.resolve(older), a.newer.resolve(newer)
+
+	_, oldUnion := o.([]any)
+	_, newUnion := n.([]any)
+	if oldUnion || newUnion {
+		a.compareUnion(path, o, n)
+		return
+	}
+
+	om, oIsMap := o.(map[string]any)
+	nm, nIsMap := n.(map[string]any)
+	if oIsMap && nIsMap && om["type"] == nm["type"] {
+		switch om["type"] {
+		case "record", "error":
+			// A recursive record refers to itself at ever longer paths,
+			// so each pair of names is compared once
+			key := fmt.Sprint(om["name"]) + "|" + fmt.Sprint(nm["name"])
+			if a.seen[key] {
+				return
+			}
+			a.seen[key] = true
+			a.compareName(path, om, nm)
+			a.compareRecord(path, om, nm)
+			return
+		case "enum":
+			a.compareName(path, om, nm)
+			a.compareEnum(path, om, nm)
+			return
+		case "array":
+			a.compareType(path+"[]", om["items"], nm["items"])
+			return
+		case "map":
+			a.compareType(path+"{}", om["values"], nm["values"])
+			return
+		}
+	}
+
+	oldType, newType := a.older.typeName(o), a.newer.typeName(n)
+	if oldType == newType {
+		return
+	}
+	a.add(Change{
+		Kind:          TypeChanged,
+		Path:          path,
+		Old:           oldType,
+		New:           newType,
+		Compatibility: a.resolution(o, n),
+	})
+}
+
+// compareUnion compares the branches of a union that appear in both
+// versions and reports a type change when branches were added or removed.
+func (a *avroAnalyzer) compareUnion(path string, older, newer any) {
+	oldBranches, newBranches := branches(older), branches(newer)
+
+	for _, ob := range oldBranches {
+		for _, nb := range newBranches {
+			if a.older.branchKey(ob) == a.newer.branchKey(nb) {
+				a.compareType(path, ob, nb)
+			}
+		}
+	}
+
+	oldKeys, newKeys := []string{}, []string{}
+	for _, b := range oldBranches {
+		oldKeys = append(oldKeys, a.older.branchKey(b))
+	}
+	for _, b := range newBranches {
+		newKeys = append(newKeys, a.newer.branchKey(b))
+	}
+	slices.Sort(oldKeys)
+	slices.Sort(newKeys)
+	if slices.Equal(oldKeys, newKeys) {
+		return
+	}
+
+	a.add(Change{
+		Kind:          TypeChanged,
+		Path:          path,
+		Old:           a.older.typeName(older),
+		New:           a.newer.typeName(newer),
+		Compatibility: a.resolution(a.older.resolve(older), a.newer.resolve(newer)),
+	})
+}
+
+// compareName reports a record or enum whose name changed. Readers only
+// resolve a named type of another name listed in their aliases.
+func (a *avroAnalyzer) compareName(path string, older, newer map[string]any) {
+	oldName, newName := shortName(fmt.Sprint(older["name"])), shortName(fmt.Sprint(newer["name"]))
+	if oldName == newName {
+		return
+	}
+	a.add(Change{
+		Kind:          TypeChanged,
+		Path:          path,
+		Old:           oldName,
+		New:           newName,
+		Compatibility: a.resolution(older, newer),
+	})
+}
+
+func (a *avroAnalyzer) compareRecord(path string, older, newer map[string]any) {
+	oldFields, newFields := fields(older), fields(newer)
+	matched := map[string]bool{}
+
+	for _, nf := range newFields {
+		name, _ := nf["name"].(string)
+
+		of, renamed := findField(oldFields, name), false
+		if of == nil {
+			// A field renamed in Avro keeps its old name as an alias
+			for _, alias := range aliases(nf) {
+				if of = findField(oldFields, alias); of != nil {
+					renamed = true
+					break
+				}
+			}
+		}
+
+		if of == nil {
+			compatibility := Forward
+			if _, ok := nf["default"]; ok {
+				compatibility = Full
+			}
+			a.add(Change{
+				Kind:          FieldAdded,
+				Path:          joinPath(path, name),
+				New:           a.newer.fieldSummary(nf),
+				Compatibility: compatibility,
+			})
+			continue
+		}
+
+		oldName, _ := of["name"].(string)
+		matched[oldName] = true
+		if renamed {
+			// Old readers do not know the new name and fall back to the
+			// default of the old field
+			compatibility := Backward
+			if _, ok := of["default"]; ok {
+				compatibility = Full
+			}
+			a.add(Change{
+				Kind:          FieldRenamed,
+				Path:          joinPath(path, name),
+				Old:           joinPath(path, oldName),
+				New:           joinPath(path, name),
+				Compatibility: compatibility,
+			})
+		}
+		a.compareField(joinPath(path, name), of, nf)
+	}
+
+	for _, of := range oldFields {
+		name, _ := of["name"].(string)
+		if matched[name] {
+			continue
+		}
+		compatibility := Backward
+		if _, ok := of["default"]; ok {
+			compatibility = Full
+		}
+		a.add(Change{
+			Kind:          FieldRemoved,
+			Path:          joinPath(path, name),
+			Old:           a.older.fieldSummary(of),
+			Compatibility: compatibility,
+		})
+	}
+}
+
+func (a *avroAnalyzer) compareField(path string, older, newer map[string]any) {
+	a.compareType(path, older["type"], newer["type"])
+
+	oldDefault, oldHas := older["default"]
+	newDefault, newHas := newer["default"]
+	if oldHas != newHas || (oldHas && jsonString(oldDefault) != jsonString(newDefault)) {
+		change := Change{Kind: DefaultChanged, Path: path, Compatibility: Full}
+		if oldHas {
+			change.Old = jsonString(oldDefault)
+		}
+		if newHas {
+			change.New = jsonString(newDefault)
+		}
+		a.add(change)
+	}
+
+	oldDoc, _ := older["doc"].(string)
+	newDoc, _ := newer["doc"].(string)
+	if oldDoc != newDoc {
+		a.add(Change{Kind: DocChanged, Path: path, Old: oldDoc, New: newDoc, Compatibility: Full})
+	}
+}
+
+// compareEnum reports added and removed symbols. A reader meets symbols
+// it does not know unless it declares a default symbol.
+func (a *avroAnalyzer) compareEnum(path string, older, newer map[string]any) {
+	oldSymbols, newSymbols := symbols(older), symbols(newer)
+	if slices.Equal(oldSymbols, newSymbols) {
+		return
+	}
+
+	compatibility := Full
+	for _, symbol := range newSymbols {
+		if !slices.Contains(oldSymbols, symbol) && older["default"] == nil {
+			compatibility &^= Forward
+		}
+	}
+	for _, symbol := range oldSymbols {
+		if !slices.Contains(newSymbols, symbol) && newer["default"] == nil {
+			compatibility &^= Backward
+		}
+	}
+
+	a.add(Change{
+		Kind:          SymbolsChanged,
+		Path:          path,
+		Old:           strings.Join(oldSymbols, ","),
+		New:           strings.Join(newSymbols, ","),
+		Compatibility: compatibility,
+	})
+}
+
+// resolution returns in which directions data of one type is readable as
+// the other.
+func (a *avroAnalyzer) resolution(older, newer any) Compatibility {
+	compatibility := None
+	if readable(a.older, older, a.newer, newer) {
+		compatibility |= Backward
+	}
+	if readable(a.newer, newer, a.older, older) {
+		compatibility |= Forward
+	}
+	return compatibility
+}
+
+// readable reports whether data written with the writer type can be read
+// with the reader type, following the Avro schema resolution rules.
+func readable(ws *avroSchema, writer any, rs *avroSchema, reader any) bool {
+	writer, reader = ws.resolve(writer), rs.resolve(reader)
+
+	if branches, ok := writer.([]any); ok {
+		for _, branch := range branches {
+			if !readable(ws, branch, rs, reader) {
+				return false
+			}
+		}
+		return true
+	}
+	if branches, ok := reader.([]any); ok {
+		for _, branch := range branches {
+			if readable(ws, writer, rs, branch) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if w, ok := writer.(string); ok {
+		r, ok := reader.(string)
+		return ok && (w == r || slices.Contains(avroPromotions[w], r))
+	}
+
+	w, wok := writer.(map[string]any)
+	r, rok := reader.(map[string]any)
+	if !wok || !rok {
+		return false
+	}
+	if w["logicalType"] != nil || r["logicalType"] != nil {
+		return ws.typeName(w) == rs.typeName(r)
+	}
+	if w["type"] != r["type"] {
+		return false
+	}
+
+	switch w["type"] {
+	case "array":
+		return readable(ws, w["items"], rs, r["items"])
+	case "map":
+		return readable(ws, w["values"], rs, r["values"])
+	case "fixed":
+		return shortName(fmt.Sprint(w["name"])) == shortName(fmt.Sprint(r["name"])) && jsonString(w["size"]) == jsonString(r["size"])
+	default:
+		// Named types match by name; their fields are compared separately
+		name := shortName(fmt.Sprint(w["name"]))
+		return name == shortName(fmt.Sprint(r["name"])) ||
+			slices.ContainsFunc(aliases(r), func(alias string) bool { return shortName(alias) == name })
+	}
+}
+
+// typeName renders a type the way it is usually written, such as
+// "int", "array<string>" or "null|Address".
+func (s *avroSchema) typeName(t any) string {
+	switch v := s.resolve(t).(type) {
+	case string:
+		return v
+	case []any:
+		names := make([]string, 0, len(v))
+		for _, branch := range v {
+			names = append(names, s.typeName(branch))
+		}
+		return strings.Join(names, "|")
+	case map[string]any:
+		if logical, ok := v["logicalType"].(string); ok {
+			return fmt.Sprintf("%v(%s)", v["type"], logical)
+		}
+		switch v["type"] {
+		case "array":
+			return "array<" + s.typeName(v["items"]) + ">"
+		case "map":
+			return "map<" + s.typeName(v["values"]) + ">"
+		case "fixed":
+			return fmt.Sprintf("fixed(%v)", v["size"])
+		default:
+			return shortName(fmt.Sprint(v["name"]))
+		}
+	}
+	return jsonString(t)
+}
+
+// branchKey identifies a union branch; Avro allows one branch per
+// primitive, container or named type.
+func (s *avroSchema) branchKey(t any) string {
+	switch v := s.resolve(t).(type) {
+	case map[string]any:
+		switch v["type"] {
+		case "array", "map":
+			return fmt.Sprint(v["type"])
+		}
+	}
+	return s.typeName(t)
+}
+
+func (s *avroSchema) fieldSummary(field map[string]any) string {
+	summary := s.typeName(field["type"])
+	if def, ok := field["default"]; ok {
+		summary += ", default " + jsonString(def)
+	}
+	return summary
+}
+
+func branches(t any) []any {
+	if branches, ok := t.([]any); ok {
+		return branches
+	}
+	return []any{t}
+}
+
+func fields(record map[string]any) []map[string]any {
+	list, _ := record["fields"].([]any)
+	fields := make([]map[string]any, 0, len(list))
+	for _, field := range list {
+		if field, ok := field.(map[string]any); ok {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func findField(fields []map[string]any, name string) map[string]any {
+	for _, field := range fields {
+		if field["name"] == name {
+			return field
+		}
+	}
+	return nil
+}
+
+func aliases(t map[string]any) []string {
+	return stringList(t["aliases"])
+}
+
+func symbols(enum map[string]any) []string {
+	return stringList(enum["symbols"])
+}
+
+func stringList(v any) []string {
+	list, _ := v.([]any)
+	strs := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
+
+func shortName(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+func jsonString(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
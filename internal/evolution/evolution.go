@@ -0,0 +1,157 @@
+// Package evolution compares two versions of a schema field by field and
+// classifies each change by the compatibility it keeps, the way a Schema
+// Registry would, without needing one.
+package evolution
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind is the kind of a schema change.
+type Kind string
+
+const (
+	FieldAdded      Kind = "added"
+	FieldRemoved    Kind = "removed"
+	FieldRenamed    Kind = "renamed"
+	TypeChanged     Kind = "type"
+	DefaultChanged  Kind = "default"
+	DocChanged      Kind = "doc"
+	RequiredChanged Kind = "required"
+	SymbolsChanged  Kind = "symbols"
+)
+
+// Compatibility is the direction in which data stays readable across a
+// change. Backward means the new schema reads data written with the old
+// one; forward means the old schema reads data written with the new one.
+type Compatibility int
+
+const (
+	None     Compatibility = 0
+	Backward Compatibility = 1
+	Forward  Compatibility = 2
+	Full                   = Backward | Forward
+)
+
+func (c Compatibility) String() string {
+	switch c {
+	case Full:
+		return "FULL"
+	case Backward:
+		return "BACKWARD"
+	case Forward:
+		return "FORWARD"
+	default:
+		return "NONE"
+	}
+}
+
+// Change is a single difference between two versions of a schema. Path
+// is the dotted path of the field; Old and New describe it before and
+// after the change.
+type Change struct {
+	Kind          Kind
+	Path          string
+	Old           string
+	New           string
+	Compatibility Compatibility
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case FieldAdded:
+		return fmt.Sprintf("%s added (%s)", c.Path, c.New)
+	case FieldRemoved:
+		return fmt.Sprintf("%s removed (%s)", c.Path, c.Old)
+	case FieldRenamed:
+		return fmt.Sprintf("%s renamed to %s", c.Old, c.New)
+	case DocChanged:
+		return fmt.Sprintf("%s doc changed", c.Path)
+	case TypeChanged:
+		if c.Path == "" {
+			// A change of the top-level type, e.g. a renamed record
+			return fmt.Sprintf("schema type changed: %s → %s", orNone(c.Old), orNone(c.New))
+		}
+		return fmt.Sprintf("%s type changed: %s → %s", c.Path, orNone(c.Old), orNone(c.New))
+	default:
+		return fmt.Sprintf("%s %s changed: %s → %s", c.Path, c.Kind, orNone(c.Old), orNone(c.New))
+	}
+}
+
+// Report lists the changes between two versions of a schema.
+type Report struct {
+	Changes []Change
+}
+
+// Compatibility returns the compatibility kept by every change.
+func (r Report) Compatibility() Compatibility {
+	compatibility := Full
+	for _, change := range r.Changes {
+		compatibility &= change.Compatibility
+	}
+	return compatibility
+}
+
+// Accepts reports whether a subject configured with the given
+// compatibility level, such as BACKWARD or FULL_TRANSITIVE, would register
+// the new version. Transitive levels are checked against the compared
+// version only.
+func (r Report) Accepts(level string) bool {
+	required := Required(level)
+	return r.Compatibility()&required == required
+}
+
+// Required returns the compatibility a Schema Registry compatibility level
+// requires. Unknown levels are treated as the registry default, BACKWARD.
+func Required(level string) Compatibility {
+	switch strings.TrimSuffix(strings.ToUpper(level), "_TRANSITIVE") {
+	case "NONE":
+		return None
+	case "FORWARD":
+		return Forward
+	case "FULL":
+		return Full
+	default:
+		return Backward
+	}
+}
+
+// Analyze compares two versions of a schema of the given type, AVRO (the
+// default), JSON or PROTOBUF.
+func Analyze(schemaType, older, newer string) (Report, error) {
+	var (
+		changes []Change
+		err     error
+	)
+
+	switch strings.ToUpper(schemaType) {
+	case "", "AVRO":
+		changes, err = analyzeAvro(older, newer)
+	case "JSON":
+		changes, err = analyzeJSON(older, newer)
+	case "PROTOBUF":
+		changes, err = analyzeProtobuf(older, newer)
+	default:
+		return Report{}, fmt.Errorf("unsupported schema type %s", schemaType)
+	}
+	if err != nil {
+		return Report{}, err
+	}
+
+	return Report{Changes: changes}, nil
+}
+
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
@@ -0,0 +1,319 @@
+package evolution
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type jsonAnalyzer struct {
+	olderRoot, newerRoot map[string]any
+	changes              []Change
+	// Definitions already compared, for recursive $refs
+	seen map[string]bool
+}
+
+func analyzeJSON(older, newer string) ([]Change, error) {
+	var o, n map[string]any
+	if err := json.Unmarshal([]byte(older), &o); err != nil {
+		return nil, fmt.Errorf("old schema: invalid JSON Schema: %w", err)
+	}
+	if err := json.Unmarshal([]byte(newer), &n); err != nil {
+		return nil, fmt.Errorf("new schema: invalid JSON Schema: %w", err)
+	}
+
+	a := &jsonAnalyzer{olderRoot: o, newerRoot: n, seen: map[string]bool{}}
+	a.compareNode("", o, n)
+	return a.changes, nil
+}
+
+func (a *jsonAnalyzer) add(change Change) {
+	a.changes = append(a.changes, change)
+}
+
+// compareNode compares the old and new schema of the value at path.
+func (a *jsonAnalyzer) compareNode(path string, older, newer map[string]any) {
+	oldRef, _ := older["$ref"].(string)
+	newRef, _ := newer["$ref"].(string)
+	if oldRef != "" || newRef != "" {
+		// A recursive $ref comes back at ever longer paths, so each pair
+		// of references is compared once
+		key := oldRef + "|" + newRef
+		if a.seen[key] {
+			return
+		}
+		a.seen[key] = true
+	}
+	older, newer = resolveRef(a.olderRoot, older), resolveRef(a.newerRoot, newer)
+
+	oldTypes, newTypes := jsonTypes(older), jsonTypes(newer)
+	switch {
+	case slices.Equal(oldTypes, newTypes) && slices.Contains(oldTypes, "object"):
+		a.compareObject(path, older, newer)
+	case slices.Equal(oldTypes, newTypes) && slices.Contains(oldTypes, "array"):
+		oldItems, _ := older["items"].(map[string]any)
+		newItems, _ := newer["items"].(map[string]any)
+		if oldItems != nil && newItems != nil {
+			a.compareNode(path+"[]", oldItems, newItems)
+		}
+	case !slices.Equal(oldTypes, newTypes):
+		// A schema accepts more values when it allows more types; integer
+		// values are numbers too
+		compatibility := None
+		if typesAccept(newTypes, oldTypes) {
+			compatibility |= Backward
+		}
+		if typesAccept(oldTypes, newTypes) {
+			compatibility |= Forward
+		}
+		a.add(Change{
+			Kind:          TypeChanged,
+			Path:          path,
+			Old:           strings.Join(oldTypes, "|"),
+			New:           strings.Join(newTypes, "|"),
+			Compatibility: compatibility,
+		})
+	}
+
+	a.compareEnum(path, older, newer)
+}
+
+func (a *jsonAnalyzer) compareObject(path string, older, newer map[string]any) {
+	oldProps, _ := older["properties"].(map[string]any)
+	newProps, _ := newer["properties"].(map[string]any)
+	oldRequired, newRequired := stringList(older["required"]), stringList(newer["required"])
+
+	for _, name := range sortedKeys(newProps) {
+		newProp, _ := newProps[name].(map[string]any)
+		fieldPath := joinPath(path, name)
+
+		oldProp, ok := oldProps[name].(map[string]any)
+		if !ok {
+			// Data written before the property existed lacks it, or holds
+			// it as an additional property when the old model allowed one
+			propTypes := jsonTypes(resolveRef(a.newerRoot, newProp))
+			oldExtra, oldClosed := additionalTypes(a.olderRoot, older)
+			compatibility := None
+			if !slices.Contains(newRequired, name) && (oldClosed || typesAccept(propTypes, oldExtra)) {
+				compatibility |= Backward
+			}
+			if !oldClosed && typesAccept(oldExtra, propTypes) {
+				compatibility |= Forward
+			}
+			a.add(Change{
+				Kind:          FieldAdded,
+				Path:          fieldPath,
+				New:           a.summary(a.newerRoot, newProp),
+				Compatibility: compatibility,
+			})
+			continue
+		}
+
+		a.compareNode(fieldPath, oldProp, newProp)
+		a.compareAnnotations(fieldPath, oldProp, newProp)
+
+		wasRequired, isRequired := slices.Contains(oldRequired, name), slices.Contains(newRequired, name)
+		if wasRequired != isRequired {
+			compatibility := Backward
+			if isRequired {
+				compatibility = Forward
+			}
+			a.add(Change{
+				Kind:          RequiredChanged,
+				Path:          fieldPath,
+				Old:           fmt.Sprint(wasRequired),
+				New:           fmt.Sprint(isRequired),
+				Compatibility: compatibility,
+			})
+		}
+	}
+
+	for _, name := range sortedKeys(oldProps) {
+		if _, ok := newProps[name]; ok {
+			continue
+		}
+		oldProp, _ := oldProps[name].(map[string]any)
+		// New readers take the property in old data for an additional one;
+		// old readers reject new data that lacks it when it was required,
+		// or that holds it with values it did not allow
+		propTypes := jsonTypes(resolveRef(a.olderRoot, oldProp))
+		newExtra, newClosed := additionalTypes(a.newerRoot, newer)
+		compatibility := None
+		if !newClosed && typesAccept(newExtra, propTypes) {
+			compatibility |= Backward
+		}
+		if !slices.Contains(oldRequired, name) && (newClosed || typesAccept(propTypes, newExtra)) {
+			compatibility |= Forward
+		}
+		a.add(Change{
+			Kind:          FieldRemoved,
+			Path:          joinPath(path, name),
+			Old:           a.summary(a.olderRoot, oldProp),
+			Compatibility: compatibility,
+		})
+	}
+}
+
+// compareAnnotations reports default and description changes, which do not
+// affect which values are valid.
+func (a *jsonAnalyzer) compareAnnotations(path string, older, newer map[string]any) {
+	oldDefault, oldHas := older["default"]
+	newDefault, newHas := newer["default"]
+	if oldHas != newHas || (oldHas && jsonString(oldDefault) != jsonString(newDefault)) {
+		change := Change{Kind: DefaultChanged, Path: path, Compatibility: Full}
+		if oldHas {
+			change.Old = jsonString(oldDefault)
+		}
+		if newHas {
+			change.New = jsonString(newDefault)
+		}
+		a.add(change)
+	}
+
+	oldDoc, _ := older["description"].(string)
+	newDoc, _ := newer["description"].(string)
+	if oldDoc != newDoc {
+		a.add(Change{Kind: DocChanged, Path: path, Old: oldDoc, New: newDoc, Compatibility: Full})
+	}
+}
+
+// compareEnum reports added and removed allowed values.
+func (a *jsonAnalyzer) compareEnum(path string, older, newer map[string]any) {
+	oldValues, newValues := enumValues(older), enumValues(newer)
+	if slices.Equal(oldValues, newValues) {
+		return
+	}
+
+	compatibility := Full
+	if oldValues == nil || (newValues != nil && !isSubset(oldValues, newValues)) {
+		compatibility &^= Backward
+	}
+	if newValues == nil || (oldValues != nil && !isSubset(newValues, oldValues)) {
+		compatibility &^= Forward
+	}
+
+	a.add(Change{
+		Kind:          SymbolsChanged,
+		Path:          path,
+		Old:           strings.Join(oldValues, ","),
+		New:           strings.Join(newValues, ","),
+		Compatibility: compatibility,
+	})
+}
+
+func (a *jsonAnalyzer) summary(root, node map[string]any) string {
+	node = resolveRef(root, node)
+	summary := strings.Join(jsonTypes(node), "|")
+	if summary == "" {
+		summary = "any"
+	}
+	if def, ok := node["default"]; ok {
+		summary += ", default " + jsonString(def)
+	}
+	return summary
+}
+
+// resolveRef follows a local $ref such as #/definitions/Address.
+func resolveRef(root, node map[string]any) map[string]any {
+	for range 10 {
+		ref, ok := node["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#") {
+			return node
+		}
+
+		var target any = root
+		for part := range strings.SplitSeq(strings.TrimPrefix(strings.TrimPrefix(ref, "#"), "/"), "/") {
+			if part == "" {
+				continue
+			}
+			m, _ := target.(map[string]any)
+			target = m[strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")]
+		}
+
+		resolved, ok := target.(map[string]any)
+		if !ok {
+			return node
+		}
+		node = resolved
+	}
+	return node
+}
+
+// jsonTypes returns the sorted types allowed by a schema, inferring object
+// from properties.
+func jsonTypes(node map[string]any) []string {
+	var types []string
+	switch t := node["type"].(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		types = stringList(t)
+	default:
+		if _, ok := node["properties"]; ok {
+			types = []string{"object"}
+		}
+	}
+	slices.Sort(types)
+	return types
+}
+
+// additionalTypes returns the types an object schema allows for the
+// properties it does not declare, none meaning any, and whether it is a
+// closed content model that rejects them.
+func additionalTypes(root, node map[string]any) ([]string, bool) {
+	switch extra := node["additionalProperties"].(type) {
+	case bool:
+		return nil, !extra
+	case map[string]any:
+		return jsonTypes(resolveRef(root, extra)), false
+	}
+	return nil, false
+}
+
+// typesAccept reports whether a schema allowing the reader types accepts
+// every value of the writer types. No types means any value.
+func typesAccept(reader, writer []string) bool {
+	if len(reader) == 0 {
+		return true
+	}
+	if len(writer) == 0 {
+		return false
+	}
+	for _, t := range writer {
+		if !slices.Contains(reader, t) && (t != "integer" || !slices.Contains(reader, "number")) {
+			return false
+		}
+	}
+	return true
+}
+
+func enumValues(node map[string]any) []string {
+	list, ok := node["enum"].([]any)
+	if !ok {
+		return nil
+	}
+	values := make([]string, 0, len(list))
+	for _, value := range list {
+		values = append(values, jsonString(value))
+	}
+	return values
+}
+
+func isSubset(subset, set []string) bool {
+	for _, value := range subset {
+		if !slices.Contains(set, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
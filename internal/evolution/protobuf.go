@@ -0,0 +1,513 @@
+package evolution
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Scalar types sharing a wire encoding; changing a field between types of
+// the same group keeps it readable
+var protoWireGroups = [][]string{
+	{"int32", "uint32", "int64", "uint64", "bool"},
+	{"sint32", "sint64"},
+	{"fixed32", "sfixed32"},
+	{"fixed64", "sfixed64"},
+	{"string", "bytes"},
+}
+
+type protoField struct {
+	name     string
+	typ      string
+	label    string
+	number   int
+	def      string
+	hasDef   bool
+	doc      string
+	repeated bool
+}
+
+type protoMessage struct {
+	fields map[int]protoField
+}
+
+type protoFile struct {
+	messages map[string]protoMessage
+	enums    map[string][]string
+}
+
+func analyzeProtobuf(older, newer string) ([]Change, error) {
+	o, err := parseProto(older)
+	if err != nil {
+		return nil, fmt.Errorf("old schema: %w", err)
+	}
+	n, err := parseProto(newer)
+	if err != nil {
+		return nil, fmt.Errorf("new schema: %w", err)
+	}
+
+	var changes []Change
+
+	for _, name := range sortedNames(o.messages, n.messages) {
+		oldMsg, inOld := o.messages[name]
+		newMsg, inNew := n.messages[name]
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Kind: FieldAdded, Path: name, New: "message", Compatibility: Full})
+		case !inNew:
+			// Fields of the removed message no longer resolve
+			changes = append(changes, Change{Kind: FieldRemoved, Path: name, Old: "message", Compatibility: None})
+		default:
+			changes = append(changes, compareMessage(name, oldMsg, newMsg)...)
+		}
+	}
+
+	for _, name := range sortedNames(o.enums, n.enums) {
+		oldValues, newValues := o.enums[name], n.enums[name]
+		if !slices.Equal(oldValues, newValues) {
+			// Unknown enum values are kept as numbers by readers
+			changes = append(changes, Change{
+				Kind:          SymbolsChanged,
+				Path:          name,
+				Old:           strings.Join(oldValues, ","),
+				New:           strings.Join(newValues, ","),
+				Compatibility: Full,
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+// compareMessage compares the fields of a message by number, as they are
+// identified on the wire.
+func compareMessage(name string, older, newer protoMessage) []Change {
+	numbers := []int{}
+	for number := range older.fields {
+		numbers = append(numbers, number)
+	}
+	for number := range newer.fields {
+		if _, ok := older.fields[number]; !ok {
+			numbers = append(numbers, number)
+		}
+	}
+	slices.Sort(numbers)
+
+	var changes []Change
+	for _, number := range numbers {
+		of, inOld := older.fields[number]
+		nf, inNew := newer.fields[number]
+
+		switch {
+		case !inOld:
+			compatibility := Full
+			if nf.label == "required" {
+				compatibility = Forward
+			}
+			changes = append(changes, Change{
+				Kind:          FieldAdded,
+				Path:          joinPath(name, nf.name),
+				New:           nf.summary(),
+				Compatibility: compatibility,
+			})
+			continue
+		case !inNew:
+			compatibility := Full
+			if of.label == "required" {
+				compatibility = Backward
+			}
+			changes = append(changes, Change{
+				Kind:          FieldRemoved,
+				Path:          joinPath(name, of.name),
+				Old:           of.summary(),
+				Compatibility: compatibility,
+			})
+			continue
+		}
+
+		path := joinPath(name, nf.name)
+		if of.name != nf.name {
+			changes = append(changes, Change{
+				Kind:          FieldRenamed,
+				Path:          path,
+				Old:           joinPath(name, of.name),
+				New:           path,
+				Compatibility: Full,
+			})
+		}
+
+		if of.typ != nf.typ || of.repeated != nf.repeated {
+			compatibility := None
+			if of.repeated == nf.repeated && sameWireGroup(of.typ, nf.typ) {
+				compatibility = Full
+			}
+			changes = append(changes, Change{
+				Kind:          TypeChanged,
+				Path:          path,
+				Old:           of.typeName(),
+				New:           nf.typeName(),
+				Compatibility: compatibility,
+			})
+		}
+
+		if (of.label == "required") != (nf.label == "required") {
+			compatibility := Backward
+			if nf.label == "required" {
+				compatibility = Forward
+			}
+			changes = append(changes, Change{
+				Kind:          RequiredChanged,
+				Path:          path,
+				Old:           strconv.FormatBool(of.label == "required"),
+				New:           strconv.FormatBool(nf.label == "required"),
+				Compatibility: compatibility,
+			})
+		}
+
+		if of.hasDef != nf.hasDef || of.def != nf.def {
+			changes = append(changes, Change{Kind: DefaultChanged, Path: path, Old: of.def, New: nf.def, Compatibility: Full})
+		}
+
+		if of.doc != nf.doc {
+			changes = append(changes, Change{Kind: DocChanged, Path: path, Old: of.doc, New: nf.doc, Compatibility: Full})
+		}
+	}
+
+	return changes
+}
+
+func (f protoField) typeName() string {
+	if f.repeated {
+		return "repeated " + f.typ
+	}
+	return f.typ
+}
+
+func (f protoField) summary() string {
+	summary := fmt.Sprintf("%s = %d", f.typeName(), f.number)
+	if f.label == "required" {
+		summary = "required " + summary
+	}
+	return summary
+}
+
+func sameWireGroup(a, b string) bool {
+	for _, group := range protoWireGroups {
+		if slices.Contains(group, a) && slices.Contains(group, b) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedNames[T any](a, b map[string]T) []string {
+	names := []string{}
+	for name := range a {
+		names = append(names, name)
+	}
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// protoToken is a token of a .proto file with the comments written just
+// before it.
+type protoToken struct {
+	text string
+	doc  string
+}
+
+// protoParser reads the messages, fields and enums of a .proto file. It
+// skips services, options and other statements it does not need.
+type protoParser struct {
+	tokens []protoToken
+	pos    int
+	file   protoFile
+}
+
+func parseProto(schema string) (protoFile, error) {
+	p := &protoParser{
+		tokens: tokenizeProto(schema),
+		file:   protoFile{messages: map[string]protoMessage{}, enums: map[string][]string{}},
+	}
+
+	for !p.done() {
+		if err := p.statement(""); err != nil {
+			return protoFile{}, fmt.Errorf("invalid Protobuf schema: %w", err)
+		}
+	}
+	return p.file, nil
+}
+
+func (p *protoParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *protoParser) peek() protoToken {
+	if p.done() {
+		return protoToken{}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *protoParser) next() protoToken {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *protoParser) expect(text string) error {
+	if token := p.next(); token.text != text {
+		return fmt.Errorf("expected %q, found %q", text, token.text)
+	}
+	return nil
+}
+
+// skipStatement skips to the end of a statement or block.
+func (p *protoParser) skipStatement() {
+	depth := 0
+	for !p.done() {
+		switch p.next().text {
+		case "{":
+			depth++
+		case "}":
+			depth--
+			if depth <= 0 {
+				return
+			}
+		case ";":
+			if depth == 0 {
+				return
+			}
+		}
+	}
+}
+
+// statement parses a top-level or message-level statement; scope is the
+// name of the enclosing message.
+func (p *protoParser) statement(scope string) error {
+	switch p.peek().text {
+	case "message":
+		p.next()
+		name := joinPath(scope, p.next().text)
+		return p.message(name)
+	case "enum":
+		p.next()
+		name := joinPath(scope, p.next().text)
+		return p.enum(name)
+	case ";":
+		p.next()
+		return nil
+	default:
+		p.skipStatement()
+		return nil
+	}
+}
+
+func (p *protoParser) message(name string) error {
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+
+	msg := protoMessage{fields: map[int]protoField{}}
+	p.file.messages[name] = msg
+
+	for {
+		if p.done() {
+			return fmt.Errorf("message %s is not closed", name)
+		}
+
+		switch p.peek().text {
+		case "}":
+			p.next()
+			return nil
+		case "message", "enum", ";":
+			if err := p.statement(name); err != nil {
+				return err
+			}
+		case "oneof":
+			p.next()
+			p.next()
+			if err := p.expect("{"); err != nil {
+				return err
+			}
+			for !p.done() && p.peek().text != "}" {
+				if p.peek().text == "option" {
+					p.skipStatement()
+					continue
+				}
+				if err := p.field(msg); err != nil {
+					return err
+				}
+			}
+			p.next()
+		case "option", "reserved", "extensions", "extend":
+			p.skipStatement()
+		default:
+			if err := p.field(msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *protoParser) field(msg protoMessage) error {
+	first := p.next()
+	field := protoField{doc: first.doc}
+
+	typ := first.text
+	switch typ {
+	case "optional", "required":
+		field.label = typ
+		typ = p.next().text
+	case "repeated":
+		field.repeated = true
+		typ = p.next().text
+	}
+
+	if typ == "map" {
+		// map<key, value>
+		if err := p.expect("<"); err != nil {
+			return err
+		}
+		key := p.next().text
+		if err := p.expect(","); err != nil {
+			return err
+		}
+		value := p.next().text
+		if err := p.expect(">"); err != nil {
+			return err
+		}
+		typ = "map<" + key + "," + value + ">"
+	}
+	field.typ = strings.TrimPrefix(typ, ".")
+	field.name = p.next().text
+
+	if err := p.expect("="); err != nil {
+		return err
+	}
+	number, err := strconv.Atoi(p.next().text)
+	if err != nil {
+		return fmt.Errorf("field %s has an invalid number", field.name)
+	}
+	field.number = number
+
+	if p.peek().text == "[" {
+		p.next()
+		for !p.done() && p.peek().text != "]" {
+			option := p.next().text
+			if option == "default" && p.peek().text == "=" {
+				p.next()
+				field.def, field.hasDef = p.next().text, true
+			}
+		}
+		p.next()
+	}
+
+	if err := p.expect(";"); err != nil {
+		return err
+	}
+
+	msg.fields[number] = field
+	return nil
+}
+
+func (p *protoParser) enum(name string) error {
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+
+	values := []string{}
+	for {
+		if p.done() {
+			return fmt.Errorf("enum %s is not closed", name)
+		}
+
+		token := p.next()
+		switch token.text {
+		case "}":
+			p.file.enums[name] = values
+			return nil
+		case "option", "reserved":
+			p.pos--
+			p.skipStatement()
+		case ";":
+		default:
+			if p.peek().text != "=" {
+				return fmt.Errorf("enum %s: unexpected %q", name, token.text)
+			}
+			p.next()
+			values = append(values, token.text+"="+p.next().text)
+			// Skip value options
+			for !p.done() && p.peek().text != ";" {
+				p.next()
+			}
+			p.next()
+		}
+	}
+}
+
+// tokenizeProto splits a .proto file into identifiers, numbers, strings and
+// punctuation. Comments are attached to the token that follows them.
+func tokenizeProto(src string) []protoToken {
+	var (
+		tokens []protoToken
+		doc    []string
+	)
+
+	emit := func(text string) {
+		tokens = append(tokens, protoToken{text: text, doc: strings.Join(doc, "\n")})
+		doc = nil
+	}
+
+	runes := []rune(src)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			end := i
+			for end < len(runes) && runes[end] != '\n' {
+				end++
+			}
+			doc = append(doc, strings.TrimSpace(string(runes[i+2:end])))
+			i = end
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			end := i + 2
+			for end+1 < len(runes) && (runes[end] != '*' || runes[end+1] != '/') {
+				end++
+			}
+			doc = append(doc, strings.TrimSpace(string(runes[i+2:min(end, len(runes))])))
+			i = end + 2
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			emit(string(runes[i:min(end+1, len(runes))]))
+			i = end + 1
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '-' || r == '+':
+			end := i
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || strings.ContainsRune("_.-+", runes[end])) {
+				end++
+			}
+			emit(string(runes[i:end]))
+			i = end
+		default:
+			emit(string(r))
+			i++
+		}
+	}
+
+	return tokens
+}
@@ -0,0 +1,130 @@
+// Package kafkaconfig documents Kafka topic configs with their broker
+// defaults and renders their values in human units.
+package kafkaconfig
+
+import (
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/smart-fellas/k4a/internal/utils"
+)
+
+// Unit is the unit of a config's value.
+type Unit int
+
+const (
+	// Plain values are shown as they are.
+	Plain Unit = iota
+	// Milliseconds are shown as a duration, e.g. 7.0d.
+	Milliseconds
+	// Bytes are shown in binary units, e.g. 1.0 GiB.
+	Bytes
+)
+
+// Config is a topic config with the default the broker applies when the
+// topic does not set it.
+type Config struct {
+	Name    string
+	Default string
+	Unit    Unit
+	Doc     string
+}
+
+// Topic configs, sorted by name. Defaults are those of Apache Kafka
+// brokers; a cluster may override them with its own broker settings.
+var topicConfigs = []Config{
+	{"cleanup.policy", "delete", Plain, "delete removes old segments, compact keeps the latest record of each key"},
+	{"compression.type", "producer", Plain, "Compression of the log: producer keeps the codec the producer used"},
+	{"delete.retention.ms", "86400000", Milliseconds, "How long delete tombstones are kept on compacted topics"},
+	{"file.delete.delay.ms", "60000", Milliseconds, "Delay before a deleted segment file is removed from disk"},
+	{"flush.messages", strconv.FormatInt(math.MaxInt64, 10), Plain, "Number of messages after which the log is fsynced"},
+	{"flush.ms", strconv.FormatInt(math.MaxInt64, 10), Milliseconds, "Time after which the log is fsynced"},
+	{"index.interval.bytes", "4096", Bytes, "Bytes between two entries of the offset index"},
+	{"local.retention.bytes", "-2", Bytes, "Bytes kept on local disk with tiered storage; -2 uses retention.bytes"},
+	{"local.retention.ms", "-2", Milliseconds, "Time segments are kept on local disk with tiered storage; -2 uses retention.ms"},
+	{"max.compaction.lag.ms", strconv.FormatInt(math.MaxInt64, 10), Milliseconds, "Longest a record stays uncompacted"},
+	{"max.message.bytes", "1048588", Bytes, "Largest record batch the topic accepts"},
+	{"message.downconversion.enable", "true", Plain, "Whether records are down-converted for older consumers"},
+	{"message.timestamp.after.max.ms", strconv.FormatInt(math.MaxInt64, 10), Milliseconds, "How far in the future a record timestamp may be"},
+	{"message.timestamp.before.max.ms", strconv.FormatInt(math.MaxInt64, 10), Milliseconds, "How far in the past a record timestamp may be"},
+	{"message.timestamp.difference.max.ms", strconv.FormatInt(math.MaxInt64, 10), Milliseconds, "Largest gap between a record timestamp and the broker time"},
+	{"message.timestamp.type", "CreateTime", Plain, "CreateTime keeps the producer's timestamp, LogAppendTime uses the broker's"},
+	{"min.cleanable.dirty.ratio", "0.5", Plain, "Share of uncompacted log that triggers compaction"},
+	{"min.compaction.lag.ms", "0", Milliseconds, "Shortest a record stays uncompacted"},
+	{"min.insync.replicas", "1", Plain, "Replicas that must acknowledge a write with acks=all"},
+	{"preallocate", "false", Plain, "Whether segment files are preallocated on disk"},
+	{"remote.storage.enable", "false", Plain, "Whether segments are moved to tiered storage"},
+	{"retention.bytes", "-1", Bytes, "Largest size of a partition before old segments are deleted; -1 is unlimited"},
+	{"retention.ms", "604800000", Milliseconds, "How long records are kept before old segments are deleted; -1 is forever"},
+	{"segment.bytes", "1073741824", Bytes, "Size of a log segment file"},
+	{"segment.index.bytes", "10485760", Bytes, "Size of the offset index of a segment"},
+	{"segment.jitter.ms", "0", Milliseconds, "Random jitter subtracted from segment.ms"},
+	{"segment.ms", "604800000", Milliseconds, "Time after which a segment is rolled even if it is not full"},
+	{"unclean.leader.election.enable", "false", Plain, "Whether an out-of-sync replica may become leader, losing data"},
+}
+
+// Lookup returns the documented config of the given name.
+func Lookup(name string) (Config, bool) {
+	i, found := slices.BinarySearchFunc(topicConfigs, name, func(c Config, name string) int {
+		switch {
+		case c.Name < name:
+			return -1
+		case c.Name > name:
+			return 1
+		}
+		return 0
+	})
+	if !found {
+		return Config{}, false
+	}
+	return topicConfigs[i], true
+}
+
+// IsDefault reports whether value is the broker default of the config.
+// Numbers are compared by value, so 1e3 and 1000 are the same.
+func (c Config) IsDefault(value string) bool {
+	if value == c.Default {
+		return true
+	}
+	a, errA := strconv.ParseFloat(value, 64)
+	b, errB := strconv.ParseFloat(c.Default, 64)
+	return errA == nil && errB == nil && a == b
+}
+
+// Humanize renders the value of a config in human units. Values of
+// unknown configs, and values that are not numbers, are returned as they
+// are.
+func Humanize(name, value string) string {
+	config, ok := Lookup(name)
+	if !ok || config.Unit == Plain {
+		return value
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return value
+	}
+
+	switch {
+	case n == -1:
+		return "unlimited"
+	case n == -2 && config.Unit == Milliseconds:
+		return "as retention.ms"
+	case n == -2 && config.Unit == Bytes:
+		return "as retention.bytes"
+	case n < 0:
+		return value
+	}
+
+	if config.Unit == Bytes {
+		return utils.FormatBytes(n)
+	}
+
+	// Durations over a century are effectively never reached, and would
+	// overflow time.Duration
+	if n > 100*365*24*60*60*1000 {
+		return "never"
+	}
+	return utils.FormatDuration(n)
+}
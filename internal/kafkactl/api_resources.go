@@ -0,0 +1,89 @@
+package kafkactl
+
+import (
+	"context"
+	"strings"
+)
+
+// APIResource is a kind of resource the Ns4Kafka API serves, as listed by
+// kafkactl api-resources.
+type APIResource struct {
+	Kind string
+	// Names are the names kafkactl accepts for the kind, e.g. topics,
+	// topic and to
+	Names      []string
+	Namespaced bool
+}
+
+// ResourceType returns the name to pass to kafkactl get.
+func (r APIResource) ResourceType() string {
+	if len(r.Names) == 0 {
+		return strings.ToLower(r.Kind)
+	}
+	return r.Names[0]
+}
+
+// Matches reports whether name is the kind or one of its names, ignoring
+// case.
+func (r APIResource) Matches(name string) bool {
+	if strings.EqualFold(name, r.Kind) {
+		return true
+	}
+	for _, n := range r.Names {
+		if strings.EqualFold(name, n) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListAPIResources retrieves the kinds of resources the API serves.
+func (c *Client) ListAPIResources(ctx context.Context) ([]APIResource, error) {
+	output, err := c.ExecuteCommand(ctx, "api-resources")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseAPIResources(string(output)), nil
+}
+
+// ParseAPIResources parses the table kafkactl api-resources prints:
+//
+//	KIND                NAMES                           NAMESPACED
+//	Topic               topics,topic,to                 true
+//
+// Names may be separated by commas, with or without spaces.
+func ParseAPIResources(output string) []APIResource {
+	var resources []APIResource
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] == "KIND" {
+			continue
+		}
+
+		resource := APIResource{Kind: fields[0]}
+		names := fields[1:]
+		if last := names[len(names)-1]; last == "true" || last == "false" {
+			resource.Namespaced = last == "true"
+			names = names[:len(names)-1]
+		}
+		for _, name := range strings.Split(strings.Join(names, ","), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				resource.Names = append(resource.Names, name)
+			}
+		}
+		resources = append(resources, resource)
+	}
+	return resources
+}
+
+// GetResources retrieves the manifests of every resource of a type, e.g.
+// rolebindings.
+func (c *Client) GetResources(ctx context.Context, resourceType string) ([]map[string]any, error) {
+	output, err := c.ExecuteCommand(ctx, "get", resourceType, "-o", "yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	return c.parseYAMLList(output)
+}
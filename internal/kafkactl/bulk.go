@@ -0,0 +1,57 @@
+package kafkactl
+
+import (
+	"context"
+	"sync"
+
+	"github.com/smart-fellas/k4a/internal/config"
+)
+
+// BulkResult is the outcome of a bulk action on one item.
+type BulkResult struct {
+	// Index of the item in the items of the run
+	Index  int
+	Item   string
+	Output string
+	Err    error
+}
+
+// RunBulk runs action on every item, at most the configured parallelism
+// at once, and sends each outcome on the returned channel as it completes.
+// The channel is closed once every item is done. Items that have not
+// started when ctx is cancelled fail with its error.
+func (c *Client) RunBulk(ctx context.Context, items []string, action func(ctx context.Context, item string) (string, error)) <-chan BulkResult {
+	workers := c.settings.Parallelism
+	if workers <= 0 {
+		workers = config.DefaultParallelism
+	}
+
+	indexes := make(chan int)
+	results := make(chan BulkResult, len(items))
+
+	var wg sync.WaitGroup
+	for range min(workers, len(items)) {
+		wg.Go(func() {
+			for i := range indexes {
+				result := BulkResult{Index: i, Item: items[i]}
+				if err := ctx.Err(); err != nil {
+					result.Err = err
+				} else {
+					result.Output, result.Err = action(ctx, items[i])
+				}
+				results <- result
+			}
+		})
+	}
+
+	go func() {
+		for i := range items {
+			indexes <- i
+		}
+		close(indexes)
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
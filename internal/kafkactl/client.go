@@ -1,83 +1,151 @@
 package kafkactl
 
 import (
-	"bytes"
+	"context"
+	"errors"
 	"fmt"
-	"os/exec"
+	"os"
 	"strings"
 
 	"github.com/smart-fellas/k4a/internal/config"
+	"github.com/smart-fellas/k4a/pkg/models"
 	"gopkg.in/yaml.v3"
 )
 
+// Client runs kafkactl commands against one context. The context is fixed
+// when the client is created, so switching contexts means creating a new
+// client.
 type Client struct {
-	config *config.Config
+	config   *config.Config
+	settings *config.Settings
+	context  string
+	executor Executor
 }
 
+// NewClient creates a client bound to the config's current context that
+// runs the kafkactl binary.
 func NewClient(cfg *config.Config) *Client {
-	return &Client{config: cfg}
+	return NewClientWithExecutor(cfg, NewProcessExecutor())
 }
 
-// ExecuteCommand runs a kafkactl command and returns the output.
-func (c *Client) ExecuteCommand(args ...string) ([]byte, error) {
-	cmd := exec.Command("kafkactl", args...)
+// NewClientWithExecutor creates a client bound to the config's current
+// context that runs kafkactl through executor.
+func NewClientWithExecutor(cfg *config.Config, executor Executor) *Client {
+	client := &Client{config: cfg, settings: config.DefaultSettings(), executor: executor}
+	if cfg != nil {
+		client.context = cfg.CurrentContext
+	}
+	return client
+}
+
+// SetSettings sets the k4a settings, which hold the per-subcommand
+// timeouts.
+func (c *Client) SetSettings(settings *config.Settings) {
+	c.settings = settings
+}
 
-	var out bytes.Buffer
-	var stderr bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = &stderr
+// Executor returns the executor the client runs kafkactl through.
+func (c *Client) Executor() Executor {
+	return c.executor
+}
+
+// readOnlyCommands are the kafkactl subcommands that change nothing and can
+// be abandoned when whoever asked for them goes away.
+var readOnlyCommands = map[string]bool{
+	"get":           true,
+	"diff":          true,
+	"api-resources": true,
+	"version":       true,
+}
+
+// ExecuteCommand runs a kafkactl command and returns the output. The run is
+// bounded by the timeout configured for its subcommand and fails with a
+// TimeoutError when it expires. Cancelling ctx abandons read-only commands;
+// changes, once started, only stop at their timeout.
+func (c *Client) ExecuteCommand(ctx context.Context, args ...string) ([]byte, error) {
+	operation := ""
+	if len(args) > 0 {
+		operation = args[0]
+	}
+	if !readOnlyCommands[operation] {
+		ctx = context.WithoutCancel(ctx)
+	}
+
+	timeout := c.settings.Timeout(operation)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := c.executor.Execute(ctx, args, c.Env())
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return nil, &TimeoutError{Args: args, Timeout: timeout}
+	case ctx.Err() != nil:
+		return nil, ctx.Err()
+	}
 
-	err := cmd.Run()
+	if err == nil && result.ExitCode != 0 {
+		return nil, &ExitError{ExitCode: result.ExitCode, Stderr: result.Stderr}
+	}
 	if err != nil {
-		return nil, fmt.Errorf("command failed: %v, stderr: %s", err, stderr.String())
+		return nil, fmt.Errorf("command failed: %v, stderr: %s", err, result.Stderr)
 	}
 
-	return out.Bytes(), nil
+	return []byte(result.Stdout), nil
 }
 
-// GetTopics retrieves all topics.
-func (c *Client) GetTopics() ([]map[string]any, error) {
-	output, err := c.ExecuteCommand("get", "topics", "-o", "yaml")
+// GetConsumerGroups retrieves consumer groups for a topic.
+func (c *Client) GetConsumerGroups(ctx context.Context, topic string) ([]models.ConsumerGroup, error) {
+	output, err := c.ExecuteCommand(ctx, "get", "consumer-groups", "--topic", topic, "-o", "yaml")
 	if err != nil {
 		return nil, err
 	}
 
-	return c.parseYAMLList(output)
+	return c.parseConsumerGroups(output)
 }
 
-// GetSchemas retrieves all schemas.
-func (c *Client) GetSchemas() ([]map[string]any, error) {
-	output, err := c.ExecuteCommand("get", "schemas", "-o", "yaml")
+// GetResourceYAML retrieves the YAML for a specific resource.
+func (c *Client) GetResourceYAML(ctx context.Context, resourceType, name string) (string, error) {
+	output, err := c.ExecuteCommand(ctx, "get", resourceType, name, "-o", "yaml")
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	return c.parseYAMLList(output)
+	return string(output), nil
 }
 
-// GetConnectors retrieves all connectors.
-func (c *Client) GetConnectors() ([]map[string]any, error) {
-	output, err := c.ExecuteCommand("get", "connectors", "-o", "yaml")
+// ApplyManifest writes a manifest to a temporary file and applies it.
+func (c *Client) ApplyManifest(ctx context.Context, manifest []byte) (string, error) {
+	file, err := os.CreateTemp("", "k4a-*.yaml")
 	if err != nil {
-		return nil, err
+		return "", fmt.Errorf("failed to create manifest file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err = file.Write(manifest); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to write manifest file: %w", err)
+	}
+	if err = file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write manifest file: %w", err)
 	}
 
-	return c.parseYAMLList(output)
+	return c.ApplyFile(ctx, file.Name())
 }
 
-// GetConsumerGroups retrieves consumer groups for a topic.
-func (c *Client) GetConsumerGroups(topic string) ([]map[string]any, error) {
-	output, err := c.ExecuteCommand("get", "consumer-groups", "--topic", topic, "-o", "yaml")
+// ApplyFile applies the manifest stored in a file.
+func (c *Client) ApplyFile(ctx context.Context, path string) (string, error) {
+	output, err := c.ExecuteCommand(ctx, "apply", "-f", path)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	return c.parseYAMLList(output)
+	return string(output), nil
 }
 
-// GetResourceYAML retrieves the YAML for a specific resource.
-func (c *Client) GetResourceYAML(resourceType, name string) (string, error) {
-	output, err := c.ExecuteCommand("get", resourceType, name, "-o", "yaml")
+// Diff shows the server-side difference between a manifest file and the
+// deployed resources.
+func (c *Client) Diff(ctx context.Context, path string) (string, error) {
+	output, err := c.ExecuteCommand(ctx, "diff", "-f", path)
 	if err != nil {
 		return "", err
 	}
@@ -85,6 +153,60 @@ func (c *Client) GetResourceYAML(resourceType, name string) (string, error) {
 	return string(output), nil
 }
 
+// DeleteResource deletes a resource by type and name.
+func (c *Client) DeleteResource(ctx context.Context, resourceType, name string) error {
+	_, err := c.ExecuteCommand(ctx, "delete", resourceType, name)
+	return err
+}
+
+// ContextName returns the name of the context the client is bound to.
+func (c *Client) ContextName() string {
+	return c.context
+}
+
+// Env returns the environment variables that point kafkactl at the bound
+// context, regardless of kafkactl's own current-context. A namespace or API
+// the context leaves empty is not set, so that of the shell or of kafkactl's
+// config still applies.
+func (c *Client) Env() []string {
+	if c.config == nil {
+		return nil
+	}
+
+	ctx, err := c.config.GetContext(c.context)
+	if err != nil {
+		return nil
+	}
+
+	var env []string
+	if ctx.Context.Namespace != "" {
+		env = append(env, "KAFKACTL_CURRENT_NAMESPACE="+ctx.Context.Namespace)
+	}
+	// The token is set, even empty, whenever the API is, so kafkactl never
+	// sends the token of its own current context to another API
+	if ctx.Context.API != "" || ctx.Context.UserToken != "" {
+		if ctx.Context.API != "" {
+			env = append(env, "KAFKACTL_API="+ctx.Context.API)
+		}
+		env = append(env, "KAFKACTL_USER_TOKEN="+ctx.Context.UserToken)
+	}
+	return env
+}
+
+// Namespace returns the namespace of the bound context.
+func (c *Client) Namespace() string {
+	if c.config == nil {
+		return ""
+	}
+
+	ctx, err := c.config.GetContext(c.context)
+	if err != nil {
+		return ""
+	}
+
+	return ctx.Context.Namespace
+}
+
 func (c *Client) parseYAMLList(data []byte) ([]map[string]any, error) {
 	// Split by document separator
 	docs := strings.Split(string(data), "---")
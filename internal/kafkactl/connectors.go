@@ -0,0 +1,27 @@
+package kafkactl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smart-fellas/k4a/pkg/models"
+)
+
+// GetConnector retrieves a connector with the status reported by its
+// Connect cluster, including the state of each task.
+func (c *Client) GetConnector(ctx context.Context, name string) (models.Connector, error) {
+	output, err := c.ExecuteCommand(ctx, "get", "connector", name, "-o", "yaml")
+	if err != nil {
+		return models.Connector{}, err
+	}
+
+	docs, err := c.parseYAMLList(output)
+	if err != nil {
+		return models.Connector{}, err
+	}
+	if len(docs) == 0 {
+		return models.Connector{}, fmt.Errorf("connector %s not found", name)
+	}
+
+	return models.Decode[models.Connector](docs[0])
+}
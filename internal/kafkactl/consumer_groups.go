@@ -0,0 +1,147 @@
+package kafkactl
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/smart-fellas/k4a/pkg/models"
+)
+
+// GetAllConsumerGroups retrieves every consumer group of the namespace.
+func (c *Client) GetAllConsumerGroups(ctx context.Context) ([]models.ConsumerGroup, error) {
+	output, err := c.ExecuteCommand(ctx, "get", "consumer-groups", "-o", "yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	return c.parseConsumerGroups(output)
+}
+
+func (c *Client) parseConsumerGroups(data []byte) ([]models.ConsumerGroup, error) {
+	docs, err := c.parseYAMLList(data)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]models.ConsumerGroup, 0, len(docs))
+	for _, doc := range docs {
+		groups = append(groups, SummarizeConsumerGroup(doc))
+	}
+
+	return groups, nil
+}
+
+// SummarizeConsumerGroup builds a ConsumerGroup from a ConsumerGroup manifest.
+// Members may be reported either as a count or as a list of member
+// descriptions, and offsets as a list of per-partition entries, so the
+// status is summarized by hand rather than decoded.
+func SummarizeConsumerGroup(doc map[string]any) models.ConsumerGroup {
+	group := models.ConsumerGroup{Status: models.ConsumerGroupStatus{State: "Unknown"}}
+	group.SetRaw(doc)
+
+	if apiVersion, ok := doc["apiVersion"].(string); ok {
+		group.APIVersion = apiVersion
+	}
+	if kind, ok := doc["kind"].(string); ok {
+		group.Kind = kind
+	}
+
+	if metadata, ok := doc["metadata"].(map[string]any); ok {
+		if name, nameOk := metadata["name"].(string); nameOk {
+			group.Metadata.Name = name
+		}
+		if namespace, namespaceOk := metadata["namespace"].(string); namespaceOk {
+			group.Metadata.Namespace = namespace
+		}
+	}
+
+	status, ok := doc["status"].(map[string]any)
+	if !ok {
+		return group
+	}
+
+	if state, stateOk := status["state"].(string); stateOk && state != "" {
+		group.Status.State = state
+	}
+
+	topics := map[string]struct{}{}
+
+	switch members := status["members"].(type) {
+	case []any:
+		group.Status.Members = len(members)
+		for _, member := range members {
+			memberMap, memberOk := member.(map[string]any)
+			if !memberOk {
+				continue
+			}
+			assignments, assignOk := memberMap["assignments"].([]any)
+			if !assignOk {
+				continue
+			}
+			for _, assignment := range assignments {
+				if a, aOk := assignment.(map[string]any); aOk {
+					if topic, topicOk := a["topic"].(string); topicOk {
+						topics[topic] = struct{}{}
+					}
+				}
+			}
+		}
+	default:
+		group.Status.Members = int(toInt64(members))
+	}
+
+	if offsets, offsetsOk := status["offsets"].([]any); offsetsOk {
+		for _, entry := range offsets {
+			e, entryOk := entry.(map[string]any)
+			if !entryOk {
+				continue
+			}
+
+			offset := models.PartitionOffset{
+				Partition:     int(toInt64(e["partition"])),
+				CurrentOffset: toInt64(e["currentOffset"]),
+				EndOffset:     toInt64(e["endOffset"]),
+			}
+			if topic, topicOk := e["topic"].(string); topicOk {
+				offset.Topic = topic
+				topics[topic] = struct{}{}
+			}
+			if lag, lagOk := e["lag"]; lagOk {
+				offset.Lag = toInt64(lag)
+			} else if offset.EndOffset > offset.CurrentOffset {
+				offset.Lag = offset.EndOffset - offset.CurrentOffset
+			}
+
+			group.Status.Offsets = append(group.Status.Offsets, offset)
+		}
+	}
+
+	for topic := range topics {
+		group.Status.Topics = append(group.Status.Topics, topic)
+	}
+	sort.Strings(group.Status.Topics)
+
+	return group
+}
+
+func toInt64(v any) int64 {
+	switch n := v.(type) {
+	case int:
+		return int64(n)
+	case int64:
+		return n
+	case uint64:
+		return int64(n)
+	case float64:
+		return int64(n)
+	case string:
+		parsed, err := strconv.ParseInt(n, 10, 64)
+		if err != nil {
+			return 0
+		}
+		return parsed
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,42 @@
+package kafkactl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeoutError reports a kafkactl run that did not finish within the
+// timeout configured for its subcommand.
+type TimeoutError struct {
+	Args    []string
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("kafkactl %s timed out after %s", strings.Join(e.Args, " "), e.Timeout)
+}
+
+// IsTimeout reports whether err is, or wraps, a TimeoutError.
+func IsTimeout(err error) bool {
+	var timeoutErr *TimeoutError
+	return errors.As(err, &timeoutErr)
+}
+
+// ExitError reports a kafkactl run that completed with a non-zero exit
+// status, so the server answered or the command was refused.
+type ExitError struct {
+	ExitCode int
+	Stderr   string
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("command failed: exit status %d, stderr: %s", e.ExitCode, e.Stderr)
+}
+
+// IsExitError reports whether err is, or wraps, an ExitError.
+func IsExitError(err error) bool {
+	var exitErr *ExitError
+	return errors.As(err, &exitErr)
+}
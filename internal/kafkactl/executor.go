@@ -0,0 +1,324 @@
+package kafkactl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smart-fellas/k4a/internal/eventlog"
+	"gopkg.in/yaml.v3"
+)
+
+// Wildcard in a recorded argument matches any argument on replay. The
+// recorder writes it in place of temporary file paths, whose names change
+// on every run.
+const Wildcard = "*"
+
+// Result is the outcome of one kafkactl run.
+type Result struct {
+	Stdout   string `yaml:"stdout"`
+	Stderr   string `yaml:"stderr,omitempty"`
+	ExitCode int    `yaml:"exitCode"`
+}
+
+// Executor runs kafkactl. env holds extra KEY=value variables for the run.
+// An error means kafkactl could not be run at all, or ctx ended first; a
+// failed command is reported through Result.ExitCode.
+type Executor interface {
+	Execute(ctx context.Context, args, env []string) (Result, error)
+}
+
+// ProcessExecutor runs the kafkactl binary.
+type ProcessExecutor struct {
+	Binary string
+}
+
+// NewProcessExecutor returns an executor for the kafkactl binary on PATH.
+func NewProcessExecutor() *ProcessExecutor {
+	return &ProcessExecutor{Binary: "kafkactl"}
+}
+
+func (e *ProcessExecutor) Execute(ctx context.Context, args, env []string) (Result, error) {
+	cmd := exec.CommandContext(ctx, e.Binary, args...)
+	cmd.Env = append(os.Environ(), env...)
+
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	result := Result{Stdout: out.String(), Stderr: stderr.String()}
+	if ctx.Err() != nil {
+		return result, ctx.Err()
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		result.ExitCode = exitErr.ExitCode()
+		return result, nil
+	}
+
+	return result, err
+}
+
+// Interaction is a recorded kafkactl run. Delay, when set, makes a replay
+// take that long, e.g. to reproduce a slow API.
+type Interaction struct {
+	Args   []string      `yaml:"args,flow"`
+	Delay  time.Duration `yaml:"delay,omitempty"`
+	Result `yaml:",inline"`
+}
+
+// Cassette is a sequence of recorded kafkactl runs. Cassettes are YAML
+// files so they can be attached to bug reports and used as test fixtures.
+type Cassette struct {
+	Interactions []Interaction `yaml:"interactions"`
+}
+
+// LoadCassette reads a cassette file.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	var cassette Cassette
+	if err = yaml.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+
+	return &cassette, nil
+}
+
+// Save writes the cassette to path.
+func (c *Cassette) Save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	return nil
+}
+
+// FakeExecutor answers kafkactl runs from recorded interactions without
+// running anything. Matching interactions are replayed in order; once all
+// have been used the last one keeps being returned.
+type FakeExecutor struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+	calls        [][]string
+}
+
+// NewFakeExecutor returns a fake answering with the given interactions.
+func NewFakeExecutor(interactions ...Interaction) *FakeExecutor {
+	return &FakeExecutor{
+		interactions: interactions,
+		used:         make([]bool, len(interactions)),
+	}
+}
+
+// NewReplayExecutor returns a fake answering from a cassette file.
+func NewReplayExecutor(path string) (*FakeExecutor, error) {
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFakeExecutor(cassette.Interactions...), nil
+}
+
+// Add registers the result of running kafkactl with args.
+func (f *FakeExecutor) Add(result Result, args ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.interactions = append(f.interactions, Interaction{Args: args, Result: result})
+	f.used = append(f.used, false)
+}
+
+// Calls returns the arguments of every run so far.
+func (f *FakeExecutor) Calls() [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([][]string(nil), f.calls...)
+}
+
+func (f *FakeExecutor) Execute(ctx context.Context, args, _ []string) (Result, error) {
+	interaction, err := f.match(args)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if interaction.Delay > 0 {
+		select {
+		case <-time.After(interaction.Delay):
+		case <-ctx.Done():
+		}
+	}
+	if ctx.Err() != nil {
+		return Result{}, ctx.Err()
+	}
+
+	return interaction.Result, nil
+}
+
+func (f *FakeExecutor) match(args []string) (Interaction, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, append([]string(nil), args...))
+
+	last := -1
+	for i, interaction := range f.interactions {
+		if !argsMatch(interaction.Args, args) {
+			continue
+		}
+		if !f.used[i] {
+			f.used[i] = true
+			return interaction, nil
+		}
+		last = i
+	}
+
+	if last >= 0 {
+		return f.interactions[last], nil
+	}
+
+	return Interaction{}, fmt.Errorf("no recorded interaction for kafkactl %s", strings.Join(args, " "))
+}
+
+func argsMatch(recorded, args []string) bool {
+	if len(recorded) != len(args) {
+		return false
+	}
+
+	for i := range recorded {
+		if recorded[i] != Wildcard && recorded[i] != args[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RecordingExecutor runs kafkactl through another executor and appends
+// every run to a cassette file. The file is rewritten after each run so it
+// survives a crash. Environment variables, which carry the user token, are
+// never recorded.
+type RecordingExecutor struct {
+	mu       sync.Mutex
+	next     Executor
+	path     string
+	cassette Cassette
+	log      *eventlog.Log
+}
+
+// NewRecordingExecutor records the runs of next into the cassette at path.
+func NewRecordingExecutor(next Executor, path string) *RecordingExecutor {
+	return &RecordingExecutor{next: next, path: path}
+}
+
+// LogTo reports cassette save failures in log rather than on stderr.
+func (r *RecordingExecutor) LogTo(log *eventlog.Log) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.log = log
+}
+
+// Execute runs kafkactl and records the run. A cassette that cannot be
+// saved is reported but does not fail the run, which may have changed
+// resources already.
+func (r *RecordingExecutor) Execute(ctx context.Context, args, env []string) (Result, error) {
+	result, err := r.next.Execute(ctx, args, env)
+	if err != nil {
+		return result, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Args:   recordedArgs(args),
+		Result: result,
+	})
+
+	if saveErr := r.cassette.Save(r.path); saveErr != nil {
+		message := fmt.Sprintf("Failed to record kafkactl %s: %v", strings.Join(args, " "), saveErr)
+		if r.log != nil {
+			r.log.Error(message)
+		} else {
+			fmt.Fprintln(os.Stderr, message)
+		}
+	}
+
+	return result, nil
+}
+
+// recordedArgs replaces temporary file paths with the wildcard.
+func recordedArgs(args []string) []string {
+	tmp := os.TempDir()
+	recorded := make([]string, len(args))
+
+	for i, arg := range args {
+		if filepath.IsAbs(arg) && strings.HasPrefix(arg, tmp) {
+			recorded[i] = Wildcard
+		} else {
+			recorded[i] = arg
+		}
+	}
+
+	return recorded
+}
+
+// LoggingExecutor runs kafkactl through another executor and records every
+// run, with its arguments, stderr and exit code, in an event log.
+// Environment variables are never logged.
+type LoggingExecutor struct {
+	next Executor
+	log  *eventlog.Log
+}
+
+// NewLoggingExecutor logs the runs of next to log.
+func NewLoggingExecutor(next Executor, log *eventlog.Log) *LoggingExecutor {
+	return &LoggingExecutor{next: next, log: log}
+}
+
+func (l *LoggingExecutor) Execute(ctx context.Context, args, env []string) (Result, error) {
+	start := time.Now()
+	result, err := l.next.Execute(ctx, args, env)
+
+	entry := eventlog.Entry{
+		Time:     start,
+		Level:    eventlog.Info,
+		Message:  "kafkactl " + strings.Join(args, " "),
+		Args:     append([]string(nil), args...),
+		Stderr:   result.Stderr,
+		ExitCode: result.ExitCode,
+		Duration: time.Since(start),
+	}
+	switch {
+	case err != nil:
+		entry.Level = eventlog.Error
+		entry.Message += ": " + err.Error()
+	case result.ExitCode != 0:
+		entry.Level = eventlog.Error
+		entry.Message += fmt.Sprintf(": exit status %d", result.ExitCode)
+	}
+	l.log.Add(entry)
+
+	return result, err
+}
@@ -0,0 +1,80 @@
+package kafkactl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// serverManagedMetadata lists metadata fields that ns4kafka sets itself and
+// rejects or ignores when they are applied.
+var serverManagedMetadata = map[string]bool{
+	"cluster":           true,
+	"creationTimestamp": true,
+	"generation":        true,
+	"resourceVersion":   true,
+	"uid":               true,
+}
+
+// StripServerFields removes the status block and server-managed metadata
+// from every document of a manifest, keeping the order of the remaining
+// fields so the result is pleasant to edit.
+func StripServerFields(manifest []byte) ([]byte, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(manifest))
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+
+	for {
+		var doc yaml.Node
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse manifest: %w", err)
+		}
+
+		if len(doc.Content) > 0 && doc.Content[0].Kind == yaml.MappingNode {
+			root := doc.Content[0]
+			removeKey(root, "status")
+			if metadata := lookupKey(root, "metadata"); metadata != nil && metadata.Kind == yaml.MappingNode {
+				for field := range serverManagedMetadata {
+					removeKey(metadata, field)
+				}
+			}
+		}
+
+		if err = encoder.Encode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to encode manifest: %w", err)
+		}
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+func lookupKey(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func removeKey(mapping *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package kafkactl
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/smart-fellas/k4a/pkg/models"
+)
+
+// ResetMethod is one of the offset reset strategies supported by
+// kafkactl reset-offsets.
+type ResetMethod string
+
+const (
+	ResetToEarliest ResetMethod = "to-earliest"
+	ResetToLatest   ResetMethod = "to-latest"
+	ResetToDatetime ResetMethod = "to-datetime"
+	ResetByDuration ResetMethod = "by-duration"
+	ResetShiftBy    ResetMethod = "shift-by"
+	ResetToOffset   ResetMethod = "to-offset"
+)
+
+// ResetMethods lists every reset method in the order they are offered.
+var ResetMethods = []ResetMethod{
+	ResetToEarliest,
+	ResetToLatest,
+	ResetToDatetime,
+	ResetByDuration,
+	ResetShiftBy,
+	ResetToOffset,
+}
+
+// NeedsValue reports whether the method takes an argument.
+func (r ResetMethod) NeedsValue() bool {
+	switch r {
+	case ResetToDatetime, ResetByDuration, ResetShiftBy, ResetToOffset:
+		return true
+	default:
+		return false
+	}
+}
+
+// ResetOffsetsOptions describes a consumer group offset reset.
+type ResetOffsetsOptions struct {
+	Group string
+	// Topic restricts the reset to one topic; empty means all topics.
+	Topic  string
+	Method ResetMethod
+	Value  string
+	DryRun bool
+}
+
+// Args builds the kafkactl arguments for the reset.
+func (o ResetOffsetsOptions) Args() []string {
+	args := []string{"reset-offsets", "--group", o.Group}
+
+	if o.Topic != "" {
+		args = append(args, "--topic", o.Topic)
+	} else {
+		args = append(args, "--all-topics")
+	}
+
+	args = append(args, "--"+string(o.Method))
+	if o.Method.NeedsValue() {
+		args = append(args, o.Value)
+	}
+
+	if o.DryRun {
+		args = append(args, "--dry-run")
+	}
+
+	return args
+}
+
+// OffsetReset is the new offset of one partition after a reset.
+type OffsetReset struct {
+	Topic     string
+	Partition int
+	NewOffset int64
+}
+
+// ResetOffsets resets (or with DryRun, simulates resetting) the offsets of
+// a consumer group.
+func (c *Client) ResetOffsets(ctx context.Context, opts ResetOffsetsOptions) ([]OffsetReset, error) {
+	if opts.Group == "" {
+		return nil, fmt.Errorf("consumer group is required")
+	}
+
+	output, err := c.ExecuteCommand(ctx, opts.Args()...)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResetOffsetsOutput(output), nil
+}
+
+// GetConsumerGroup retrieves a single consumer group.
+func (c *Client) GetConsumerGroup(ctx context.Context, name string) (models.ConsumerGroup, error) {
+	output, err := c.ExecuteCommand(ctx, "get", "consumer-groups", name, "-o", "yaml")
+	if err != nil {
+		return models.ConsumerGroup{}, err
+	}
+
+	groups, err := c.parseConsumerGroups(output)
+	if err != nil {
+		return models.ConsumerGroup{}, err
+	}
+	if len(groups) == 0 {
+		return models.ConsumerGroup{}, fmt.Errorf("consumer group %s not found", name)
+	}
+
+	return groups[0], nil
+}
+
+// ParseResetOffsetsOutput parses the table printed by kafkactl
+// reset-offsets. Columns are located by their header, so the order of
+// TOPIC, PARTITION and NEW_OFFSET does not matter.
+func ParseResetOffsetsOutput(data []byte) []OffsetReset {
+	var (
+		results                   []OffsetReset
+		topicCol, partCol, offCol = -1, -1, -1
+	)
+
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(strings.ReplaceAll(line, "|", " "))
+		if len(fields) == 0 {
+			continue
+		}
+
+		if partCol < 0 {
+			for i, field := range fields {
+				switch strings.ToUpper(field) {
+				case "TOPIC":
+					topicCol = i
+				case "PARTITION":
+					partCol = i
+				case "NEW_OFFSET", "OFFSET":
+					offCol = i
+				}
+			}
+			if partCol < 0 || offCol < 0 {
+				partCol = -1
+			}
+			continue
+		}
+
+		if partCol >= len(fields) || offCol >= len(fields) {
+			continue
+		}
+
+		partition, err := strconv.Atoi(fields[partCol])
+		if err != nil {
+			continue
+		}
+		offset, err := strconv.ParseInt(fields[offCol], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		reset := OffsetReset{Partition: partition, NewOffset: offset}
+		if topicCol >= 0 && topicCol < len(fields) {
+			reset.Topic = fields[topicCol]
+		}
+		results = append(results, reset)
+	}
+
+	return results
+}
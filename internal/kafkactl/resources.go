@@ -0,0 +1,81 @@
+package kafkactl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smart-fellas/k4a/pkg/models"
+)
+
+// ListTopics retrieves all topics of the namespace.
+func (c *Client) ListTopics(ctx context.Context) ([]models.Topic, error) {
+	return getList[models.Topic](ctx, c, "topics")
+}
+
+// ListSchemas retrieves all schemas of the namespace.
+func (c *Client) ListSchemas(ctx context.Context) ([]models.Schema, error) {
+	return getList[models.Schema](ctx, c, "schemas")
+}
+
+// ListConnectors retrieves all connectors of the namespace with their
+// status.
+func (c *Client) ListConnectors(ctx context.Context) ([]models.Connector, error) {
+	return getList[models.Connector](ctx, c, "connectors")
+}
+
+// ListACLs retrieves the access control entries visible to the namespace.
+func (c *Client) ListACLs(ctx context.Context) ([]models.AccessControlEntry, error) {
+	return getList[models.AccessControlEntry](ctx, c, "acls")
+}
+
+// ListConnectClusters retrieves the Connect clusters of the namespace.
+func (c *Client) ListConnectClusters(ctx context.Context) ([]models.ConnectCluster, error) {
+	return getList[models.ConnectCluster](ctx, c, "connect-clusters")
+}
+
+// ListStreams retrieves the Kafka Streams applications of the namespace.
+func (c *Client) ListStreams(ctx context.Context) ([]models.KafkaStream, error) {
+	return getList[models.KafkaStream](ctx, c, "streams")
+}
+
+// ListResourceQuotas retrieves the resource quotas of the namespace.
+func (c *Client) ListResourceQuotas(ctx context.Context) ([]models.ResourceQuota, error) {
+	return getList[models.ResourceQuota](ctx, c, "resource-quotas")
+}
+
+// getList runs kafkactl get for a resource type and decodes the documents
+// into typed resources. Documents that fail to decode are left out and
+// reported in an error wrapping models.ErrDecode, returned with the others.
+func getList[T any, PT models.RawSetter[T]](ctx context.Context, c *Client, resourceType string) ([]T, error) {
+	output, err := c.ExecuteCommand(ctx, "get", resourceType, "-o", "yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := c.parseYAMLList(output)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.DecodeList[T, PT](docs)
+}
+
+// GetNamespace retrieves the Namespace the client works in, with its topic
+// validator. ns4kafka may only let administrators read namespaces, so
+// callers should carry on without it when this fails.
+func (c *Client) GetNamespace(ctx context.Context) (models.Namespace, error) {
+	output, err := c.ExecuteCommand(ctx, "get", "namespace", c.Namespace(), "-o", "yaml")
+	if err != nil {
+		return models.Namespace{}, err
+	}
+
+	docs, err := c.parseYAMLList(output)
+	if err != nil {
+		return models.Namespace{}, err
+	}
+	if len(docs) == 0 {
+		return models.Namespace{}, fmt.Errorf("namespace %s not found", c.Namespace())
+	}
+
+	return models.Decode[models.Namespace](docs[0])
+}
@@ -0,0 +1,98 @@
+package kafkactl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/smart-fellas/k4a/pkg/models"
+)
+
+// ListSchemaVersions retrieves every version registered for a subject, in
+// registration order.
+func (c *Client) ListSchemaVersions(ctx context.Context, subject string) ([]models.Schema, error) {
+	output, err := c.ExecuteCommand(ctx, "get", "schema", subject, "--all-versions", "-o", "yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := c.parseYAMLList(output)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := models.DecodeList[models.Schema](docs)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no versions found for subject %s", subject)
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].Spec.Version < versions[j].Spec.Version
+	})
+
+	return versions, nil
+}
+
+// SchemaFromFile builds the Schema registering the .avsc, .json or .proto
+// file at path under subject. The schema type follows the extension.
+func SchemaFromFile(path, subject, namespace string) (models.Schema, error) {
+	var schemaType string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".avsc", ".avro":
+	case ".json":
+		schemaType = "JSON"
+	case ".proto":
+		schemaType = "PROTOBUF"
+	default:
+		return models.Schema{}, fmt.Errorf("%s is not an .avsc, .json or .proto file", filepath.Base(path))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return models.Schema{}, fmt.Errorf("failed to read schema: %w", err)
+	}
+	if strings.TrimSpace(string(data)) == "" {
+		return models.Schema{}, fmt.Errorf("%s is empty", filepath.Base(path))
+	}
+
+	return models.Schema{
+		BaseResource: models.BaseResource{
+			APIVersion: "v1",
+			Kind:       "Schema",
+			Metadata: models.ResourceMetadata{
+				Name:      subject,
+				Namespace: namespace,
+			},
+		},
+		Spec: models.SchemaSpec{
+			Schema:     strings.TrimSpace(string(data)),
+			SchemaType: schemaType,
+		},
+	}, nil
+}
+
+// CompatibilityLevels are the compatibility levels a subject can be set to.
+// GLOBAL drops the subject's own level for the Schema Registry's global one.
+var CompatibilityLevels = []string{
+	"BACKWARD",
+	"BACKWARD_TRANSITIVE",
+	"FORWARD",
+	"FORWARD_TRANSITIVE",
+	"FULL",
+	"FULL_TRANSITIVE",
+	"NONE",
+	"GLOBAL",
+}
+
+// SetSchemaCompatibility sets the compatibility level of a subject; kafkactl
+// takes the level as written in CompatibilityLevels.
+func (c *Client) SetSchemaCompatibility(ctx context.Context, subject, level string) error {
+	_, err := c.ExecuteCommand(ctx, "schema", level, subject)
+	return err
+}
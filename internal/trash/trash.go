@@ -0,0 +1,143 @@
+package trash
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/smart-fellas/k4a/internal/utils"
+)
+
+// timestampFormat is used in trash file names; it sorts lexically and
+// contains no characters that are awkward in file names. The microseconds
+// keep two deletions of a name within a second apart.
+const timestampFormat = "20060102-150405.000000"
+
+// Entry is a resource manifest saved before the resource was deleted.
+type Entry struct {
+	Context   string
+	Kind      string
+	Name      string
+	DeletedAt time.Time
+	Path      string
+}
+
+// Dir returns the trash directory, ~/.k4a/trash unless K4A_TRASH_DIR is set.
+func Dir() string {
+	if dir := os.Getenv("K4A_TRASH_DIR"); dir != "" {
+		return dir
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".k4a", "trash")
+	}
+
+	return filepath.Join(homeDir, ".k4a", "trash")
+}
+
+// Save stores a manifest as <dir>/<context>/<kind>/<name>-<timestamp>.yaml
+// and returns the file path. It never overwrites an earlier backup.
+func Save(context, kind, name string, manifest []byte) (string, error) {
+	dir := filepath.Join(Dir(), ContextDir(context), utils.SafeFileName(kind))
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create trash directory: %w", err)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.yaml", utils.SafeFileName(name), time.Now().Format(timestampFormat)))
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", fmt.Errorf("failed to create trash file: %w", err)
+	}
+	_, err = file.Write(manifest)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to write trash file: %w", err)
+	}
+
+	return path, nil
+}
+
+// ContextDir returns the name of the directory of a context's entries,
+// which is the Context of those entries.
+func ContextDir(context string) string {
+	return utils.SafeFileName(context)
+}
+
+// List returns every trash entry, most recently deleted first.
+func List() ([]Entry, error) {
+	root := Dir()
+
+	var entries []Entry
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".yaml" {
+			return nil
+		}
+
+		rel, relErr := filepath.Rel(root, path)
+		if relErr != nil {
+			return nil
+		}
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		if len(parts) != 3 {
+			return nil
+		}
+
+		name, deletedAt, ok := parseFileName(parts[2])
+		if !ok {
+			return nil
+		}
+
+		entries = append(entries, Entry{
+			Context:   parts[0],
+			Kind:      parts[1],
+			Name:      name,
+			DeletedAt: deletedAt,
+			Path:      path,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trash: %w", err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].DeletedAt.After(entries[j].DeletedAt)
+	})
+
+	return entries, nil
+}
+
+// Remove deletes a trash entry, typically after it has been restored.
+func Remove(entry Entry) error {
+	if err := os.Remove(entry.Path); err != nil {
+		return fmt.Errorf("failed to remove trash file: %w", err)
+	}
+	return nil
+}
+
+func parseFileName(file string) (string, time.Time, bool) {
+	base := strings.TrimSuffix(file, ".yaml")
+	if len(base) <= len(timestampFormat)+1 {
+		return "", time.Time{}, false
+	}
+
+	split := len(base) - len(timestampFormat)
+	deletedAt, err := time.ParseInLocation(timestampFormat, base[split:], time.Local)
+	if err != nil || base[split-1] != '-' {
+		return "", time.Time{}, false
+	}
+
+	return base[:split-1], deletedAt, true
+}
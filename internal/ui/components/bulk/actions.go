@@ -0,0 +1,104 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/config"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/deletion"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+	"github.com/smart-fellas/k4a/internal/utils"
+)
+
+// timestampFormat is used in export file names.
+const timestampFormat = "20060102-150405"
+
+// Describe shows the YAML of every item as one stream of documents.
+func Describe(client *kafkactl.Client, kind string) Action {
+	return Action{
+		Verb:       "Describe",
+		Past:       "Described",
+		Kind:       kind,
+		ShowOutput: true,
+		Run: func(ctx context.Context, name string) (string, error) {
+			return client.GetResourceYAML(ctx, kind, name)
+		},
+	}
+}
+
+// Delete backs every item up to the trash and deletes it, once the number
+// of items is typed. Done is run with the results, e.g. to reload the list.
+func Delete(client *kafkactl.Client, kind string, done func([]kafkactl.BulkResult) tea.Cmd) Action {
+	return Action{
+		Verb:    "Delete",
+		Past:    "Deleted",
+		Kind:    kind,
+		Confirm: Typed,
+		Message: "A YAML backup of each is saved to the trash and can be restored with :restore.",
+		Run: func(ctx context.Context, name string) (string, error) {
+			return deletion.Delete(ctx, client, kind, name)
+		},
+		Done: done,
+	}
+}
+
+// Export writes the manifests of the items, without the fields the server
+// sets, to a single file that can be applied again, and reports its path.
+func Export(client *kafkactl.Client, kind string) Action {
+	return Action{
+		Verb: "Export",
+		Past: "Exported",
+		Kind: kind,
+		Run: func(ctx context.Context, name string) (string, error) {
+			yaml, err := client.GetResourceYAML(ctx, kind, name)
+			if err != nil {
+				return "", err
+			}
+			manifest, err := kafkactl.StripServerFields([]byte(yaml))
+			return string(manifest), err
+		},
+		Done: func(results []kafkactl.BulkResult) tea.Cmd {
+			var documents []string
+			for _, result := range results {
+				if result.Err == nil {
+					documents = append(documents, strings.TrimSpace(result.Output))
+				}
+			}
+			if len(documents) == 0 {
+				return nil
+			}
+
+			return func() tea.Msg {
+				path, err := writeExport(client.ContextName(), kind, strings.Join(documents, "\n---\n")+"\n")
+				return footer.Result(fmt.Sprintf("Exported %s to %s", Count(len(documents), kind), path), err)()
+			}
+		},
+	}
+}
+
+// ExportDir returns the directory exports are written to, ~/.k4a/exports.
+func ExportDir() string {
+	return filepath.Join(config.SettingsDir(), "exports")
+}
+
+// writeExport stores an export as <dir>/<context>/<kind>s-<timestamp>.yaml
+// and returns the file path.
+func writeExport(context, kind, content string) (string, error) {
+	dir := filepath.Join(ExportDir(), utils.SafeFileName(context))
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%ss-%s.yaml", kind, time.Now().Format(timestampFormat)))
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write export: %w", err)
+	}
+
+	return path, nil
+}
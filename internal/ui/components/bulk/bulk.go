@@ -0,0 +1,381 @@
+// Package bulk runs an action on the rows marked in a table, through the
+// bounded worker pool of the kafkactl client, and shows the outcome of
+// each row as it completes.
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/confirm"
+	"github.com/smart-fellas/k4a/internal/ui/components/dialog"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+	"github.com/smart-fellas/k4a/internal/ui/keys"
+	"github.com/smart-fellas/k4a/internal/ui/styles"
+)
+
+// Confirmation is how a bulk action is confirmed before it runs.
+type Confirmation int
+
+const (
+	// NoConfirmation runs the action at once.
+	NoConfirmation Confirmation = iota
+	// YesNo asks to answer y or n.
+	YesNo
+	// Typed asks to type the number and kind of the items, e.g. 12 topics.
+	Typed
+)
+
+// listedInConfirmation is the number of items a confirmation names.
+const listedInConfirmation = 10
+
+// Action is an action run on every marked row.
+type Action struct {
+	// Verb and Past name the action, e.g. "Restart" and "Restarted"
+	Verb string
+	Past string
+	// Kind is the kafkactl kind of the items, e.g. "connector"
+	Kind    string
+	Confirm Confirmation
+	// Message explains the consequences in the confirmation
+	Message string
+	// ShowOutput shows the outputs of the items once done, as one YAML
+	// stream, rather than their outcomes
+	ShowOutput bool
+	// Run runs the action on one item and returns its output
+	Run func(ctx context.Context, item string) (string, error)
+	// Done, when set, is run with the results in item order once every
+	// item is done, e.g. to reload the list
+	Done func(results []kafkactl.BulkResult) tea.Cmd
+}
+
+type state int
+
+const (
+	stateIdle state = iota
+	stateConfirm
+	stateRunning
+	stateDone
+)
+
+var (
+	okStyle      = styles.StatusRunning
+	failedStyle  = styles.StatusFailed
+	pendingStyle = styles.MutedText
+)
+
+// Model confirms a bulk action if it asks to, runs it and shows the
+// progress with the outcome of each item. Esc cancels the items that have
+// not started, and closes the view once every item is done or the run is
+// cancelled.
+type Model struct {
+	client *kafkactl.Client
+	ctx    context.Context
+	cancel context.CancelFunc
+	action Action
+	items  []string
+	state  state
+	// cancelled is set once Esc cancelled the items that had not started
+	cancelled bool
+
+	// run tells the results of the current run from those of a run that
+	// was closed before it finished
+	run      int
+	results  <-chan kafkactl.BulkResult
+	outcomes []kafkactl.BulkResult
+	finished []bool
+	count    int
+
+	confirm confirm.Model
+	dialog  dialog.Model
+	keys    keys.KeyMap
+	width   int
+	height  int
+}
+
+func New(client *kafkactl.Client) Model {
+	return Model{
+		client: client,
+		dialog: dialog.New(),
+		keys:   keys.DefaultKeyMap(),
+	}
+}
+
+// Start runs action on items, once confirmed if the action asks for it.
+// Ctx bounds the kafkactl runs.
+func (m Model) Start(ctx context.Context, action Action, items []string) (Model, tea.Cmd) {
+	m.ctx, m.cancel = context.WithCancel(ctx)
+	m.action = action
+	m.items = items
+	m.run++
+	m.results = nil
+	m.outcomes = make([]kafkactl.BulkResult, len(items))
+	m.finished = make([]bool, len(items))
+	m.count = 0
+	m.cancelled = false
+
+	switch action.Confirm {
+	case YesNo:
+		m.confirm = confirm.NewYesNo(m.title(), m.confirmMessage(), m.client.ContextName(), m.client.Namespace())
+	case Typed:
+		m.confirm = confirm.New(m.title(), m.confirmMessage(), Count(len(items), action.Kind),
+			m.client.ContextName(), m.client.Namespace())
+	case NoConfirmation:
+		return m.execute()
+	}
+	m.confirm.SetSize(m.width, m.height)
+	m.state = stateConfirm
+	return m, nil
+}
+
+// Active reports whether an action is being confirmed, running or showing
+// its outcome.
+func (m Model) Active() bool {
+	return m.state != stateIdle
+}
+
+// Finished reports whether the action has run on every item and its
+// outcome is shown.
+func (m Model) Finished() bool {
+	return m.state == stateDone
+}
+
+// CapturingInput reports whether the typed confirmation is receiving
+// keystrokes or the action is running. The results of a run reach only the
+// current view, so it must not be left before they are all back.
+func (m Model) CapturingInput() bool {
+	return m.state == stateConfirm || m.state == stateRunning
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.confirm.SetSize(width, height)
+	m.dialog.SetSize(width, height)
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case resultMsg:
+		if msg.run != m.run {
+			return m, nil
+		}
+		m.outcomes[msg.result.Index] = msg.result
+		m.finished[msg.result.Index] = true
+		m.count++
+		m.render()
+		return m, m.wait
+
+	case finishedMsg:
+		if msg.run != m.run {
+			return m, nil
+		}
+		m.cancel()
+		m.state = stateDone
+		m.render()
+		if m.action.Done == nil {
+			return m, m.summary()
+		}
+		// Done runs after the summary, so that what it reports replaces it
+		return m, tea.Sequence(m.summary(), m.action.Done(m.outcomes))
+	}
+
+	switch m.state {
+	case stateConfirm:
+		var cmd tea.Cmd
+		m.confirm, cmd = m.confirm.Update(msg)
+		switch {
+		case m.confirm.Cancelled():
+			m.cancel()
+			m.state = stateIdle
+			return m, nil
+		case m.confirm.Confirmed():
+			return m.execute()
+		}
+		return m, cmd
+
+	case stateRunning, stateDone:
+		if msg, ok := msg.(tea.KeyMsg); ok && key.Matches(msg, m.keys.Back) {
+			if m.state == stateRunning && !m.cancelled {
+				// The items that have started finish; the others fail
+				// as cancelled
+				m.cancel()
+				m.cancelled = true
+				m.render()
+				return m, nil
+			}
+			if m.state == stateRunning {
+				// The results still to come belong to a closed run
+				m.run++
+				m.state = stateIdle
+				return m, footer.Failure(fmt.Sprintf("%s cancelled after %d/%d done", m.title(), m.count, len(m.items)))
+			}
+			m.state = stateIdle
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.dialog, cmd = m.dialog.Update(msg)
+		return m, cmd
+
+	case stateIdle:
+	}
+	return m, nil
+}
+
+func (m Model) View() string {
+	switch m.state {
+	case stateConfirm:
+		return m.confirm.View()
+	case stateRunning, stateDone:
+		return m.dialog.View()
+	case stateIdle:
+	}
+	return ""
+}
+
+// Count returns n with the kind in the singular or plural, e.g. 1 topic
+// or 12 topics.
+func Count(n int, kind string) string {
+	if n == 1 {
+		return "1 " + kind
+	}
+	return fmt.Sprintf("%d %ss", n, kind)
+}
+
+type resultMsg struct {
+	run    int
+	result kafkactl.BulkResult
+}
+
+type finishedMsg struct {
+	run int
+}
+
+func (m Model) execute() (Model, tea.Cmd) {
+	m.state = stateRunning
+	m.results = m.client.RunBulk(m.ctx, m.items, m.action.Run)
+	m.render()
+	return m, m.wait
+}
+
+// wait reads the next result of the run, until every item is done.
+func (m Model) wait() tea.Msg {
+	result, ok := <-m.results
+	if !ok {
+		return finishedMsg{run: m.run}
+	}
+	return resultMsg{run: m.run, result: result}
+}
+
+func (m Model) title() string {
+	return fmt.Sprintf("%s %s", m.action.Verb, Count(len(m.items), m.action.Kind))
+}
+
+// confirmMessage explains the action and names the first items.
+func (m Model) confirmMessage() string {
+	listed := m.items
+	more := ""
+	if len(listed) > listedInConfirmation {
+		listed = listed[:listedInConfirmation]
+		more = fmt.Sprintf("\n  and %d more", len(m.items)-listedInConfirmation)
+	}
+
+	message := "  " + strings.Join(listed, "\n  ") + more
+	if m.action.Message != "" {
+		message = m.action.Message + "\n\n" + message
+	}
+	return message
+}
+
+func (m *Model) render() {
+	switch {
+	case m.state == stateDone:
+		m.dialog.SetTitle(fmt.Sprintf("%s (ESC to close)", m.doneTitle()))
+	case m.cancelled:
+		m.dialog.SetTitle(fmt.Sprintf("%s: %d/%d done, cancelling (ESC to close)", m.title(), m.count, len(m.items)))
+	default:
+		m.dialog.SetTitle(fmt.Sprintf("%s: %d/%d done (ESC cancels the rest)", m.title(), m.count, len(m.items)))
+	}
+
+	if m.state == stateDone && m.action.ShowOutput {
+		m.dialog.SetContent(m.output())
+		return
+	}
+
+	var b strings.Builder
+	for i, item := range m.items {
+		switch outcome := m.outcomes[i]; {
+		case !m.finished[i]:
+			b.WriteString(pendingStyle.Render("· "+item) + "\n")
+		case outcome.Err != nil:
+			b.WriteString(failedStyle.Render("✗ "+item) + "  " + outcome.Err.Error() + "\n")
+		default:
+			b.WriteString(okStyle.Render("✓ "+item) + "\n")
+		}
+	}
+	m.dialog.SetContent(b.String())
+}
+
+// output joins the outputs of the items as YAML documents, with a comment
+// in place of those that failed.
+func (m Model) output() string {
+	documents := make([]string, 0, len(m.outcomes))
+	for _, outcome := range m.outcomes {
+		if outcome.Err != nil {
+			documents = append(documents, fmt.Sprintf("# %s: %v", outcome.Item, outcome.Err))
+			continue
+		}
+		documents = append(documents, strings.TrimSpace(outcome.Output))
+	}
+	return strings.Join(documents, "\n---\n") + "\n"
+}
+
+func (m Model) failures() []kafkactl.BulkResult {
+	var failed []kafkactl.BulkResult
+	for _, outcome := range m.outcomes {
+		if outcome.Err != nil {
+			failed = append(failed, outcome)
+		}
+	}
+	return failed
+}
+
+func (m Model) doneTitle() string {
+	succeeded := len(m.items) - len(m.failures())
+	if succeeded == len(m.items) {
+		return fmt.Sprintf("%s %s", m.action.Past, Count(len(m.items), m.action.Kind))
+	}
+	return fmt.Sprintf("%s %d of %s", m.action.Past, succeeded, Count(len(m.items), m.action.Kind))
+}
+
+// summary reports the outcome in the footer, naming the items that failed.
+func (m Model) summary() tea.Cmd {
+	failed := m.failures()
+	if len(failed) == 0 {
+		return footer.Success(m.doneTitle())
+	}
+
+	cancelled := 0
+	var names []string
+	for _, outcome := range failed {
+		if errors.Is(outcome.Err, context.Canceled) {
+			cancelled++
+			continue
+		}
+		names = append(names, outcome.Item)
+	}
+
+	text := m.doneTitle()
+	if len(names) > 0 {
+		text += "; failed: " + strings.Join(names, ", ")
+	}
+	if cancelled > 0 {
+		text += fmt.Sprintf("; %d cancelled", cancelled)
+	}
+	return footer.Failure(text)
+}
@@ -0,0 +1,175 @@
+// Package changes diffs successive snapshots of a list by metadata.name,
+// and keeps the rows that were added, changed or removed highlighted for a
+// while.
+package changes
+
+import (
+	"reflect"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/smart-fellas/k4a/internal/ui/styles"
+)
+
+// Highlight is how long a change stays highlighted.
+const Highlight = 10 * time.Second
+
+// Kind is how a row changed since the previous snapshot.
+type Kind int
+
+const (
+	// Unchanged rows are not highlighted.
+	Unchanged Kind = iota
+	Added
+	Changed
+	Removed
+)
+
+var (
+	addedStyle   = lipgloss.NewStyle().Foreground(styles.Success)
+	changedStyle = lipgloss.NewStyle().Foreground(styles.Warning)
+	removedStyle = lipgloss.NewStyle().Foreground(styles.Error).Strikethrough(true)
+)
+
+// ExpiredMsg asks the view to render its rows again once the changes of a
+// snapshot are no longer highlighted.
+type ExpiredMsg struct{}
+
+type change struct {
+	kind Kind
+	at   time.Time
+	// doc is the last document of a removed row
+	doc map[string]any
+}
+
+// Tracker holds the previous snapshot of a list, by name, and the changes
+// found in the snapshots since.
+type Tracker struct {
+	previous map[string]map[string]any
+	changes  map[string]change
+}
+
+// Update diffs snapshot, the documents of the rows by name, against the
+// previous one. The first snapshot only sets the baseline. The returned
+// command sends ExpiredMsg once the changes it found are no longer
+// highlighted.
+func (t Tracker) Update(snapshot map[string]map[string]any, now time.Time) (Tracker, tea.Cmd) {
+	if t.previous == nil {
+		return Tracker{previous: snapshot, changes: map[string]change{}}, nil
+	}
+
+	changes := make(map[string]change, len(t.changes))
+	for name, c := range t.changes {
+		if now.Sub(c.at) < Highlight {
+			changes[name] = c
+		}
+	}
+
+	found := false
+	for name, doc := range snapshot {
+		previous, existed := t.previous[name]
+		switch {
+		case !existed:
+			changes[name] = change{kind: Added, at: now}
+			found = true
+		case !reflect.DeepEqual(previous, doc):
+			changes[name] = change{kind: Changed, at: now}
+			found = true
+		}
+	}
+	for name := range t.previous {
+		if _, exists := snapshot[name]; !exists {
+			changes[name] = change{kind: Removed, at: now, doc: t.previous[name]}
+			found = true
+		}
+	}
+	t = Tracker{previous: snapshot, changes: changes}
+	if !found {
+		return t, nil
+	}
+	return t, tea.Tick(Highlight, func(time.Time) tea.Msg { return ExpiredMsg{} })
+}
+
+// Kind returns how the row of the given name changed, if it did within the
+// highlight duration.
+func (t Tracker) Kind(name string, now time.Time) Kind {
+	c, ok := t.changes[name]
+	if !ok || now.Sub(c.at) >= Highlight {
+		return Unchanged
+	}
+	return c.kind
+}
+
+// Removed returns the last documents of the rows removed within the
+// highlight duration, sorted by name, so that they can be listed until
+// then.
+func (t Tracker) Removed(now time.Time) []map[string]any {
+	var names []string
+	for name, c := range t.changes {
+		if c.kind == Removed && now.Sub(c.at) < Highlight {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	docs := make([]map[string]any, len(names))
+	for i, name := range names {
+		docs[i] = t.changes[name].doc
+	}
+	return docs
+}
+
+// Symbol returns the mark of a change in the selection column: + for an
+// added row, ~ for a changed one and - for a removed one.
+func (t Tracker) Symbol(name string, now time.Time) string {
+	switch t.Kind(name, now) {
+	case Added:
+		return "+"
+	case Changed:
+		return "~"
+	case Removed:
+		return "-"
+	case Unchanged:
+	}
+	return ""
+}
+
+// Render highlights cell, of the row of the given name, in a column of the
+// given width. The table counts the escape sequences of colors as part of
+// the width, so long cells are shortened for the color to fit.
+func (t Tracker) Render(name, cell string, width int, now time.Time) string {
+	var style lipgloss.Style
+	switch t.Kind(name, now) {
+	case Added:
+		style = addedStyle
+	case Changed:
+		style = changedStyle
+	case Removed:
+		style = removedStyle
+	case Unchanged:
+		return cell
+	}
+
+	rendered := style.Render(cell)
+	overhead := len(rendered) - len(cell)
+	if ansi.StringWidth(cell)+overhead <= width {
+		return rendered
+	}
+	if width-overhead < 4 {
+		return cell
+	}
+	return style.Render(ansi.Truncate(cell, width-overhead-1, "") + "…")
+}
+
+// Snapshot returns the documents of items by name, as Update expects them.
+func Snapshot[T any](items []T, doc func(T) (string, map[string]any)) map[string]map[string]any {
+	snapshot := make(map[string]map[string]any, len(items))
+	for _, item := range items {
+		name, raw := doc(item)
+		snapshot[name] = raw
+	}
+	return snapshot
+}
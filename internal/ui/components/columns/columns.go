@@ -0,0 +1,142 @@
+// Package columns renders table columns read from resource manifests, as
+// configured in column presets or declared by the generic resource view.
+package columns
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/smart-fellas/k4a/internal/config"
+	"github.com/smart-fellas/k4a/internal/utils"
+)
+
+// DefaultWidth is the width of configured columns that set none.
+const DefaultWidth = 15
+
+// Column is a table column showing the value at Path in each manifest,
+// resolved with utils.ExtractValue and rendered by Format.
+type Column struct {
+	Title string
+	Width int
+	Path  string
+	// Format renders the value as text; nil leaves it as it is
+	Format utils.Formatter
+	// Numeric columns are sorted by their value as a number
+	Numeric bool
+}
+
+// FromPreset builds the columns of a preset.
+func FromPreset(preset config.ColumnPreset) ([]Column, error) {
+	columns := make([]Column, 0, len(preset.Columns))
+	for _, spec := range preset.Columns {
+		format, err := utils.NamedFormatter(spec.Format)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", spec.Title, err)
+		}
+
+		width := spec.Width
+		if width == 0 {
+			width = DefaultWidth
+		}
+
+		name, _, _ := strings.Cut(spec.Format, ":")
+		columns = append(columns, Column{
+			Title:   spec.Title,
+			Width:   width,
+			Path:    spec.Path,
+			Format:  format,
+			Numeric: spec.Numeric || name == "duration" || name == "bytes",
+		})
+	}
+	return columns, nil
+}
+
+// Value renders the column of a manifest, or - if the manifest does not
+// set it.
+func (c Column) Value(doc map[string]any) string {
+	value, err := utils.ExtractValue(doc, c.Path)
+	if err != nil || value == nil {
+		return "-"
+	}
+	if c.Format != nil {
+		return c.Format(Text(value))
+	}
+	return Text(value)
+}
+
+// SortValue returns the value a manifest is sorted by in the column: the
+// value itself for numeric columns, as formatting may hide the number.
+func (c Column) SortValue(doc map[string]any) string {
+	if !c.Numeric {
+		return c.Value(doc)
+	}
+	value, err := utils.ExtractValue(doc, c.Path)
+	if err != nil || value == nil {
+		return ""
+	}
+	return Text(value)
+}
+
+// Table returns the table columns of columns.
+func Table(columns []Column) []table.Column {
+	tableColumns := make([]table.Column, len(columns))
+	for i, column := range columns {
+		tableColumns[i] = table.Column{Title: column.Title, Width: column.Width}
+	}
+	return tableColumns
+}
+
+// Numeric returns the titles of the numeric columns.
+func Numeric(columns []Column) []string {
+	var titles []string
+	for _, column := range columns {
+		if column.Numeric {
+			titles = append(titles, column.Title)
+		}
+	}
+	return titles
+}
+
+// Find returns the column of a title.
+func Find(columns []Column, title string) (Column, bool) {
+	i := slices.IndexFunc(columns, func(c Column) bool { return c.Title == title })
+	if i < 0 {
+		return Column{}, false
+	}
+	return columns[i], true
+}
+
+// Values renders the columns of a manifest.
+func Values(columns []Column, doc map[string]any) []string {
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		values[i] = column.Value(doc)
+	}
+	return values
+}
+
+// Text renders a value as text: lists are joined by commas and maps are
+// listed as sorted key=value pairs.
+func Text(value any) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case []any:
+		items := make([]string, len(value))
+		for i, item := range value {
+			items[i] = Text(item)
+		}
+		return strings.Join(items, ", ")
+	case map[string]any:
+		pairs := make([]string, 0, len(value))
+		for key, item := range value {
+			pairs = append(pairs, key+"="+Text(item))
+		}
+		slices.Sort(pairs)
+		return strings.Join(pairs, ", ")
+	default:
+		return fmt.Sprint(value)
+	}
+}
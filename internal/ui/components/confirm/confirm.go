@@ -0,0 +1,196 @@
+package confirm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Model is a modal that only confirms once the user has typed an expected
+// value, typically the name of the resource about to be destroyed, or, when
+// created with NewYesNo, once the user answers yes.
+type Model struct {
+	title     string
+	message   string
+	expected  string
+	context   string
+	namespace string
+
+	input     textinput.Model
+	yesNo     bool
+	confirmed bool
+	cancelled bool
+	mismatch  bool
+	width     int
+	height    int
+}
+
+var (
+	boxStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("196")).
+			Padding(1, 2)
+
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("196"))
+
+	targetStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("196"))
+
+	labelStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("245"))
+
+	hintStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241"))
+)
+
+// New creates a typed confirmation. The user must type expected exactly;
+// context and namespace are shown so it is obvious where the action runs.
+func New(title, message, expected, context, namespace string) Model {
+	ti := textinput.New()
+	ti.Placeholder = expected
+	ti.CharLimit = 255
+	ti.Width = 50
+	ti.Prompt = "> "
+	ti.Focus()
+
+	return Model{
+		title:     title,
+		message:   message,
+		expected:  expected,
+		context:   context,
+		namespace: namespace,
+		input:     ti,
+	}
+}
+
+// NewYesNo creates a confirmation answered with y or enter, and declined
+// with n or esc.
+func NewYesNo(title, message, context, namespace string) Model {
+	return Model{
+		title:     title,
+		message:   message,
+		context:   context,
+		namespace: namespace,
+		yesNo:     true,
+	}
+}
+
+// Confirmed reports whether the expected value was typed and submitted.
+func (m Model) Confirmed() bool {
+	return m.confirmed
+}
+
+// Cancelled reports whether the modal was dismissed.
+func (m Model) Cancelled() bool {
+	return m.cancelled
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if m.yesNo {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			switch msg.String() {
+			case "y", "Y", "enter":
+				m.confirmed = true
+			case "n", "N", "esc":
+				m.cancelled = true
+			}
+		}
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.cancelled = true
+			return m, nil
+		case tea.KeyEnter:
+			if m.input.Value() == m.expected {
+				m.confirmed = true
+			} else {
+				m.mismatch = true
+			}
+			return m, nil
+		default:
+			m.mismatch = false
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m Model) View() string {
+	box := m.box()
+	if m.width == 0 || m.height == 0 {
+		return box
+	}
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// Over renders the modal centered over background, typically the view it
+// confirms an action of, so that the view stays visible around it.
+func (m Model) Over(background string) string {
+	box := m.box()
+	boxLines := strings.Split(box, "\n")
+	lines := strings.Split(background, "\n")
+
+	width := max(m.width, lipgloss.Width(background), lipgloss.Width(box))
+	height := max(m.height, len(lines), len(boxLines))
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+
+	top := (height - len(boxLines)) / 2
+	left := (width - lipgloss.Width(box)) / 2
+	for i, boxLine := range boxLines {
+		line := lines[top+i]
+		before := ansi.Truncate(line, left, "")
+		before += strings.Repeat(" ", left-ansi.StringWidth(before))
+		after := ansi.TruncateLeft(line, left+ansi.StringWidth(boxLine), "")
+		lines[top+i] = before + boxLine + after
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (m Model) box() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(m.title) + "\n\n")
+	b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Context:  "), targetStyle.Render(m.context)))
+	b.WriteString(fmt.Sprintf("%s %s\n\n", labelStyle.Render("Namespace:"), targetStyle.Render(m.namespace)))
+
+	if m.message != "" {
+		b.WriteString(m.message + "\n\n")
+	}
+
+	if m.yesNo {
+		b.WriteString(hintStyle.Render("y/enter to confirm, n/esc to cancel"))
+		return boxStyle.Render(b.String())
+	}
+
+	b.WriteString(fmt.Sprintf("Type %s to confirm:\n", targetStyle.Render(m.expected)))
+	b.WriteString(m.input.View() + "\n")
+
+	if m.mismatch {
+		b.WriteString(titleStyle.Render("Typed value does not match") + "\n")
+	}
+
+	b.WriteString("\n" + hintStyle.Render("enter to confirm, esc to cancel"))
+
+	return boxStyle.Render(b.String())
+}
@@ -0,0 +1,181 @@
+package deletion
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/trash"
+	"github.com/smart-fellas/k4a/internal/ui/components/confirm"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+)
+
+type state int
+
+const (
+	stateIdle state = iota
+	stateConfirm
+	stateDeleting
+	stateDone
+)
+
+var (
+	statusStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("220"))
+
+	errorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196"))
+)
+
+// Model runs the delete workflow for a single resource: a typed-name
+// confirmation, a YAML backup to the trash directory, then the delete.
+type Model struct {
+	client *kafkactl.Client
+	ctx    context.Context
+	kind   string
+	name   string
+	state  state
+
+	confirm confirm.Model
+	deleted bool
+	result  string
+	err     error
+	width   int
+	height  int
+}
+
+func New(client *kafkactl.Client) Model {
+	return Model{client: client}
+}
+
+// Start asks for confirmation before deleting the named resource. Kind is
+// the kafkactl resource type, e.g. "topic" or "connector"; ctx bounds the
+// kafkactl runs.
+func (m Model) Start(ctx context.Context, kind, name string) Model {
+	m.ctx = ctx
+	m.kind = kind
+	m.name = name
+	m.state = stateConfirm
+	m.deleted = false
+	m.result = ""
+	m.err = nil
+	m.confirm = confirm.New(
+		fmt.Sprintf("Delete %s %s", kind, name),
+		"A YAML backup is saved to the trash and can be restored with :restore.",
+		name,
+		m.client.ContextName(),
+		m.client.Namespace(),
+	)
+	m.confirm.SetSize(m.width, m.height)
+	return m
+}
+
+// Active reports whether a delete is being confirmed or running.
+func (m Model) Active() bool {
+	return m.state == stateConfirm || m.state == stateDeleting
+}
+
+// Deleted reports whether the last delete succeeded. It is reset by Start.
+func (m Model) Deleted() bool {
+	return m.state == stateDone && m.deleted
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.confirm.SetSize(width, height)
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case deletedMsg:
+		m.state = stateDone
+		m.err = msg.err
+		if msg.err == nil {
+			m.deleted = true
+			m.result = fmt.Sprintf("Deleted %s %s (backup: %s)", m.kind, m.name, msg.backup)
+		}
+		return m, footer.Result(m.result, m.err)
+	}
+
+	if m.state != stateConfirm {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.confirm, cmd = m.confirm.Update(msg)
+
+	switch {
+	case m.confirm.Cancelled():
+		m.state = stateIdle
+		return m, nil
+	case m.confirm.Confirmed():
+		m.state = stateDeleting
+		return m, m.delete
+	}
+
+	return m, cmd
+}
+
+func (m Model) View() string {
+	switch m.state {
+	case stateConfirm:
+		return m.confirm.View()
+	case stateDeleting:
+		return statusStyle.Render(fmt.Sprintf("Deleting %s %s...", m.kind, m.name))
+	case stateDone:
+		if m.err != nil {
+			return errorStyle.Render(m.err.Error())
+		}
+		return statusStyle.Render(m.result)
+	case stateIdle:
+	}
+	return ""
+}
+
+type deletedMsg struct {
+	backup string
+	err    error
+}
+
+func (m Model) delete() tea.Msg {
+	backup, err := Delete(m.ctx, m.client, m.kind, m.name)
+	return deletedMsg{backup: backup, err: err}
+}
+
+// Delete backs the resource up to the trash before deleting it, and
+// refuses to delete anything it could not back up. It returns the path of
+// the backup, which is removed again only if kafkactl refused the delete:
+// after a timeout or any other failure the delete may have reached the
+// server, and the backup is kept.
+func Delete(ctx context.Context, client *kafkactl.Client, kind, name string) (string, error) {
+	yaml, err := client.GetResourceYAML(ctx, kind, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to back up %s %s: %w", kind, name, err)
+	}
+
+	manifest, err := kafkactl.StripServerFields([]byte(yaml))
+	if err != nil {
+		return "", fmt.Errorf("failed to back up %s %s: %w", kind, name, err)
+	}
+
+	backup, err := trash.Save(client.ContextName(), kind, name, manifest)
+	if err != nil {
+		return "", err
+	}
+
+	if err = client.DeleteResource(ctx, kind, name); err != nil {
+		if !kafkactl.IsExitError(err) {
+			return "", fmt.Errorf("failed to delete %s %s: %w (backup kept: %s)", kind, name, err, backup)
+		}
+		// The resource is still there, so there is nothing to restore
+		if removeErr := trash.Remove(trash.Entry{Path: backup}); removeErr != nil {
+			return "", fmt.Errorf("failed to delete %s %s: %w (and %w)", kind, name, err, removeErr)
+		}
+		return "", fmt.Errorf("failed to delete %s %s: %w", kind, name, err)
+	}
+
+	return backup, nil
+}
@@ -43,6 +43,15 @@ func (m *Model) SetTitle(title string) {
 	m.title = title
 }
 
+// SetSize sizes the dialog to the area it is rendered in.
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.viewport.Width = m.width - 6
+	m.viewport.Height = m.height - 6
+	m.viewport.SetContent(m.content)
+}
+
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
 
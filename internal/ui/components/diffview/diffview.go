@@ -0,0 +1,158 @@
+package diffview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/utils"
+)
+
+// Context is the number of unchanged lines kept around each change; longer
+// unchanged runs are collapsed.
+const Context = 3
+
+var (
+	headerStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("229"))
+
+	deleteStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196"))
+
+	insertStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("42"))
+
+	collapsedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241"))
+)
+
+// Unified renders a diff in unified format, with removed lines in red and
+// added lines in green.
+func Unified(diff []utils.DiffLine, oldName, newName string) string {
+	var b strings.Builder
+
+	b.WriteString(headerStyle.Render("--- "+oldName) + "\n")
+	b.WriteString(headerStyle.Render("+++ "+newName) + "\n")
+
+	if !changed(diff) {
+		b.WriteString(collapsedStyle.Render("No differences"))
+		return b.String()
+	}
+
+	visible := visibleLines(diff)
+	for i, line := range diff {
+		if !visible[i] {
+			if i == 0 || visible[i-1] {
+				b.WriteString(collapsedStyle.Render(fmt.Sprintf("@@ %d unchanged lines @@", hiddenRun(visible, i))) + "\n")
+			}
+			continue
+		}
+
+		switch line.Op {
+		case utils.DiffDelete:
+			b.WriteString(deleteStyle.Render("-"+line.Text) + "\n")
+		case utils.DiffInsert:
+			b.WriteString(insertStyle.Render("+"+line.Text) + "\n")
+		case utils.DiffEqual:
+			b.WriteString(" " + line.Text + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// SideBySide renders a diff in two columns, the old text on the left and
+// the new on the right, fitting in width.
+func SideBySide(diff []utils.DiffLine, oldName, newName string, width int) string {
+	column := max((width-3)/2, 10)
+
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(cell(oldName, column)) + " │ " + headerStyle.Render(cell(newName, column)) + "\n")
+
+	if !changed(diff) {
+		b.WriteString(collapsedStyle.Render("No differences"))
+		return b.String()
+	}
+
+	visible := visibleLines(diff)
+	for i := 0; i < len(diff); {
+		if !visible[i] {
+			run := hiddenRun(visible, i)
+			b.WriteString(collapsedStyle.Render(fmt.Sprintf("@@ %d unchanged lines @@", run)) + "\n")
+			i += run
+			continue
+		}
+
+		if diff[i].Op == utils.DiffEqual {
+			text := cell(diff[i].Text, column)
+			b.WriteString(text + " │ " + text + "\n")
+			i++
+			continue
+		}
+
+		// Pair the lines removed by a change with the lines it added
+		var deleted, inserted []string
+		for ; i < len(diff) && diff[i].Op == utils.DiffDelete; i++ {
+			deleted = append(deleted, diff[i].Text)
+		}
+		for ; i < len(diff) && diff[i].Op == utils.DiffInsert; i++ {
+			inserted = append(inserted, diff[i].Text)
+		}
+		for row := range max(len(deleted), len(inserted)) {
+			left, right := cell("", column), cell("", column)
+			if row < len(deleted) {
+				left = deleteStyle.Render(cell(deleted[row], column))
+			}
+			if row < len(inserted) {
+				right = insertStyle.Render(cell(inserted[row], column))
+			}
+			b.WriteString(left + " │ " + right + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+func changed(diff []utils.DiffLine) bool {
+	for _, line := range diff {
+		if line.Op != utils.DiffEqual {
+			return true
+		}
+	}
+	return false
+}
+
+// visibleLines marks the changed lines and the unchanged lines within
+// Context of a change.
+func visibleLines(diff []utils.DiffLine) []bool {
+	visible := make([]bool, len(diff))
+	for i, line := range diff {
+		if line.Op == utils.DiffEqual {
+			continue
+		}
+		for j := max(i-Context, 0); j <= min(i+Context, len(diff)-1); j++ {
+			visible[j] = true
+		}
+	}
+	return visible
+}
+
+// hiddenRun returns the number of hidden lines starting at i.
+func hiddenRun(visible []bool, i int) int {
+	run := 0
+	for ; i < len(visible) && !visible[i]; i++ {
+		run++
+	}
+	return run
+}
+
+// cell fits text in a column of width runes, truncating or padding it.
+func cell(text string, width int) string {
+	text = strings.ReplaceAll(text, "\t", "  ")
+	runes := []rune(text)
+	if len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	return text + strings.Repeat(" ", width-len(runes))
+}
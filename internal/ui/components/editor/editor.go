@@ -0,0 +1,322 @@
+package editor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/dialog"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+)
+
+type state int
+
+const (
+	stateIdle state = iota
+	stateFetching
+	stateEditing
+	stateDiffing
+	stateConfirm
+	stateApplying
+	stateDone
+)
+
+// annotationPrefix marks the lines k4a adds on top of a manifest after a
+// failed apply. They are removed before the manifest is diffed or applied.
+const annotationPrefix = "# k4a: "
+
+var (
+	statusStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("220"))
+
+	errorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196"))
+)
+
+// Model runs the edit round trip for a single resource: fetch its YAML,
+// open it in $EDITOR, show the kafkactl diff and apply after confirmation.
+type Model struct {
+	client *kafkactl.Client
+	ctx    context.Context
+	kind   string
+	name   string
+	state  state
+
+	file     string
+	original string
+
+	dialog  dialog.Model
+	applied bool
+	result  string
+	err     error
+	width   int
+	height  int
+}
+
+func New(client *kafkactl.Client) Model {
+	return Model{
+		client: client,
+		dialog: dialog.New(),
+	}
+}
+
+// Start begins editing the named resource; ctx bounds its kafkactl runs.
+func (m Model) Start(ctx context.Context, kind, name string) (Model, tea.Cmd) {
+	m.ctx = ctx
+	m.kind = kind
+	m.name = name
+	m.state = stateFetching
+	m.applied = false
+	m.result = ""
+	m.err = nil
+	return m, m.fetch
+}
+
+// Active reports whether an edit is in progress.
+func (m Model) Active() bool {
+	return m.state != stateIdle && m.state != stateDone
+}
+
+// Applied reports whether the last edit was applied successfully. It is
+// reset by Start.
+func (m Model) Applied() bool {
+	return m.state == stateDone && m.applied
+}
+
+// Result returns the outcome of the last edit.
+func (m Model) Result() (string, error) {
+	return m.result, m.err
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.dialog.SetSize(width, height)
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case fetchedMsg:
+		if msg.err != nil {
+			return m.finish("", fmt.Errorf("failed to fetch %s %s: %w", m.kind, m.name, msg.err))
+		}
+		file, err := os.CreateTemp("", fmt.Sprintf("k4a-%s-%s-*.yaml", m.kind, m.name))
+		if err != nil {
+			return m.finish("", fmt.Errorf("failed to create edit file: %w", err))
+		}
+		_, err = file.WriteString(msg.yaml)
+		file.Close()
+		if err != nil {
+			return m.finish("", fmt.Errorf("failed to write edit file: %w", err))
+		}
+		m.file = file.Name()
+		m.original = msg.yaml
+		return m.openEditor()
+
+	case editorClosedMsg:
+		if msg.err != nil {
+			return m.finish("", fmt.Errorf("editor failed: %w", msg.err))
+		}
+		content, err := os.ReadFile(m.file)
+		if err != nil {
+			return m.finish("", fmt.Errorf("failed to read edit file: %w", err))
+		}
+		manifest := stripAnnotations(string(content))
+		if manifest == m.original {
+			return m.finish("No changes made to "+m.kind+" "+m.name, nil)
+		}
+		if err = os.WriteFile(m.file, []byte(manifest), 0o600); err != nil {
+			return m.finish("", fmt.Errorf("failed to write edit file: %w", err))
+		}
+		m.state = stateDiffing
+		return m, m.diff
+
+	case diffMsg:
+		m.state = stateConfirm
+		if msg.err != nil {
+			m.dialog.SetTitle("Diff failed (e to edit again, esc to discard)")
+			m.dialog.SetContent(msg.err.Error())
+		} else {
+			m.dialog.SetTitle(fmt.Sprintf("Apply changes to %s %s? (y to apply, e to edit again, esc to discard)", m.kind, m.name))
+			m.dialog.SetContent(msg.diff)
+		}
+		return m, nil
+
+	case appliedMsg:
+		if msg.err != nil {
+			if err := annotate(m.file, msg.err); err != nil {
+				return m.finish("", err)
+			}
+			return m.openEditor()
+		}
+		m.applied = true
+		result := strings.TrimSpace(msg.output)
+		if result == "" {
+			result = fmt.Sprintf("Applied %s %s", m.kind, m.name)
+		}
+		return m.finish(result, nil)
+
+	case tea.KeyMsg:
+		if m.state != stateConfirm {
+			return m, nil
+		}
+		switch msg.String() {
+		case "y":
+			m.state = stateApplying
+			return m, m.apply
+		case "e":
+			return m.openEditor()
+		case "esc", "n":
+			return m.finish("Discarded changes to "+m.kind+" "+m.name, nil)
+		}
+	}
+
+	if m.state == stateConfirm {
+		var cmd tea.Cmd
+		m.dialog, cmd = m.dialog.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	switch m.state {
+	case stateFetching:
+		return statusStyle.Render(fmt.Sprintf("Fetching %s %s...", m.kind, m.name))
+	case stateEditing:
+		return statusStyle.Render("Waiting for editor to close...")
+	case stateDiffing:
+		return statusStyle.Render("Computing diff...")
+	case stateConfirm:
+		return m.dialog.View()
+	case stateApplying:
+		return statusStyle.Render(fmt.Sprintf("Applying %s %s...", m.kind, m.name))
+	case stateDone:
+		if m.err != nil {
+			return errorStyle.Render(m.err.Error())
+		}
+		return statusStyle.Render(m.result)
+	case stateIdle:
+	}
+	return ""
+}
+
+func (m Model) openEditor() (Model, tea.Cmd) {
+	m.state = stateEditing
+	return m, tea.ExecProcess(editorCommand(m.file), func(err error) tea.Msg {
+		return editorClosedMsg{err: err}
+	})
+}
+
+func (m Model) finish(result string, err error) (Model, tea.Cmd) {
+	if m.file != "" {
+		os.Remove(m.file)
+		m.file = ""
+	}
+	m.state = stateDone
+	m.result = result
+	m.err = err
+
+	switch {
+	case err != nil:
+		return m, footer.Failure(err.Error())
+	case m.applied:
+		return m, footer.Success(result)
+	default:
+		return m, footer.Info(result)
+	}
+}
+
+// editorCommand builds the command for $VISUAL or $EDITOR, which may carry
+// arguments such as "code --wait". Blank variables are skipped, down to vi.
+func editorCommand(file string) *exec.Cmd {
+	parts := strings.Fields(os.Getenv("VISUAL"))
+	if len(parts) == 0 {
+		parts = strings.Fields(os.Getenv("EDITOR"))
+	}
+	if len(parts) == 0 {
+		parts = []string{"vi"}
+	}
+
+	args := append(parts[1:], file)
+	return exec.Command(parts[0], args...)
+}
+
+func stripAnnotations(content string) string {
+	lines := strings.Split(content, "\n")
+	i := 0
+	for i < len(lines) && strings.HasPrefix(lines[i], annotationPrefix) {
+		i++
+	}
+	return strings.Join(lines[i:], "\n")
+}
+
+// annotate prefixes the manifest file with the apply error so the user sees
+// why it was rejected when the editor reopens.
+func annotate(file string, applyErr error) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read edit file: %w", err)
+	}
+
+	var b strings.Builder
+	b.WriteString(annotationPrefix + "apply failed, fix the manifest below and save to retry\n")
+	for _, line := range strings.Split(strings.TrimSpace(applyErr.Error()), "\n") {
+		b.WriteString(annotationPrefix + line + "\n")
+	}
+	b.WriteString(stripAnnotations(string(content)))
+
+	if err = os.WriteFile(file, []byte(b.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to write edit file: %w", err)
+	}
+	return nil
+}
+
+type fetchedMsg struct {
+	yaml string
+	err  error
+}
+
+type editorClosedMsg struct {
+	err error
+}
+
+type diffMsg struct {
+	diff string
+	err  error
+}
+
+type appliedMsg struct {
+	output string
+	err    error
+}
+
+func (m Model) fetch() tea.Msg {
+	yaml, err := m.client.GetResourceYAML(m.ctx, m.kind, m.name)
+	if err != nil {
+		return fetchedMsg{err: err}
+	}
+
+	stripped, err := kafkactl.StripServerFields([]byte(yaml))
+	if err != nil {
+		return fetchedMsg{err: err}
+	}
+
+	return fetchedMsg{yaml: string(stripped)}
+}
+
+func (m Model) diff() tea.Msg {
+	output, err := m.client.Diff(m.ctx, m.file)
+	return diffMsg{diff: output, err: err}
+}
+
+func (m Model) apply() tea.Msg {
+	output, err := m.client.ApplyFile(m.ctx, m.file)
+	return appliedMsg{output: output, err: err}
+}
@@ -0,0 +1,128 @@
+package filter
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/utils"
+)
+
+// Model is an inline filter bar. Rows are narrowed as the expression is
+// typed; enter keeps the filter and returns focus to the table, esc clears
+// it. See utils.CompileFilter for the expression syntax.
+type Model struct {
+	input   textinput.Model
+	editing bool
+	err     error
+}
+
+var (
+	promptStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("86")).
+			Bold(true)
+
+	valueStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("229"))
+
+	errorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196"))
+)
+
+func New() Model {
+	ti := textinput.New()
+	ti.Placeholder = "name, /regex/ or spec.partitions>=12"
+	ti.CharLimit = 255
+	ti.Width = 60
+	ti.Prompt = "/"
+	ti.PromptStyle = promptStyle
+
+	return Model{input: ti}
+}
+
+// Start focuses the filter bar, keeping any expression already entered.
+func (m Model) Start() (Model, tea.Cmd) {
+	m.editing = true
+	m.input.CursorEnd()
+	cmd := m.input.Focus()
+	return m, cmd
+}
+
+// Editing reports whether the filter bar is receiving keystrokes.
+func (m Model) Editing() bool {
+	return m.editing
+}
+
+// Value returns the current filter expression.
+func (m Model) Value() string {
+	return m.input.Value()
+}
+
+// Err returns the compile error of the current expression, if any.
+func (m Model) Err() error {
+	return m.err
+}
+
+// Clear removes the filter and leaves edit mode.
+func (m Model) Clear() Model {
+	m.input.SetValue("")
+	m.input.Blur()
+	m.editing = false
+	m.err = nil
+	return m
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.editing {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyEsc:
+			return m.Clear(), nil
+		case tea.KeyEnter:
+			m.editing = false
+			m.input.Blur()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.compile()
+	return m, cmd
+}
+
+func (m *Model) compile() {
+	m.err = nil
+
+	if m.input.Value() == "" {
+		return
+	}
+
+	_, m.err = utils.CompileFilter(m.input.Value())
+}
+
+// Apply returns the resources whose document, as returned by doc, matches
+// the filter; see utils.FilterResources.
+func Apply[T any](m Model, resources []T, doc func(T) map[string]any) []T {
+	return utils.FilterResources(resources, m.input.Value(), doc)
+}
+
+// View renders the filter bar, or nothing when no filter is set.
+func (m Model) View() string {
+	if m.editing {
+		if m.err != nil {
+			return m.input.View() + "  " + errorStyle.Render(m.err.Error())
+		}
+		return m.input.View()
+	}
+
+	if m.input.Value() == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s %s", promptStyle.Render("filter:"), valueStyle.Render(m.input.Value()))
+}
@@ -3,13 +3,72 @@ package footer
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// MessageTimeout is how long a message stays in the footer.
+const MessageTimeout = 5 * time.Second
+
+// Level is the kind of a footer message, which sets its color.
+type Level int
+
+const (
+	LevelInfo Level = iota
+	LevelSuccess
+	LevelError
+)
+
+// MessageMsg asks the app to show a message in the footer. Views report
+// the outcome of their actions with it rather than rendering it themselves.
+type MessageMsg struct {
+	Text  string
+	Level Level
+}
+
+// Info returns a command showing a neutral message.
+func Info(text string) tea.Cmd {
+	return notify(text, LevelInfo)
+}
+
+// Success returns a command reporting a successful action.
+func Success(text string) tea.Cmd {
+	return notify(text, LevelSuccess)
+}
+
+// Failure returns a command reporting a failed action or load.
+func Failure(text string) tea.Cmd {
+	return notify(text, LevelError)
+}
+
+// Result reports err as a failure if it is set, and text as a success
+// otherwise.
+func Result(text string, err error) tea.Cmd {
+	if err != nil {
+		return Failure(err.Error())
+	}
+	return Success(text)
+}
+
+func notify(text string, level Level) tea.Cmd {
+	return func() tea.Msg {
+		return MessageMsg{Text: text, Level: level}
+	}
+}
+
+// expiredMsg clears the message it was scheduled for, unless another
+// message replaced it since.
+type expiredMsg struct {
+	id int
+}
+
 type Model struct {
 	keybindings []Keybinding
 	message     string
+	level       Level
+	messageID   int
 	width       int
 }
 
@@ -33,6 +92,12 @@ var (
 
 	messageStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("220"))
+
+	successStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("42"))
+
+	errorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196"))
 )
 
 func New() Model {
@@ -54,8 +119,25 @@ func DefaultKeybindings() []Keybinding {
 	}
 }
 
-func (m *Model) SetMessage(msg string) {
+// SetMessage shows a message and returns the command clearing it after
+// MessageTimeout.
+func (m *Model) SetMessage(msg string, level Level) tea.Cmd {
 	m.message = msg
+	m.level = level
+	m.messageID++
+
+	id := m.messageID
+	return tea.Tick(MessageTimeout, func(time.Time) tea.Msg {
+		return expiredMsg{id: id}
+	})
+}
+
+// Update clears the message once it expires.
+func (m Model) Update(msg tea.Msg) Model {
+	if msg, ok := msg.(expiredMsg); ok && msg.id == m.messageID {
+		m.message = ""
+	}
+	return m
 }
 
 func (m *Model) ClearMessage() {
@@ -84,7 +166,15 @@ func (m Model) View() string {
 	keysLine := strings.Join(parts, "  ")
 
 	if m.message != "" {
-		keysLine += "  |  " + messageStyle.Render(m.message)
+		style := messageStyle
+		switch m.level {
+		case LevelSuccess:
+			style = successStyle
+		case LevelError:
+			style = errorStyle
+		case LevelInfo:
+		}
+		keysLine += "  |  " + style.Render(m.message)
 	}
 
 	// Ensure footer spans full width
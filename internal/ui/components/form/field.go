@@ -0,0 +1,117 @@
+package form
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/smart-fellas/k4a/internal/utils"
+)
+
+// Kind is the type of value a field holds.
+type Kind int
+
+const (
+	// Text is free text.
+	Text Kind = iota
+	// Int is a whole number.
+	Int
+	// Duration is a human duration such as 7d or 12h, read as milliseconds.
+	Duration
+	// Select picks one of the options.
+	Select
+	// Bool is a yes/no switch.
+	Bool
+	// MultiSelect picks any number of the options.
+	MultiSelect
+)
+
+// Field declares one input of a form. Values of every kind are strings:
+// Bool fields hold "true" or "false" and MultiSelect fields the selected
+// options joined with commas, in the order of Options, so the options of
+// a MultiSelect must not contain commas.
+type Field struct {
+	Key         string
+	Label       string
+	Kind        Kind
+	Placeholder string
+	// Hint is shown next to the field while it has no error
+	Hint    string
+	Default string
+	Options []string
+	// Required rejects an empty value
+	Required bool
+	// Validate checks the value once it parsed as its kind
+	Validate func(value string) error
+}
+
+// check validates a value against the field's kind, then its own check.
+func (f Field) check(value string) error {
+	if value == "" {
+		if f.Required {
+			return fmt.Errorf("%s is required", strings.ToLower(f.Label))
+		}
+		if f.Kind != Text {
+			return nil
+		}
+	}
+
+	switch f.Kind {
+	case Int:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("must be a whole number")
+		}
+	case Duration:
+		if _, err := utils.ParseDuration(value); err != nil {
+			return err
+		}
+	case Text, Select, Bool, MultiSelect:
+	}
+
+	if f.Validate != nil {
+		return f.Validate(value)
+	}
+	return nil
+}
+
+// Positive is a Validate function accepting numbers above zero.
+func Positive(value string) error {
+	if n, err := strconv.Atoi(value); err != nil || n < 1 {
+		return fmt.Errorf("must be a positive number")
+	}
+	return nil
+}
+
+// Values holds the value of each field by key.
+type Values map[string]string
+
+// String returns the value of a field.
+func (v Values) String(key string) string {
+	return v[key]
+}
+
+// Int returns the value of an Int field, or 0 when it is not a number.
+func (v Values) Int(key string) int {
+	n, _ := strconv.Atoi(v[key])
+	return n
+}
+
+// Duration returns the value of a Duration field in milliseconds, or 0
+// when it is not a duration.
+func (v Values) Duration(key string) int64 {
+	ms, _ := utils.ParseDuration(v[key])
+	return ms
+}
+
+// Bool returns the value of a Bool field.
+func (v Values) Bool(key string) bool {
+	return v[key] == "true"
+}
+
+// List returns the selected options of a MultiSelect field.
+func (v Values) List(key string) []string {
+	if v[key] == "" {
+		return nil
+	}
+	return strings.Split(v[key], ",")
+}
@@ -0,0 +1,433 @@
+package form
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("229")).
+			MarginBottom(1)
+
+	labelStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("245")).
+			Width(16)
+
+	focusedLabelStyle = labelStyle.
+				Foreground(lipgloss.Color("229")).
+				Bold(true)
+
+	optionStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("229")).
+			Underline(true)
+
+	errorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196"))
+
+	hintStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241"))
+)
+
+// fieldState is the input state of a field: a text input for Text, Int
+// and Duration fields, the selected option of a Select, the switch of a
+// Bool, and the option cursor and selection of a MultiSelect.
+type fieldState struct {
+	input    textinput.Model
+	choice   int
+	on       bool
+	selected []bool
+}
+
+// Model is a form of typed fields. Tab and shift+tab move between fields,
+// enter validates every field and submits, esc cancels. Forms are
+// declared with their fields:
+//
+//	form.New("Create topic",
+//		form.Field{Key: "name", Label: "Name", Required: true},
+//		form.Field{Key: "partitions", Label: "Partitions", Kind: form.Int, Default: "3", Validate: form.Positive},
+//	)
+type Model struct {
+	title  string
+	fields []Field
+	states []fieldState
+	focus  int
+	errs   map[string]string
+	note   string
+	check  func(Values) map[string]error
+
+	submitted bool
+	cancelled bool
+}
+
+// New creates a form with the fields filled in with their defaults and
+// the first one focused.
+func New(title string, fields ...Field) Model {
+	m := Model{
+		title:  title,
+		fields: slices.Clone(fields),
+		states: make([]fieldState, len(fields)),
+		errs:   map[string]string{},
+	}
+
+	for i, field := range fields {
+		switch field.Kind {
+		case Text, Int, Duration:
+			ti := textinput.New()
+			ti.CharLimit = 249
+			ti.Width = 50
+			ti.Prompt = ""
+			ti.Placeholder = field.Placeholder
+			m.states[i].input = ti
+		case MultiSelect:
+			m.states[i].selected = make([]bool, len(field.Options))
+		case Select, Bool:
+		}
+		m.SetValue(field.Key, field.Default)
+	}
+
+	m.setFocus(0)
+	return m
+}
+
+// Init starts the cursor blinking.
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Submitted reports whether the form was submitted with valid values.
+func (m Model) Submitted() bool {
+	return m.submitted
+}
+
+// Cancelled reports whether the form was dismissed.
+func (m Model) Cancelled() bool {
+	return m.cancelled
+}
+
+// Resume reopens a submitted or cancelled form for editing, keeping its
+// values.
+func (m *Model) Resume() {
+	m.submitted = false
+	m.cancelled = false
+	m.setFocus(m.focus)
+}
+
+// SetCheck sets a check run on submit once every field is valid on its
+// own. It returns the errors of the fields it rejects by key, for checks
+// across fields or against constraints loaded after the form was opened.
+func (m *Model) SetCheck(check func(Values) map[string]error) {
+	m.check = check
+}
+
+// Focus moves the focus to a field.
+func (m *Model) Focus(key string) {
+	if i := m.index(key); i >= 0 {
+		m.setFocus(i)
+	}
+}
+
+// SetHint replaces the hint of a field.
+func (m *Model) SetHint(key, hint string) {
+	if i := m.index(key); i >= 0 {
+		m.fields[i].Hint = hint
+	}
+}
+
+// SetNote sets a line shown below the fields.
+func (m *Model) SetNote(note string) {
+	m.note = note
+}
+
+// SetError shows an error against a field, or below the form when key is
+// not a field.
+func (m *Model) SetError(key string, err error) {
+	if err == nil {
+		delete(m.errs, key)
+		return
+	}
+	m.errs[key] = err.Error()
+}
+
+// SetValue sets the value of a field, in the form Values returns it.
+// Values that are not options of a Select or MultiSelect are ignored.
+func (m *Model) SetValue(key, value string) {
+	i := m.index(key)
+	if i < 0 {
+		return
+	}
+
+	field, state := m.fields[i], &m.states[i]
+	switch field.Kind {
+	case Text, Int, Duration:
+		state.input.SetValue(value)
+		state.input.CursorEnd()
+	case Select:
+		if choice := slices.Index(field.Options, value); choice >= 0 {
+			state.choice = choice
+		}
+	case Bool:
+		state.on = value == "true"
+	case MultiSelect:
+		for j, option := range field.Options {
+			state.selected[j] = slices.Contains(strings.Split(value, ","), option)
+		}
+	}
+}
+
+// Value returns the value of a field.
+func (m Model) Value(key string) string {
+	i := m.index(key)
+	if i < 0 {
+		return ""
+	}
+
+	field, state := m.fields[i], m.states[i]
+	switch field.Kind {
+	case Select:
+		if len(field.Options) == 0 {
+			return ""
+		}
+		return field.Options[state.choice]
+	case Bool:
+		if state.on {
+			return "true"
+		}
+		return "false"
+	case MultiSelect:
+		var selected []string
+		for j, option := range field.Options {
+			if state.selected[j] {
+				selected = append(selected, option)
+			}
+		}
+		return strings.Join(selected, ",")
+	case Text, Int, Duration:
+	}
+	return strings.TrimSpace(state.input.Value())
+}
+
+// Values returns the value of every field.
+func (m Model) Values() Values {
+	values := Values{}
+	for _, field := range m.fields {
+		values[field.Key] = m.Value(field.Key)
+	}
+	return values
+}
+
+func (m Model) index(key string) int {
+	return slices.IndexFunc(m.fields, func(f Field) bool { return f.Key == key })
+}
+
+func (m *Model) setFocus(focus int) {
+	if len(m.fields) == 0 {
+		return
+	}
+	m.states[m.focus].input.Blur()
+	m.focus = focus
+	switch m.fields[m.focus].Kind {
+	case Text, Int, Duration:
+		m.states[m.focus].input.Focus()
+	case Select, Bool, MultiSelect:
+	}
+}
+
+// validate checks every field and then the form's check, and focuses the
+// first invalid field.
+func (m *Model) validate() bool {
+	m.errs = map[string]string{}
+	values := m.Values()
+
+	for _, field := range m.fields {
+		if err := field.check(values[field.Key]); err != nil {
+			m.errs[field.Key] = err.Error()
+		}
+	}
+	if len(m.errs) == 0 && m.check != nil {
+		for key, err := range m.check(values) {
+			m.errs[key] = err.Error()
+		}
+	}
+
+	for i, field := range m.fields {
+		if _, ok := m.errs[field.Key]; ok {
+			m.setFocus(i)
+			break
+		}
+	}
+	return len(m.errs) == 0
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if m.submitted || m.cancelled || len(m.fields) == 0 {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		// Keep the cursor of the focused input blinking
+		var cmd tea.Cmd
+		if m.states[m.focus].input.Focused() {
+			m.states[m.focus].input, cmd = m.states[m.focus].input.Update(msg)
+		}
+		return m, cmd
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		m.cancelled = true
+		return m, nil
+	case "tab", "down":
+		m.setFocus((m.focus + 1) % len(m.fields))
+		return m, nil
+	case "shift+tab", "up":
+		m.setFocus((m.focus + len(m.fields) - 1) % len(m.fields))
+		return m, nil
+	case "enter":
+		m.submitted = m.validate()
+		return m, nil
+	}
+
+	field, state := m.fields[m.focus], &m.states[m.focus]
+	delete(m.errs, field.Key)
+
+	switch field.Kind {
+	case Select:
+		state.choice = cycle(state.choice, len(field.Options), keyMsg.String())
+	case Bool:
+		switch keyMsg.String() {
+		case "left", "right", "h", "l", " ":
+			state.on = !state.on
+		case "y":
+			state.on = true
+		case "n":
+			state.on = false
+		}
+	case MultiSelect:
+		switch keyMsg.String() {
+		case "left", "h":
+			state.choice = max(state.choice-1, 0)
+		case "right", "l":
+			state.choice = min(state.choice+1, len(field.Options)-1)
+		case " ", "x":
+			if len(field.Options) > 0 {
+				state.selected[state.choice] = !state.selected[state.choice]
+			}
+		}
+	case Text, Int, Duration:
+		var cmd tea.Cmd
+		state.input, cmd = state.input.Update(keyMsg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func cycle(current, size int, key string) int {
+	if size == 0 {
+		return current
+	}
+	switch key {
+	case "left", "h":
+		return (current + size - 1) % size
+	case "right", "l", " ":
+		return (current + 1) % size
+	default:
+		return current
+	}
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(m.title + " (tab to move, enter to submit, esc to cancel)"))
+	b.WriteString("\n")
+
+	for i, field := range m.fields {
+		focused := i == m.focus
+		style := labelStyle
+		if focused {
+			style = focusedLabelStyle
+		}
+
+		line := style.Render(field.Label) + " " + m.renderValue(i, focused)
+		if err, ok := m.errs[field.Key]; ok {
+			line += "  " + errorStyle.Render(err)
+		} else if field.Hint != "" {
+			line += "  " + hintStyle.Render("("+field.Hint+")")
+		}
+		b.WriteString(line + "\n")
+	}
+
+	if m.note != "" {
+		b.WriteString("\n" + hintStyle.Render(m.note))
+	}
+
+	// Errors that are not against a field
+	keys := slices.Sorted(maps.Keys(m.errs))
+	for _, key := range keys {
+		if m.index(key) < 0 {
+			b.WriteString("\n" + errorStyle.Render(m.errs[key]))
+		}
+	}
+
+	return b.String()
+}
+
+func (m Model) renderValue(i int, focused bool) string {
+	field, state := m.fields[i], m.states[i]
+
+	switch field.Kind {
+	case Select:
+		return renderChoice(field.Options, state.choice, focused, "←/→ to change")
+	case Bool:
+		choice := 1
+		if state.on {
+			choice = 0
+		}
+		return renderChoice([]string{"yes", "no"}, choice, focused, "←/→ to change")
+	case MultiSelect:
+		parts := make([]string, len(field.Options))
+		for j, option := range field.Options {
+			mark := "[ ]"
+			if state.selected[j] {
+				mark = "[x]"
+			}
+			part := mark + " " + option
+			if focused && j == state.choice {
+				part = optionStyle.Render(part)
+			}
+			parts[j] = part
+		}
+		value := strings.Join(parts, "  ")
+		if focused {
+			value += hintStyle.Render("  ←/→ to move, space to select")
+		}
+		return value
+	case Text, Int, Duration:
+	}
+	return state.input.View()
+}
+
+func renderChoice(options []string, selected int, focused bool, help string) string {
+	parts := make([]string, len(options))
+	for i, option := range options {
+		if i == selected {
+			parts[i] = "[" + option + "]"
+		} else {
+			parts[i] = " " + option + " "
+		}
+	}
+
+	choice := strings.Join(parts, " ")
+	if focused {
+		choice += hintStyle.Render("  " + help)
+	}
+	return choice
+}
@@ -14,6 +14,11 @@ type Model struct {
 	api         string
 	currentView string
 	width       int
+
+	// Active filter of the current view
+	filter  string
+	matched int
+	total   int
 }
 
 var (
@@ -62,6 +67,18 @@ func (m *Model) SetNamespace(namespace string) {
 	m.namespace = namespace
 }
 
+func (m *Model) SetAPI(api string) {
+	m.api = api
+}
+
+// SetFilter shows the current view's filter and how many of its rows
+// match. An empty filter hides the line.
+func (m *Model) SetFilter(filter string, matched, total int) {
+	m.filter = filter
+	m.matched = matched
+	m.total = total
+}
+
 func (m *Model) SetWidth(width int) {
 	m.width = width
 }
@@ -82,6 +99,12 @@ func (m Model) View() string {
 		fmt.Sprintf("%s %s", labelStyle.Render("View:     "), viewStyle.Render(":"+m.currentView)),
 		fmt.Sprintf("%s %s", labelStyle.Render("Time:     "), infoStyle.Render(time.Now().Format("15:04:05"))),
 	}
+	if m.filter != "" {
+		infoLines = append(infoLines, fmt.Sprintf("%s %s %s",
+			labelStyle.Render("Filter:   "),
+			viewStyle.Render(m.filter),
+			infoStyle.Render(fmt.Sprintf("(%d/%d)", m.matched, m.total))))
+	}
 
 	// Calculate spacing
 	padding := 3
@@ -75,12 +75,26 @@ func defaultSections() []Section {
 				{"esc", "Go back"},
 				{"d", "Describe resource"},
 				{"e", "Edit resource"},
-				{"ctrl+d", "Delete resource"},
+				{"ctrl+d", "Delete resource (backed up to the trash)"},
 				{"r", "Refresh view"},
-				{"/", "Filter resources"},
+				{"s", "Sort by the next column"},
+				{"S", "Reverse the sort order"},
+				{"/", "Filter: text, /regex/ or spec.partitions>=12"},
 				{"ctrl+r", "Force refresh"},
 			},
 		},
+		{
+			Title: "Bulk Actions",
+			Commands: []Command{
+				{"space", "Mark row"},
+				{"ctrl+a", "Mark all visible rows"},
+				{"*", "Invert marks"},
+				{"esc", "Clear marks"},
+				{"d", "Describe marked as one YAML"},
+				{"ctrl+d", "Delete marked (type the count)"},
+				{"X", "Export marked to ~/.k4a/exports"},
+			},
+		},
 		{
 			Title: "View Commands",
 			Commands: []Command{
@@ -89,16 +103,56 @@ func defaultSections() []Section {
 				{":connectors", "Switch to connectors view"},
 				{":consumers", "Switch to consumers view"},
 				{":acls", "Switch to ACLs view"},
-				{":ctx", "Switch context"},
-				{":ns", "Switch namespace"},
+				{":streams", "Switch to Kafka Streams view"},
+				{":restore", "Restore deleted resources from the trash"},
+				{":ctx", "Pick a context (s saves it as kafkactl's current)"},
+				{":ctx <name>", "Switch context (add --save to persist)"},
+				{":log", "Event log with kafkactl errors and stderr"},
+				{":events", "Observed resource changes (enter shows the diff)"},
+				{":<kind>", "List any other kind, e.g. :rolebindings or :rb"},
+				{":columns <preset>", "Switch column preset (builtin restores)"},
+				{":schema apply <file>", "Register a schema from a local file"},
+				{":ns <namespace>", "Switch to the context of a namespace"},
+			},
+		},
+		{
+			Title: "Topic Actions",
+			Commands: []Command{
+				{"c", "Create a topic (checked against owned prefixes)"},
+				{"C", "Show configs with defaults and docs (c creates)"},
+				{"u", "Toggle human units and raw values"},
+				{"enter", "Show consumer groups"},
+			},
+		},
+		{
+			Title: "Schema Actions",
+			Commands: []Command{
+				{"enter", "Show versions (space mark, enter diff)"},
+				{"s", "Toggle unified/side-by-side diff"},
+				{"a", "Evolution report (in versions), register from a file (in list)"},
+				{"C", "Set compatibility of the marked subjects"},
 			},
 		},
 		{
 			Title: "Connector Actions",
 			Commands: []Command{
-				{"p", "Pause connector"},
-				{"r", "Resume connector"},
-				{"R", "Restart connector"},
+				{"p", "Pause connector (or marked)"},
+				{"r", "Resume connector (or marked)"},
+				{"R", "Restart connector or marked (y/n)"},
+				{"enter", "Show tasks (enter trace, R restarts the whole connector)"},
+			},
+		},
+		{
+			Title: "Consumer Group Actions",
+			Commands: []Command{
+				{"o", "Reset offsets (dry-run first)"},
+			},
+		},
+		{
+			Title: "ACL Actions",
+			Commands: []Command{
+				{"g", "Grant topic access to a namespace"},
+				{"x", "Revoke ACL"},
 			},
 		},
 		{
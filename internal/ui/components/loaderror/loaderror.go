@@ -0,0 +1,31 @@
+package loaderror
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+)
+
+var (
+	timeoutStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("220"))
+
+	errorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196"))
+
+	hintStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241"))
+)
+
+// View renders a failed load of what, e.g. "topics". Timeouts are told
+// apart from other errors as they are usually worth retrying as is.
+func View(what string, err error) string {
+	hint := hintStyle.Render("Press r to retry")
+
+	if kafkactl.IsTimeout(err) {
+		return fmt.Sprintf("%s\n\n%s", timeoutStyle.Render(fmt.Sprintf("Timed out loading %s: %v", what, err)), hint)
+	}
+
+	return fmt.Sprintf("%s\n\n%s", errorStyle.Render(fmt.Sprintf("Error loading %s: %v", what, err)), hint)
+}
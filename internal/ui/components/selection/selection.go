@@ -0,0 +1,112 @@
+// Package selection keeps the rows of a table marked for a bulk action.
+package selection
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Marker is shown in the selection column of marked rows.
+const Marker = "●"
+
+// Model is the set of rows marked by name: space marks the row under the
+// cursor, ctrl+a marks every visible row, * inverts the marks of the
+// visible rows and esc clears them.
+type Model struct {
+	names map[string]bool
+}
+
+func New() Model {
+	return Model{names: map[string]bool{}}
+}
+
+// Handles reports whether msg is a selection key. Esc only is while rows
+// are marked, so that it keeps its meaning otherwise.
+func (m Model) Handles(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case " ", "ctrl+a", "*":
+		return true
+	case "esc":
+		return m.Len() > 0
+	}
+	return false
+}
+
+// Update applies a selection key. Current is the name of the row under the
+// cursor, and visible the names of the rows the filter shows.
+func (m Model) Update(msg tea.KeyMsg, current string, visible []string) Model {
+	if msg.String() == "esc" {
+		return New()
+	}
+
+	m = m.clone()
+	switch msg.String() {
+	case " ":
+		if current != "" {
+			m.flip(current)
+		}
+	case "ctrl+a":
+		for _, name := range visible {
+			m.names[name] = true
+		}
+	case "*":
+		for _, name := range visible {
+			m.flip(name)
+		}
+	}
+	return m
+}
+
+// Toggle marks name, or unmarks it if it is marked.
+func (m Model) Toggle(name string) Model {
+	m = m.clone()
+	m.flip(name)
+	return m
+}
+
+func (m Model) flip(name string) {
+	if m.names[name] {
+		delete(m.names, name)
+	} else {
+		m.names[name] = true
+	}
+}
+
+// Has reports whether name is marked.
+func (m Model) Has(name string) bool {
+	return m.names[name]
+}
+
+// Len returns the number of marked rows.
+func (m Model) Len() int {
+	return len(m.names)
+}
+
+// Marker returns the selection column of the row of the given name.
+func (m Model) Marker(name string) string {
+	if m.names[name] {
+		return Marker
+	}
+	return ""
+}
+
+// Names returns the marked names in the order of names; marked rows that
+// are no longer listed are left out.
+func (m Model) Names(names []string) []string {
+	var marked []string
+	for _, name := range names {
+		if m.names[name] {
+			marked = append(marked, name)
+		}
+	}
+	return marked
+}
+
+// clone copies the marks, so that models handed out before stay as they
+// were.
+func (m Model) clone() Model {
+	names := make(map[string]bool, len(m.names)+1)
+	for name := range m.names {
+		names[name] = true
+	}
+	return Model{names: names}
+}
@@ -0,0 +1,164 @@
+package sorter
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/config"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+)
+
+// Model is the sort order of a table: s cycles through the columns, and
+// back to the loaded order, and S reverses the order. The order is kept in
+// the state file by view name, so it survives refreshes and restarts.
+type Model struct {
+	view       string
+	state      *config.State
+	columns    []table.Column
+	numeric    map[string]bool
+	column     string
+	descending bool
+}
+
+// New restores the sort order of a view from state, which the app loads
+// once; with a nil state, the order is not kept. The columns titled in
+// numeric are compared as numbers.
+func New(view string, state *config.State, columns []table.Column, numeric ...string) Model {
+	m := Model{
+		view:    view,
+		state:   state,
+		columns: columns,
+		numeric: map[string]bool{},
+	}
+	for _, title := range numeric {
+		m.numeric[title] = true
+	}
+
+	if state != nil {
+		order := state.Sort[view]
+		if m.index(order.Column) >= 0 {
+			m.column = order.Column
+			m.descending = order.Descending
+		}
+	}
+
+	return m
+}
+
+// Update handles the sort keys and reports whether msg was one of them.
+// The new order is saved at once; a failure to save it is reported in the
+// footer.
+func (m Model) Update(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	switch msg.String() {
+	case "s":
+		m.column = m.next()
+		m.descending = false
+	case "S":
+		if m.column == "" {
+			return m, nil, true
+		}
+		m.descending = !m.descending
+	default:
+		return m, nil, false
+	}
+
+	if err := m.save(); err != nil {
+		return m, footer.Failure("Failed to save the sort order: " + err.Error()), true
+	}
+	return m, nil, true
+}
+
+// next returns the titled column after the sorted one, or none after the
+// last column.
+func (m Model) next() string {
+	for i := m.index(m.column) + 1; i < len(m.columns); i++ {
+		if m.columns[i].Title != "" {
+			return m.columns[i].Title
+		}
+	}
+	return ""
+}
+
+func (m Model) index(title string) int {
+	if title == "" {
+		return -1
+	}
+	return slices.IndexFunc(m.columns, func(c table.Column) bool { return c.Title == title })
+}
+
+// Columns returns the columns with the sort indicator in the title of the
+// sorted column.
+func (m Model) Columns() []table.Column {
+	columns := slices.Clone(m.columns)
+	if i := m.index(m.column); i >= 0 {
+		indicator := " ▲"
+		if m.descending {
+			indicator = " ▼"
+		}
+		columns[i].Title += indicator
+	}
+	return columns
+}
+
+// Sort sorts items by the sorted column, keeping the order of equal items.
+// Cell returns the value of an item in a column, by column title; numeric
+// columns compare values as numbers, and values that are not numbers sort
+// after them.
+func Sort[T any](m Model, items []T, cell func(item T, column string) string) {
+	column := m.column
+	if column == "" {
+		return
+	}
+
+	compare := func(a, b string) int {
+		return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
+	}
+	if m.numeric[m.column] {
+		compare = compareNumbers
+	}
+
+	slices.SortStableFunc(items, func(a, b T) int {
+		c := compare(cell(a, column), cell(b, column))
+		if m.descending {
+			return -c
+		}
+		return c
+	})
+}
+
+func compareNumbers(a, b string) int {
+	x, errX := strconv.ParseFloat(a, 64)
+	y, errY := strconv.ParseFloat(b, 64)
+	switch {
+	case errX != nil && errY != nil:
+		return cmp.Compare(a, b)
+	case errX != nil:
+		return 1
+	case errY != nil:
+		return -1
+	}
+	return cmp.Compare(x, y)
+}
+
+// save records the sort order of the view in the state and writes the
+// state file.
+func (m Model) save() error {
+	if m.state == nil {
+		return nil
+	}
+
+	if m.column == "" {
+		delete(m.state.Sort, m.view)
+	} else {
+		if m.state.Sort == nil {
+			m.state.Sort = map[string]config.SortOrder{}
+		}
+		m.state.Sort[m.view] = config.SortOrder{Column: m.column, Descending: m.descending}
+	}
+
+	return m.state.Save()
+}
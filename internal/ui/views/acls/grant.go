@@ -0,0 +1,141 @@
+package acls
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/ui/components/form"
+	"github.com/smart-fellas/k4a/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	fieldGrantedTo   = "grantedTo"
+	fieldResource    = "resource"
+	fieldPatternType = "resourcePatternType"
+	fieldPermission  = "permission"
+	fieldName        = "name"
+)
+
+// grantForm collects the fields of an AccessControlEntry that grants
+// another namespace access to one of our topic prefixes.
+type grantForm struct {
+	namespace     string
+	ownedPrefixes []string
+	form          form.Model
+}
+
+func newGrantForm(namespace string, ownedPrefixes []string) grantForm {
+	f := grantForm{
+		namespace:     namespace,
+		ownedPrefixes: ownedPrefixes,
+		form: form.New("Grant topic access",
+			form.Field{Key: fieldGrantedTo, Label: "Granted To", Placeholder: "namespace to grant access to", Required: true},
+			form.Field{Key: fieldResource, Label: "Resource", Placeholder: "owned topic prefix", Required: true},
+			form.Field{Key: fieldPatternType, Label: "Pattern Type", Kind: form.Select, Options: []string{"PREFIXED", "LITERAL"}},
+			form.Field{Key: fieldPermission, Label: "Permission", Kind: form.Select, Options: []string{"READ", "WRITE"}},
+			form.Field{Key: fieldName, Label: "Name", Placeholder: "generated from grantee, resource and permission"},
+		),
+	}
+	if len(ownedPrefixes) > 0 {
+		f.form.SetValue(fieldResource, ownedPrefixes[0])
+		f.form.SetNote("Owned prefixes: " + strings.Join(ownedPrefixes, ", "))
+	}
+	f.form.SetCheck(f.check)
+
+	return f
+}
+
+// Update handles a key press and reports whether the form was submitted.
+// A submitted form stays open for editing.
+func (f grantForm) Update(msg tea.KeyMsg) (grantForm, tea.Cmd, bool) {
+	var cmd tea.Cmd
+	f.form, cmd = f.form.Update(msg)
+	submitted := f.form.Submitted()
+	f.form.Resume()
+	return f, cmd, submitted
+}
+
+// SetError shows an error below the form.
+func (f *grantForm) SetError(err error) {
+	f.form.SetError("", err)
+}
+
+func (f grantForm) check(values form.Values) map[string]error {
+	grantedTo := values.String(fieldGrantedTo)
+	resource := values.String(fieldResource)
+
+	errs := map[string]error{}
+	if grantedTo == f.namespace {
+		errs[fieldGrantedTo] = errors.New("cannot grant access to your own namespace")
+	}
+	if len(f.ownedPrefixes) > 0 && !f.isOwned(resource) {
+		errs[fieldResource] = fmt.Errorf("%s is not under an owned prefix (%s)", resource, strings.Join(f.ownedPrefixes, ", "))
+	}
+
+	return errs
+}
+
+func (f grantForm) isOwned(resource string) bool {
+	for _, prefix := range f.ownedPrefixes {
+		if strings.HasPrefix(resource, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Manifest renders the AccessControlEntry described by the form.
+func (f grantForm) Manifest() ([]byte, error) {
+	values := f.form.Values()
+	grantedTo := values.String(fieldGrantedTo)
+	resource := values.String(fieldResource)
+	patternType := values.String(fieldPatternType)
+	permission := values.String(fieldPermission)
+
+	// The resource and pattern type are part of the default name, as apply
+	// would replace an existing grant of the same name
+	name := values.String(fieldName)
+	if name == "" {
+		name = strings.ToLower(fmt.Sprintf("%s-acl-%s-%s-%s-%s",
+			f.namespace, grantedTo, nameSafe(resource), patternType, permission))
+	}
+
+	acl := models.AccessControlEntry{
+		BaseResource: models.BaseResource{
+			APIVersion: "v1",
+			Kind:       "AccessControlEntry",
+			Metadata: models.ResourceMetadata{
+				Name:      name,
+				Namespace: f.namespace,
+			},
+		},
+		Spec: models.AccessControlEntrySpec{
+			ResourceType:        "TOPIC",
+			Resource:            resource,
+			ResourcePatternType: patternType,
+			Permission:          permission,
+			GrantedTo:           grantedTo,
+		},
+	}
+
+	return yaml.Marshal(acl)
+}
+
+// nameSafe reduces a resource to the characters allowed in resource names,
+// e.g. orders- to orders.
+func nameSafe(resource string) string {
+	safe := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-' {
+			return r
+		}
+		return '-'
+	}, resource)
+	return strings.Trim(safe, "-._")
+}
+
+func (f grantForm) View() string {
+	return f.form.View()
+}
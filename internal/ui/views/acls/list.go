@@ -0,0 +1,374 @@
+package acls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/deletion"
+	"github.com/smart-fellas/k4a/internal/ui/components/dialog"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+	"github.com/smart-fellas/k4a/internal/ui/components/loaderror"
+	"github.com/smart-fellas/k4a/internal/ui/keys"
+	"github.com/smart-fellas/k4a/pkg/models"
+)
+
+type Model struct {
+	client  *kafkactl.Client
+	ctx     context.Context
+	table   table.Model
+	acls    []models.AccessControlEntry
+	keys    keys.KeyMap
+	width   int
+	height  int
+	loading bool
+	err     error
+	status  string
+
+	// Detail view
+	showDetail   bool
+	detailDialog dialog.Model
+
+	// Grant workflow
+	showGrant    bool
+	form         grantForm
+	showPreview  bool
+	manifest     []byte
+	previewModal dialog.Model
+
+	// Revoke (delete) workflow
+	deleter deletion.Model
+}
+
+var statusStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("220"))
+
+func New(client *kafkactl.Client) Model {
+	columns := []table.Column{
+		{Title: "Name", Width: 40},
+		{Title: "Granted To", Width: 20},
+		{Title: "Resource Type", Width: 15},
+		{Title: "Pattern", Width: 10},
+		{Title: "Resource", Width: 35},
+		{Title: "Permission", Width: 12},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(20),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	t.SetStyles(s)
+
+	preview := dialog.New()
+	preview.SetTitle("Apply AccessControlEntry? (enter/y to apply, esc to go back)")
+
+	return Model{
+		client:       client,
+		ctx:          context.Background(),
+		table:        t,
+		keys:         keys.DefaultKeyMap(),
+		loading:      true,
+		detailDialog: dialog.New(),
+		previewModal: preview,
+		deleter:      deletion.New(client),
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadACLs
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	// Handle detail view
+	if m.showDetail {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			if key.Matches(msg, m.keys.Back) {
+				m.showDetail = false
+				return m, nil
+			}
+		case aclDetailMsg:
+			m.detailDialog.SetContent(msg.yaml)
+			return m, nil
+		}
+
+		newDialog, cmd := m.detailDialog.Update(msg)
+		m.detailDialog = newDialog
+		return m, cmd
+	}
+
+	// Handle manifest preview before applying a grant
+	if m.showPreview {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			switch msg.String() {
+			case "enter", "y":
+				m.showPreview = false
+				m.showGrant = false
+				m.status = statusStyle.Render("Applying AccessControlEntry...")
+				return m, m.applyGrant
+			case "esc", "n":
+				m.showPreview = false
+				return m, nil
+			}
+		}
+
+		newDialog, cmd := m.previewModal.Update(msg)
+		m.previewModal = newDialog
+		return m, cmd
+	}
+
+	// Handle grant form
+	if m.showGrant {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			if msg.Type == tea.KeyEsc {
+				m.showGrant = false
+				return m, nil
+			}
+
+			form, cmd, submitted := m.form.Update(msg)
+			m.form = form
+			if submitted {
+				manifest, err := m.form.Manifest()
+				if err != nil {
+					m.form.SetError(err)
+					return m, nil
+				}
+				m.manifest = manifest
+				m.previewModal.SetContent(string(manifest))
+				m.showPreview = true
+			}
+			return m, cmd
+		}
+	}
+
+	// Handle revoke confirmation
+	if m.deleter.Active() {
+		newDeleter, cmd := m.deleter.Update(msg)
+		m.deleter = newDeleter
+		if !m.deleter.Active() {
+			if m.deleter.Deleted() {
+				return m, tea.Batch(cmd, m.loadACLs)
+			}
+		}
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Describe):
+			if len(m.acls) > 0 {
+				m.showDetail = true
+				return m, m.loadACLDetail
+			}
+
+		case key.Matches(msg, m.keys.Refresh):
+			if m.err != nil {
+				m.err = nil
+				m.loading = true
+			}
+			return m, m.loadACLs
+
+		case msg.String() == "g":
+			// Grant access to another namespace
+			m.form = newGrantForm(m.client.Namespace(), m.ownedPrefixes())
+			m.showGrant = true
+			m.status = ""
+			return m, nil
+
+		case msg.String() == "x" || key.Matches(msg, m.keys.Delete):
+			// Revoke the selected ACL
+			acl, ok := m.selectedACL()
+			if !ok {
+				return m, nil
+			}
+			if acl.Metadata.Namespace != m.client.Namespace() {
+				return m, footer.Failure("Only ACLs owned by your namespace can be revoked")
+			}
+			m.deleter = m.deleter.Start(m.ctx, "acl", acl.Metadata.Name)
+			return m, nil
+		}
+
+	case aclsLoadedMsg:
+		if errors.Is(msg.err, context.Canceled) {
+			return m, nil
+		}
+		m.loading = false
+		m.err = nil
+		if msg.err != nil && !errors.Is(msg.err, models.ErrDecode) {
+			m.err = msg.err
+			return m, footer.Failure("Failed to load ACLs: " + msg.err.Error())
+		}
+		m.acls = msg.acls
+		m.updateTable()
+		if msg.err != nil {
+			return m, footer.Failure("Some ACLs could not be read: " + msg.err.Error())
+		}
+
+	case aclActionMsg:
+		m.status = ""
+		if msg.err != nil {
+			return m, footer.Failure(fmt.Sprintf("%s failed: %v", msg.action, msg.err))
+		}
+		return m, tea.Batch(footer.Success(msg.action), m.loadACLs)
+	}
+
+	newTable, cmd := m.table.Update(msg)
+	m.table = newTable
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) View() string {
+	if m.deleter.Active() {
+		return m.deleter.View()
+	}
+
+	if m.showDetail {
+		return m.detailDialog.View()
+	}
+
+	if m.showPreview {
+		return m.previewModal.View()
+	}
+
+	if m.showGrant {
+		return m.form.View()
+	}
+
+	if m.loading {
+		return "Loading ACLs..."
+	}
+
+	if m.err != nil {
+		return loaderror.View("ACLs", m.err)
+	}
+
+	if m.status != "" {
+		return m.table.View() + "\n" + m.status
+	}
+
+	return m.table.View()
+}
+
+// CapturingInput reports whether the grant form or the revoke
+// confirmation is receiving keystrokes.
+func (m Model) CapturingInput() bool {
+	return (m.showGrant && !m.showPreview) || m.deleter.Active()
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.table.SetHeight(height - 2)
+	m.detailDialog.SetSize(width, height)
+	m.previewModal.SetSize(width, height)
+	m.deleter.SetSize(width, height)
+}
+
+// SetContext sets the context of the view's kafkactl runs. The app cancels
+// it when the view is left, abandoning loads still in flight.
+func (m *Model) SetContext(ctx context.Context) {
+	m.ctx = ctx
+}
+
+func (m *Model) updateTable() {
+	rows := []table.Row{}
+
+	for _, acl := range m.acls {
+		rows = append(rows, table.Row{
+			orDash(acl.Metadata.Name),
+			orDash(acl.Spec.GrantedTo),
+			orDash(acl.Spec.ResourceType),
+			orDash(acl.Spec.ResourcePatternType),
+			orDash(acl.Spec.Resource),
+			orDash(acl.Spec.Permission),
+		})
+	}
+
+	m.table.SetRows(rows)
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func (m Model) selectedACL() (models.AccessControlEntry, bool) {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.acls) {
+		return models.AccessControlEntry{}, false
+	}
+	return m.acls[cursor], true
+}
+
+// ownedPrefixes returns the topic prefixes the current namespace owns.
+func (m Model) ownedPrefixes() []string {
+	return models.OwnedPrefixes(m.acls, m.client.Namespace(), "TOPIC")
+}
+
+type aclsLoadedMsg struct {
+	acls []models.AccessControlEntry
+	err  error
+}
+
+type aclDetailMsg struct {
+	yaml string
+}
+
+type aclActionMsg struct {
+	action string
+	err    error
+}
+
+func (m *Model) loadACLs() tea.Msg {
+	acls, err := m.client.ListACLs(m.ctx)
+	return aclsLoadedMsg{acls: acls, err: err}
+}
+
+func (m *Model) loadACLDetail() tea.Msg {
+	acl, ok := m.selectedACL()
+	if !ok {
+		return nil
+	}
+
+	name := acl.Metadata.Name
+	yaml, err := m.client.GetResourceYAML(m.ctx, "acl", name)
+	if err != nil {
+		return aclDetailMsg{yaml: fmt.Sprintf("Error loading ACL details: %v", err)}
+	}
+
+	return aclDetailMsg{yaml: yaml}
+}
+
+func (m *Model) applyGrant() tea.Msg {
+	output, err := m.client.ApplyManifest(m.ctx, m.manifest)
+	if err != nil {
+		return aclActionMsg{action: "Grant", err: err}
+	}
+
+	return aclActionMsg{action: "Grant: " + strings.TrimSpace(output)}
+}
@@ -1,23 +1,43 @@
 package connectors
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/smart-fellas/k4a/internal/config"
 	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/bulk"
+	"github.com/smart-fellas/k4a/internal/ui/components/changes"
+	"github.com/smart-fellas/k4a/internal/ui/components/columns"
+	"github.com/smart-fellas/k4a/internal/ui/components/confirm"
+	"github.com/smart-fellas/k4a/internal/ui/components/deletion"
 	"github.com/smart-fellas/k4a/internal/ui/components/dialog"
+	"github.com/smart-fellas/k4a/internal/ui/components/editor"
+	"github.com/smart-fellas/k4a/internal/ui/components/filter"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+	"github.com/smart-fellas/k4a/internal/ui/components/loaderror"
+	"github.com/smart-fellas/k4a/internal/ui/components/selection"
+	"github.com/smart-fellas/k4a/internal/ui/components/sorter"
 	"github.com/smart-fellas/k4a/internal/ui/keys"
 	"github.com/smart-fellas/k4a/internal/ui/styles"
+	"github.com/smart-fellas/k4a/pkg/models"
 )
 
 type Model struct {
 	client     *kafkactl.Client
+	ctx        context.Context
 	table      table.Model
-	connectors []map[string]any
+	connectors []models.Connector
+	visible    []models.Connector
 	keys       keys.KeyMap
 	width      int
 	height     int
@@ -27,20 +47,58 @@ type Model struct {
 	// Detail view
 	showDetail   bool
 	detailDialog dialog.Model
+
+	// Edit round trip and delete workflows
+	editor  editor.Model
+	deleter deletion.Model
+
+	// Filter bar; visible holds the rows matching it
+	filter filter.Model
+
+	// Sort order of the visible rows, kept in the state
+	sort  sorter.Model
+	state *config.State
+
+	// Columns configured for the view: added after the built-in columns,
+	// or with replaceColumns, in place of all of them but the name
+	extra          []columns.Column
+	replaceColumns bool
+
+	// Tasks of the selected connector
+	showTasks bool
+	tasks     tasksModel
+
+	// Confirmation of a connector restart, shown over the table
+	confirmRestart bool
+	restartConfirm confirm.Model
+
+	// Connectors marked with space, and the bulk action run on them
+	marks selection.Model
+	bulk  bulk.Model
+
+	// Changes found by the last refreshes; removed holds the removed
+	// connectors matching the filter, listed while they are highlighted
+	changes changes.Tracker
+	removed []models.Connector
 }
 
-func New(client *kafkactl.Client) Model {
-	columns := []table.Column{
-		{Title: "Name", Width: 40},
-		{Title: "Class", Width: 40},
-		{Title: "Type", Width: 10},
-		{Title: "State", Width: 10},
-		{Title: "Tasks", Width: 10},
-		{Title: "Connect Cluster", Width: 20},
-	}
+// builtinColumns are the columns of the view before any configured ones;
+// configured columns replace all of them but the marker and the name.
+var builtinColumns = []table.Column{
+	{Title: "", Width: 2},
+	{Title: "Name", Width: 40},
+	{Title: "Class", Width: 40},
+	{Title: "Type", Width: 10},
+	{Title: "State", Width: 10},
+	{Title: "Tasks", Width: 10},
+	{Title: "Connect Cluster", Width: 20},
+}
+
+// builtinNumeric are the built-in columns sorted as numbers.
+var builtinNumeric = []string{"Tasks"}
 
+func New(client *kafkactl.Client, state *config.State) Model {
 	t := table.New(
-		table.WithColumns(columns),
 		table.WithFocused(true),
 		table.WithHeight(20),
 	)
@@ -57,12 +115,22 @@ func New(client *kafkactl.Client) Model {
 		Bold(false)
 	t.SetStyles(s)
 
-	return Model{
+	m := Model{
 		client:       client,
+		state:        state,
+		ctx:          context.Background(),
 		table:        t,
 		keys:         keys.DefaultKeyMap(),
+		loading:      true,
 		detailDialog: dialog.New(),
+		editor:       editor.New(client),
+		deleter:      deletion.New(client),
+		filter:       filter.New(),
+		marks:        selection.New(),
+		bulk:         bulk.New(client),
 	}
+	m.SetColumns(nil, false)
+	return m
 }
 
 func (m Model) Init() tea.Cmd {
@@ -72,6 +140,52 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	// Snapshots are applied whatever is open, so that the list is current
+	// once it is closed
+	switch msg := msg.(type) {
+	case connectorsLoadedMsg:
+		return m.loaded(msg)
+	case changes.ExpiredMsg:
+		m.applyFilter()
+		return m, nil
+	}
+
+	// Handle edit round trip
+	if m.editor.Active() {
+		newEditor, cmd := m.editor.Update(msg)
+		m.editor = newEditor
+		if !m.editor.Active() {
+			if m.editor.Applied() {
+				return m, tea.Batch(cmd, m.loadConnectors)
+			}
+		}
+		return m, cmd
+	}
+
+	// Handle delete confirmation
+	if m.deleter.Active() {
+		newDeleter, cmd := m.deleter.Update(msg)
+		m.deleter = newDeleter
+		if !m.deleter.Active() {
+			if m.deleter.Deleted() {
+				return m, tea.Batch(cmd, m.loadConnectors)
+			}
+		}
+		return m, cmd
+	}
+
+	// Handle bulk action
+	if m.bulk.Active() {
+		var cmd tea.Cmd
+		m.bulk, cmd = m.bulk.Update(msg)
+		// Marks are for one action
+		if m.bulk.Finished() && m.marks.Len() > 0 {
+			m.marks = selection.New()
+			m.updateTable()
+		}
+		return m, cmd
+	}
+
 	// Handle detail view
 	if m.showDetail {
 		switch msg := msg.(type) {
@@ -80,6 +194,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.showDetail = false
 				return m, nil
 			}
+		case connectorDetailMsg:
+			m.detailDialog.SetContent(msg.yaml)
+			return m, nil
 		}
 
 		newDialog, cmd := m.detailDialog.Update(msg)
@@ -87,17 +204,113 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	// Handle restart confirmation
+	if m.confirmRestart {
+		var cmd tea.Cmd
+		m.restartConfirm, cmd = m.restartConfirm.Update(msg)
+		switch {
+		case m.restartConfirm.Confirmed():
+			m.confirmRestart = false
+			return m, m.restartConnector
+		case m.restartConfirm.Cancelled():
+			m.confirmRestart = false
+		}
+		if _, ok := msg.(tea.KeyMsg); ok {
+			return m, cmd
+		}
+	}
+
+	// Handle tasks view
+	if m.showTasks {
+		newTasks, cmd := m.tasks.Update(msg)
+		m.tasks = newTasks
+		if m.tasks.Closed() {
+			m.showTasks = false
+			return m, m.loadConnectors
+		}
+		return m, cmd
+	}
+
+	// Handle filter bar
+	if _, ok := msg.(tea.KeyMsg); ok && m.filter.Editing() {
+		var cmd tea.Cmd
+		m.filter, cmd = m.filter.Update(msg)
+		m.applyFilter()
+		return m, cmd
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if sorted, cmd, ok := m.sort.Update(msg); ok {
+			m.sort = sorted
+			m.table.SetColumns(m.sort.Columns())
+			m.applyFilter()
+			return m, cmd
+		}
+
 		switch {
+		case key.Matches(msg, m.keys.Filter):
+			var cmd tea.Cmd
+			m.filter, cmd = m.filter.Start()
+			return m, cmd
+
+		case key.Matches(msg, m.keys.Back) && m.filter.Value() != "":
+			m.filter = m.filter.Clear()
+			m.applyFilter()
+			return m, nil
+
+		case m.marks.Handles(msg):
+			m.marks = m.marks.Update(msg, m.selectedName(), connectorNames(m.visible))
+			m.updateTable()
+			return m, nil
+
+		case m.marks.Len() > 0 && msg.String() == "p":
+			return m.startBulk(m.connectorAction("Pause", "Paused", bulk.NoConfirmation))
+
+		case m.marks.Len() > 0 && msg.String() == "r":
+			return m.startBulk(m.connectorAction("Resume", "Resumed", bulk.NoConfirmation))
+
+		case m.marks.Len() > 0 && msg.String() == "R":
+			return m.startBulk(m.connectorAction("Restart", "Restarted", bulk.YesNo))
+
+		case key.Matches(msg, m.keys.Describe) && m.marks.Len() > 0:
+			return m.startBulk(bulk.Describe(m.client, "connector"))
+
+		case key.Matches(msg, m.keys.Delete) && m.marks.Len() > 0:
+			return m.startBulk(bulk.Delete(m.client, "connector", m.reload))
+
+		case msg.String() == "X":
+			// Export the marked connectors, or the connector under the
+			// cursor
+			return m.startBulk(bulk.Export(m.client, "connector"))
+
+		case key.Matches(msg, m.keys.Enter):
+			// Show the status of the connector's tasks
+			if name := m.selectedName(); name != "" {
+				m.tasks = newTasksModel(m.ctx, m.client, name)
+				m.tasks.SetSize(m.width, m.height)
+				m.showTasks = true
+				return m, m.tasks.Init()
+			}
+
 		case key.Matches(msg, m.keys.Describe):
-			if len(m.connectors) > 0 {
+			if len(m.visible) > 0 {
 				m.showDetail = true
 				return m, m.loadConnectorDetail
 			}
 
-		case key.Matches(msg, m.keys.Refresh):
-			return m, m.loadConnectors
+		case key.Matches(msg, m.keys.Edit):
+			if name := m.selectedName(); name != "" {
+				var cmd tea.Cmd
+				m.editor, cmd = m.editor.Start(m.ctx, "connector", name)
+				return m, cmd
+			}
+
+		case key.Matches(msg, m.keys.Delete):
+			if name := m.selectedName(); name != "" {
+				m.deleter = m.deleter.Start(m.ctx, "connector", name)
+				return m, nil
+			}
 
 		case msg.String() == "p":
 			// Pause connector
@@ -108,22 +321,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, m.resumeConnector
 
 		case msg.String() == "R":
-			// Restart connector
-			return m, m.restartConnector
-		}
+			// Restart connector once confirmed
+			if name := m.selectedName(); name != "" {
+				m.restartConfirm = confirm.NewYesNo(
+					"Restart connector "+name,
+					"The connector and all of its tasks are restarted.",
+					m.client.ContextName(),
+					m.client.Namespace(),
+				)
+				m.restartConfirm.SetSize(m.width, m.height)
+				m.confirmRestart = true
+			}
+			return m, nil
 
-	case connectorsLoadedMsg:
-		m.connectors = msg.connectors
-		m.loading = false
-		m.updateTable()
+		case key.Matches(msg, m.keys.Refresh):
+			// r resumes connectors, so only ctrl+r refreshes
+			if m.err != nil {
+				m.err = nil
+				m.loading = true
+			}
+			return m, m.loadConnectors
+		}
 
 	case connectorDetailMsg:
 		m.detailDialog.SetContent(msg.yaml)
 		m.showDetail = true
 
 	case connectorActionMsg:
-		// Refresh after action
-		return m, m.loadConnectors
+		if msg.err != nil {
+			return m, footer.Failure(fmt.Sprintf("Failed to %s connector %s: %v", msg.action, msg.name, msg.err))
+		}
+		return m, tea.Batch(footer.Success(msg.result), m.loadConnectors)
 	}
 
 	newTable, cmd := m.table.Update(msg)
@@ -134,85 +362,312 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) View() string {
+	if m.editor.Active() {
+		return m.editor.View()
+	}
+
+	if m.deleter.Active() {
+		return m.deleter.View()
+	}
+
+	if m.bulk.Active() {
+		return m.bulk.View()
+	}
+
 	if m.showDetail {
 		return m.detailDialog.View()
 	}
 
+	if m.showTasks {
+		return m.tasks.View()
+	}
+
 	if m.loading {
 		return "Loading connectors..."
 	}
 
 	if m.err != nil {
-		return fmt.Sprintf("Error: %v", m.err)
+		return loaderror.View("connectors", m.err)
+	}
+
+	view := m.table.View()
+	if bar := m.filter.View(); bar != "" {
+		view += "\n" + bar
 	}
 
-	return m.table.View()
+	if m.confirmRestart {
+		return m.restartConfirm.Over(view)
+	}
+
+	return view
+}
+
+// CapturingInput reports whether an edit, a delete or restart
+// confirmation, a bulk action or the filter bar is receiving keystrokes.
+func (m Model) CapturingInput() bool {
+	return m.editor.Active() || m.deleter.Active() || m.bulk.CapturingInput() || m.confirmRestart ||
+		(m.showTasks && m.tasks.CapturingInput()) || m.filter.Editing()
 }
 
 func (m *Model) SetSize(width, height int) {
 	m.width = width
 	m.height = height
-	m.table.SetHeight(height - 2)
+	m.table.SetHeight(height - 3)
+	m.detailDialog.SetSize(width, height)
+	m.editor.SetSize(width, height)
+	m.deleter.SetSize(width, height)
+	m.bulk.SetSize(width, height)
+	m.tasks.SetSize(width, height)
+	m.restartConfirm.SetSize(width, height)
+}
+
+// SetContext sets the context of the view's kafkactl runs. The app cancels
+// it when the view is left, abandoning loads still in flight.
+func (m *Model) SetContext(ctx context.Context) {
+	m.ctx = ctx
+}
+
+// SetColumns sets the configured columns of the view: added after the
+// built-in columns, or with replace, in place of all of them but the name.
+func (m *Model) SetColumns(extra []columns.Column, replace bool) {
+	m.extra, m.replaceColumns = extra, replace
+
+	builtin, numeric := builtinColumns, builtinNumeric
+	if replace {
+		builtin, numeric = builtinColumns[:2], nil
+	}
+	tableColumns := slices.Concat(builtin, columns.Table(extra))
+	m.sort = sorter.New("connectors", m.state, tableColumns, slices.Concat(numeric, columns.Numeric(extra))...)
+	// The rows are reset first, as the table renders them with the new
+	// columns as soon as they are set
+	m.table.SetRows(nil)
+	m.table.SetColumns(m.sort.Columns())
+	m.applyFilter()
+}
+
+// withColumns applies the configured columns to a row of built-in values.
+func (m Model) withColumns(row table.Row, doc map[string]any) table.Row {
+	if m.replaceColumns {
+		row = row[:2]
+	}
+	return append(row, columns.Values(m.extra, doc)...)
+}
+
+// AutoRefresh reloads the connectors, unless a dialog, form or workflow is
+// open or the filter is being edited.
+func (m Model) AutoRefresh() tea.Cmd {
+	if m.loading || m.editor.Active() || m.deleter.Active() || m.bulk.Active() || m.showDetail ||
+		m.showTasks || m.confirmRestart || m.filter.Editing() {
+		return nil
+	}
+	return m.loadConnectors
+}
+
+// FilterStatus returns the active filter expression with the number of
+// matching and total connectors.
+func (m Model) FilterStatus() (string, int, int) {
+	return m.filter.Value(), len(m.visible), len(m.connectors)
+}
+
+// applyFilter recomputes the visible rows from the filter.
+func (m *Model) applyFilter() {
+	m.visible = filter.Apply(m.filter, m.connectors, func(c models.Connector) map[string]any { return c.Raw })
+	sorter.Sort(m.sort, m.visible, func(connector models.Connector, column string) string {
+		if configured, ok := columns.Find(m.extra, column); ok {
+			return configured.SortValue(connector.Raw)
+		}
+		value := connectorColumns(connector)[column]
+		if column == "Tasks" {
+			// Sort by running tasks
+			value, _, _ = strings.Cut(value, "/")
+		}
+		return value
+	})
+	removed, _ := models.DecodeList[models.Connector](m.changes.Removed(time.Now()))
+	m.removed = filter.Apply(m.filter, removed, func(c models.Connector) map[string]any { return c.Raw })
+	m.updateTable()
+	if rows := len(m.table.Rows()); m.table.Cursor() >= rows {
+		m.table.SetCursor(max(rows-1, 0))
+	}
+	// The cursor is -1 once no row matched, or before any loaded; it moves
+	// back to the first row as soon as there is one
+	if m.table.Cursor() < 0 && len(m.table.Rows()) > 0 {
+		m.table.SetCursor(0)
+	}
 }
 
 func (m *Model) updateTable() {
 	rows := []table.Row{}
+	now := time.Now()
+	nameWidth := m.table.Columns()[1].Width
 
-	for _, connector := range m.connectors {
-		metadata, ok := connector["metadata"].(map[string]any)
-		if !ok {
-			continue
-		}
+	for _, connector := range slices.Concat(m.visible, m.removed) {
+		values := connectorColumns(connector)
+		name := connector.Metadata.Name
 
-		spec, ok := connector["spec"].(map[string]any)
-		if !ok {
-			continue
+		marker := m.marks.Marker(name)
+		if marker == "" {
+			marker = m.changes.Symbol(name, now)
 		}
 
-		name, ok := metadata["name"].(string)
-		if !ok {
-			continue
+		// The status dot counts towards the width of the name column, as
+		// the table measures it
+		dot := styles.StatusDot(values["State"])
+		rows = append(rows, m.withColumns(table.Row{
+			marker,
+			dot + " " + m.changes.Render(name, values["Name"], nameWidth-runewidth.StringWidth(dot)-1, now),
+			values["Class"],
+			values["Type"],
+			values["State"],
+			values["Tasks"],
+			values["Connect Cluster"],
+		}, connector.Raw))
+	}
+
+	m.table.SetRows(rows)
+}
+
+// connectorColumns returns the value of a connector in each column, by
+// column title.
+func connectorColumns(connector models.Connector) map[string]string {
+	connectorClass := "-"
+	connectorType := "source"
+	if class, ok := connector.Spec.Config["connector.class"]; ok {
+		connectorClass = class
+		if strings.Contains(strings.ToLower(class), "sink") {
+			connectorType = "sink"
 		}
+	}
 
-		connectorClass := "-"
-		connectorType := "source"
-		state := "RUNNING"
-		tasks := "1"
-		cluster := "-"
-
-		if config, configOk := spec["config"].(map[string]any); configOk {
-			if class, classOk := config["connector.class"].(string); classOk {
-				connectorClass = class
-				if strings.Contains(strings.ToLower(class), "sink") {
-					connectorType = "sink"
-				}
-			}
-			if t, tasksOk := config["tasks.max"]; tasksOk {
-				tasks = fmt.Sprintf("%v", t)
+	// Running out of reported tasks, or the configured maximum while the
+	// Connect cluster has not reported any
+	tasks := "-"
+	if reported := connector.Status.Tasks; len(reported) > 0 {
+		running := 0
+		for _, task := range reported {
+			if task.State == "RUNNING" {
+				running++
 			}
 		}
+		tasks = fmt.Sprintf("%d/%d", running, len(reported))
+	} else if t, ok := connector.Spec.Config["tasks.max"]; ok {
+		tasks = t
+	}
+
+	state := "-"
+	if connector.Status.State != "" {
+		state = connector.Status.State
+	}
+
+	cluster := "-"
+	if connector.Spec.ConnectCluster != "" {
+		cluster = connector.Spec.ConnectCluster
+	}
 
-		if c, clusterOk := spec["connectCluster"].(string); clusterOk {
-			cluster = c
+	return map[string]string{
+		"Name":            connector.Metadata.Name,
+		"Class":           connectorClass,
+		"Type":            connectorType,
+		"State":           state,
+		"Tasks":           tasks,
+		"Connect Cluster": cluster,
+	}
+}
+
+// startBulk runs action on the marked connectors the filter shows, or on
+// the connector under the cursor when none are marked. Marks hidden by
+// the filter never fall back to the cursor.
+func (m Model) startBulk(action bulk.Action) (Model, tea.Cmd) {
+	names := m.marks.Names(connectorNames(m.visible))
+	if len(names) == 0 && m.marks.Len() > 0 {
+		return m, footer.Info("No visible marked rows")
+	}
+	if len(names) == 0 {
+		if name := m.selectedName(); name != "" {
+			names = []string{name}
 		}
+	}
+	if len(names) == 0 {
+		return m, nil
+	}
 
-		displayName := styles.StatusDot(state) + " " + name
+	var cmd tea.Cmd
+	m.bulk, cmd = m.bulk.Start(m.ctx, action, names)
+	return m, cmd
+}
 
-		rows = append(rows, table.Row{
-			displayName,
-			connectorClass,
-			connectorType,
-			state,
-			tasks,
-			cluster,
-		})
+// connectorAction runs the kafkactl connector command of the verb, e.g.
+// pause, on each connector.
+func (m Model) connectorAction(verb, past string, confirmation bulk.Confirmation) bulk.Action {
+	message := ""
+	if verb == "Restart" {
+		message = "The connectors and all of their tasks are restarted."
+	}
+	command := strings.ToLower(verb)
+	return bulk.Action{
+		Verb:    verb,
+		Past:    past,
+		Kind:    "connector",
+		Confirm: confirmation,
+		Message: message,
+		Run: func(ctx context.Context, name string) (string, error) {
+			output, err := m.client.ExecuteCommand(ctx, "connector", command, name)
+			return string(output), err
+		},
+		Done: m.reload,
 	}
+}
 
-	m.table.SetRows(rows)
+// reload reloads the connectors once a bulk action is done.
+func (m Model) reload([]kafkactl.BulkResult) tea.Cmd {
+	return m.loadConnectors
+}
+
+func connectorNames(connectors []models.Connector) []string {
+	names := make([]string, len(connectors))
+	for i, connector := range connectors {
+		names[i] = connector.Metadata.Name
+	}
+	return names
+}
+
+// selectedName returns the name of the connector under the cursor. The
+// first column cannot be used as it is prefixed with the status dot.
+func (m Model) selectedName() string {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.visible) {
+		return ""
+	}
+	return m.visible[cursor].Metadata.Name
+}
+
+// loaded applies a snapshot of the connectors, highlighting what changed
+// since the previous one, such as a connector that failed.
+func (m Model) loaded(msg connectorsLoadedMsg) (tea.Model, tea.Cmd) {
+	if errors.Is(msg.err, context.Canceled) {
+		return m, nil
+	}
+	m.loading = false
+	m.err = nil
+	if msg.err != nil && !errors.Is(msg.err, models.ErrDecode) {
+		m.err = msg.err
+		return m, footer.Failure("Failed to load connectors: " + msg.err.Error())
+	}
+
+	var cmd tea.Cmd
+	m.changes, cmd = m.changes.Update(changes.Snapshot(msg.connectors, func(c models.Connector) (string, map[string]any) {
+		return c.Metadata.Name, c.Raw
+	}), time.Now())
+	m.connectors = msg.connectors
+	m.applyFilter()
+	if msg.err != nil {
+		cmd = tea.Batch(cmd, footer.Failure("Some connectors could not be read: "+msg.err.Error()))
+	}
+	return m, cmd
 }
 
 type connectorsLoadedMsg struct {
-	connectors []map[string]any
+	connectors []models.Connector
 	err        error
 }
 
@@ -222,22 +677,22 @@ type connectorDetailMsg struct {
 
 type connectorActionMsg struct {
 	action string
+	name   string
 	result string
+	err    error
 }
 
 func (m *Model) loadConnectors() tea.Msg {
-	connectors, err := m.client.GetConnectors()
+	connectors, err := m.client.ListConnectors(m.ctx)
 	return connectorsLoadedMsg{connectors: connectors, err: err}
 }
 
 func (m *Model) loadConnectorDetail() tea.Msg {
-	selectedRow := m.table.SelectedRow()
-	if len(selectedRow) == 0 {
+	connectorName := m.selectedName()
+	if connectorName == "" {
 		return nil
 	}
-
-	connectorName := selectedRow[0]
-	yaml, err := m.client.GetResourceYAML("connector", connectorName)
+	yaml, err := m.client.GetResourceYAML(m.ctx, "connector", connectorName)
 	if err != nil {
 		return connectorDetailMsg{yaml: fmt.Sprintf("Error loading connector details: %v", err)}
 	}
@@ -246,46 +701,43 @@ func (m *Model) loadConnectorDetail() tea.Msg {
 }
 
 func (m *Model) pauseConnector() tea.Msg {
-	selectedRow := m.table.SelectedRow()
-	if len(selectedRow) == 0 {
+	connectorName := m.selectedName()
+	if connectorName == "" {
 		return nil
 	}
-
-	connectorName := selectedRow[0]
-	_, err := m.client.ExecuteCommand("connector", "pause", connectorName)
-	if err != nil {
-		return connectorActionMsg{action: "pause", result: fmt.Sprintf("Error: %v", err)}
+	_, err := m.client.ExecuteCommand(m.ctx, "connector", "pause", connectorName)
+	return connectorActionMsg{
+		action: "pause",
+		name:   connectorName,
+		result: "Paused connector " + connectorName,
+		err:    err,
 	}
-
-	return connectorActionMsg{action: "pause", result: "success"}
 }
 
 func (m *Model) resumeConnector() tea.Msg {
-	selectedRow := m.table.SelectedRow()
-	if len(selectedRow) == 0 {
+	connectorName := m.selectedName()
+	if connectorName == "" {
 		return nil
 	}
-
-	connectorName := selectedRow[0]
-	_, err := m.client.ExecuteCommand("connector", "resume", connectorName)
-	if err != nil {
-		return connectorActionMsg{action: "resume", result: fmt.Sprintf("Error: %v", err)}
+	_, err := m.client.ExecuteCommand(m.ctx, "connector", "resume", connectorName)
+	return connectorActionMsg{
+		action: "resume",
+		name:   connectorName,
+		result: "Resumed connector " + connectorName,
+		err:    err,
 	}
-
-	return connectorActionMsg{action: "resume", result: "success"}
 }
 
 func (m *Model) restartConnector() tea.Msg {
-	selectedRow := m.table.SelectedRow()
-	if len(selectedRow) == 0 {
+	connectorName := m.selectedName()
+	if connectorName == "" {
 		return nil
 	}
-
-	connectorName := selectedRow[0]
-	_, err := m.client.ExecuteCommand("connector", "restart", connectorName)
-	if err != nil {
-		return connectorActionMsg{action: "restart", result: fmt.Sprintf("Error: %v", err)}
+	_, err := m.client.ExecuteCommand(m.ctx, "connector", "restart", connectorName)
+	return connectorActionMsg{
+		action: "restart",
+		name:   connectorName,
+		result: "Restarted connector " + connectorName,
+		err:    err,
 	}
-
-	return connectorActionMsg{action: "restart", result: "success"}
 }
@@ -0,0 +1,309 @@
+package connectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/confirm"
+	"github.com/smart-fellas/k4a/internal/ui/components/dialog"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+	"github.com/smart-fellas/k4a/internal/ui/components/loaderror"
+	"github.com/smart-fellas/k4a/internal/ui/keys"
+	"github.com/smart-fellas/k4a/internal/ui/styles"
+	"github.com/smart-fellas/k4a/pkg/models"
+)
+
+// tasksModel shows the status of one connector and its tasks. Neither
+// kafkactl nor ns4kafka restarts a single task, so R restarts the whole
+// connector, with all of its tasks, once confirmed as in the list.
+type tasksModel struct {
+	client    *kafkactl.Client
+	ctx       context.Context
+	name      string
+	connector models.Connector
+	table     table.Model
+	keys      keys.KeyMap
+	width     int
+	height    int
+	loading   bool
+	err       error
+	status    string
+	closed    bool
+
+	// Stack trace of a failed task
+	showTrace   bool
+	traceDialog dialog.Model
+
+	// Restart confirmation
+	confirmRestart bool
+	restartConfirm confirm.Model
+}
+
+func newTasksModel(ctx context.Context, client *kafkactl.Client, name string) tasksModel {
+	columns := []table.Column{
+		{Title: "Task", Width: 6},
+		{Title: "State", Width: 14},
+		{Title: "Worker", Width: 30},
+		{Title: "Error", Width: 60},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(20),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	t.SetStyles(s)
+
+	return tasksModel{
+		client:      client,
+		ctx:         ctx,
+		name:        name,
+		table:       t,
+		keys:        keys.DefaultKeyMap(),
+		loading:     true,
+		traceDialog: dialog.New(),
+	}
+}
+
+func (m tasksModel) Init() tea.Cmd {
+	return m.loadStatus
+}
+
+// Closed reports whether the user left the tasks view.
+func (m tasksModel) Closed() bool {
+	return m.closed
+}
+
+func (m tasksModel) Update(msg tea.Msg) (tasksModel, tea.Cmd) {
+	if m.showTrace {
+		if msg, ok := msg.(tea.KeyMsg); ok && key.Matches(msg, m.keys.Back) {
+			m.showTrace = false
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+		m.traceDialog, cmd = m.traceDialog.Update(msg)
+		return m, cmd
+	}
+
+	if m.confirmRestart {
+		var cmd tea.Cmd
+		m.restartConfirm, cmd = m.restartConfirm.Update(msg)
+		switch {
+		case m.restartConfirm.Confirmed():
+			m.confirmRestart = false
+			m.status = fmt.Sprintf("Restarting connector %s and all of its tasks...", m.name)
+			return m, m.restartConnector
+		case m.restartConfirm.Cancelled():
+			m.confirmRestart = false
+		}
+		if _, ok := msg.(tea.KeyMsg); ok {
+			return m, cmd
+		}
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		m.status = ""
+		switch {
+		case key.Matches(msg, m.keys.Back):
+			m.closed = true
+			return m, nil
+
+		case key.Matches(msg, m.keys.Enter) || msg.String() == "t":
+			task, ok := m.selectedTask()
+			if !ok {
+				return m, nil
+			}
+			if task.Trace == "" {
+				m.status = fmt.Sprintf("Task %d has no error trace", task.ID)
+				return m, nil
+			}
+			m.traceDialog.SetTitle(fmt.Sprintf("%s task %d trace (ESC to close)", m.name, task.ID))
+			m.traceDialog.SetContent(task.Trace)
+			m.traceDialog.SetSize(m.width, m.height)
+			m.showTrace = true
+			return m, nil
+
+		case msg.String() == "R":
+			m.restartConfirm = confirm.NewYesNo(
+				"Restart connector "+m.name,
+				"The connector and all of its tasks are restarted.",
+				m.client.ContextName(),
+				m.client.Namespace(),
+			)
+			m.restartConfirm.SetSize(m.width, m.height)
+			m.confirmRestart = true
+			return m, nil
+
+		case key.Matches(msg, m.keys.Refresh):
+			if m.err != nil {
+				m.err = nil
+				m.loading = true
+			}
+			return m, m.loadStatus
+		}
+
+	case connectorStatusMsg:
+		if msg.name != m.name || errors.Is(msg.err, context.Canceled) {
+			return m, nil
+		}
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, footer.Failure(fmt.Sprintf("Failed to load status of connector %s: %v", m.name, msg.err))
+		}
+		m.connector = msg.connector
+		m.updateTable()
+		return m, nil
+
+	case connectorRestartedMsg:
+		if msg.name != m.name {
+			return m, nil
+		}
+		m.status = ""
+		if msg.err != nil {
+			return m, footer.Failure(fmt.Sprintf("Failed to restart connector %s: %v", m.name, msg.err))
+		}
+		return m, tea.Batch(footer.Success("Restarted connector "+m.name+" and all of its tasks"), m.loadStatus)
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+func (m tasksModel) View() string {
+	if m.showTrace {
+		return m.traceDialog.View()
+	}
+
+	if m.loading {
+		return "Loading status of connector " + m.name + "..."
+	}
+
+	if m.err != nil {
+		return loaderror.View("connector status", m.err)
+	}
+
+	state := m.connector.Status.State
+	if state == "" {
+		state = "UNKNOWN"
+	}
+	header := fmt.Sprintf("%s %s  %s", styles.StatusDot(state), m.name, state)
+	if m.connector.Status.WorkerID != "" {
+		header += "  on " + m.connector.Status.WorkerID
+	}
+
+	view := header + "\n" + m.table.View()
+	if len(m.connector.Status.Tasks) == 0 {
+		view += "\n" + styles.MutedText.Render("The connector has no tasks")
+	}
+	if m.status != "" {
+		view += "\n" + m.status
+	} else {
+		view += "\n" + styles.MutedText.Render("enter trace, R restart the whole connector (no single-task restart), r refresh, esc back")
+	}
+
+	if m.confirmRestart {
+		return m.restartConfirm.Over(view)
+	}
+
+	return view
+}
+
+// CapturingInput reports whether the restart confirmation is receiving
+// keystrokes.
+func (m tasksModel) CapturingInput() bool {
+	return m.confirmRestart
+}
+
+func (m *tasksModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.table.SetHeight(height - 4)
+	m.traceDialog.SetSize(width, height)
+	m.restartConfirm.SetSize(width, height)
+}
+
+func (m *tasksModel) updateTable() {
+	rows := []table.Row{}
+
+	for _, task := range m.connector.Status.Tasks {
+		worker := "-"
+		if task.WorkerID != "" {
+			worker = task.WorkerID
+		}
+
+		rows = append(rows, table.Row{
+			strconv.Itoa(task.ID),
+			styles.StatusDot(task.State) + " " + task.State,
+			worker,
+			traceSummary(task.Trace),
+		})
+	}
+
+	m.table.SetRows(rows)
+	if cursor := m.table.Cursor(); cursor >= len(rows) {
+		m.table.SetCursor(max(len(rows)-1, 0))
+	}
+}
+
+func (m tasksModel) selectedTask() (models.TaskStatus, bool) {
+	cursor := m.table.Cursor()
+	tasks := m.connector.Status.Tasks
+	if cursor < 0 || cursor >= len(tasks) {
+		return models.TaskStatus{}, false
+	}
+	return tasks[cursor], true
+}
+
+// traceSummary returns the first line of a stack trace, which holds the
+// exception and its message.
+func traceSummary(trace string) string {
+	if trace == "" {
+		return "-"
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(trace), "\n")
+	return line
+}
+
+type connectorStatusMsg struct {
+	name      string
+	connector models.Connector
+	err       error
+}
+
+type connectorRestartedMsg struct {
+	name string
+	err  error
+}
+
+func (m tasksModel) loadStatus() tea.Msg {
+	connector, err := m.client.GetConnector(m.ctx, m.name)
+	return connectorStatusMsg{name: m.name, connector: connector, err: err}
+}
+
+func (m tasksModel) restartConnector() tea.Msg {
+	_, err := m.client.ExecuteCommand(m.ctx, "connector", "restart", m.name)
+	return connectorRestartedMsg{name: m.name, err: err}
+}
@@ -0,0 +1,349 @@
+package consumers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/dialog"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+	"github.com/smart-fellas/k4a/internal/ui/components/loaderror"
+	"github.com/smart-fellas/k4a/internal/ui/keys"
+	"github.com/smart-fellas/k4a/internal/ui/styles"
+	"github.com/smart-fellas/k4a/pkg/models"
+)
+
+type Model struct {
+	client  *kafkactl.Client
+	ctx     context.Context
+	table   table.Model
+	groups  []models.ConsumerGroup
+	keys    keys.KeyMap
+	width   int
+	height  int
+	loading bool
+	err     error
+
+	// Detail view
+	showDetail   bool
+	detailDialog dialog.Model
+
+	// Per-partition lag view
+	showOffsets  bool
+	offsetsTable table.Model
+	offsetsGroup models.ConsumerGroup
+
+	// Offset reset wizard
+	showReset bool
+	reset     ResetWizard
+}
+
+func New(client *kafkactl.Client) Model {
+	columns := []table.Column{
+		{Title: "Group ID", Width: 40},
+		{Title: "State", Width: 22},
+		{Title: "Members", Width: 10},
+		{Title: "Lag", Width: 12},
+		{Title: "Topics", Width: 50},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(20),
+	)
+	t.SetStyles(tableStyles())
+
+	return Model{
+		client:       client,
+		ctx:          context.Background(),
+		table:        t,
+		keys:         keys.DefaultKeyMap(),
+		loading:      true,
+		detailDialog: dialog.New(),
+	}
+}
+
+func tableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	return s
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadConsumerGroups
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	// Handle offset reset wizard
+	if m.showReset {
+		newWizard, cmd := m.reset.Update(msg)
+		m.reset = newWizard
+		if m.reset.Closed() {
+			m.showReset = false
+			if m.reset.Executed() {
+				m.showOffsets = false
+				return m, m.loadConsumerGroups
+			}
+		}
+		return m, cmd
+	}
+
+	// Handle detail view
+	if m.showDetail {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			if key.Matches(msg, m.keys.Back) {
+				m.showDetail = false
+				return m, nil
+			}
+		case consumerGroupDetailMsg:
+			m.detailDialog.SetContent(msg.yaml)
+			return m, nil
+		}
+
+		newDialog, cmd := m.detailDialog.Update(msg)
+		m.detailDialog = newDialog
+		return m, cmd
+	}
+
+	// Handle per-partition lag view
+	if m.showOffsets {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			if key.Matches(msg, m.keys.Back) {
+				m.showOffsets = false
+				return m, nil
+			}
+			if msg.String() == "o" {
+				topic := ""
+				if row := m.offsetsTable.SelectedRow(); len(row) > 0 {
+					topic = row[0]
+				}
+				m.startReset(m.offsetsGroup, topic)
+				return m, nil
+			}
+		}
+
+		newTable, cmd := m.offsetsTable.Update(msg)
+		m.offsetsTable = newTable
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Enter):
+			if group, ok := m.selectedGroup(); ok {
+				m.updateOffsetsTable(group)
+				m.showOffsets = true
+				return m, nil
+			}
+
+		case key.Matches(msg, m.keys.Describe):
+			if len(m.groups) > 0 {
+				m.showDetail = true
+				return m, m.loadConsumerGroupDetail
+			}
+
+		case key.Matches(msg, m.keys.Refresh):
+			if m.err != nil {
+				m.err = nil
+				m.loading = true
+			}
+			return m, m.loadConsumerGroups
+
+		case msg.String() == "o":
+			// Reset offsets of the selected group
+			if group, ok := m.selectedGroup(); ok {
+				m.startReset(group, "")
+				return m, nil
+			}
+		}
+
+	case consumerGroupsLoadedMsg:
+		if errors.Is(msg.err, context.Canceled) {
+			return m, nil
+		}
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, footer.Failure("Failed to load consumer groups: " + msg.err.Error())
+		}
+		m.groups = msg.groups
+		m.updateTable()
+	}
+
+	newTable, cmd := m.table.Update(msg)
+	m.table = newTable
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) View() string {
+	if m.showReset {
+		return m.reset.View()
+	}
+
+	if m.showDetail {
+		return m.detailDialog.View()
+	}
+
+	if m.showOffsets {
+		return m.offsetsTable.View()
+	}
+
+	if m.loading {
+		return "Loading consumer groups..."
+	}
+
+	if m.err != nil {
+		return loaderror.View("consumer groups", m.err)
+	}
+
+	return m.table.View()
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.table.SetHeight(height - 2)
+	m.offsetsTable.SetHeight(height - 2)
+	m.detailDialog.SetSize(width, height)
+	m.reset.SetSize(width, height)
+}
+
+// SetContext sets the context of the view's kafkactl runs. The app cancels
+// it when the view is left, abandoning loads still in flight.
+func (m *Model) SetContext(ctx context.Context) {
+	m.ctx = ctx
+}
+
+// CapturingInput reports whether the offset reset wizard is collecting text
+// or waiting for a kafkactl run.
+func (m Model) CapturingInput() bool {
+	return m.showReset && m.reset.CapturingInput()
+}
+
+func (m *Model) startReset(group models.ConsumerGroup, topic string) {
+	m.reset = NewResetWizard(m.ctx, m.client, group, topic)
+	m.reset.SetSize(m.width, m.height)
+	m.showReset = true
+}
+
+func (m *Model) updateTable() {
+	rows := []table.Row{}
+
+	for _, group := range m.groups {
+		rows = append(rows, table.Row{
+			group.Metadata.Name,
+			styles.StatusDot(stateStatus(group.Status.State)) + " " + group.Status.State,
+			strconv.Itoa(group.Status.Members),
+			strconv.FormatInt(group.TotalLag(), 10),
+			strings.Join(group.Status.Topics, ", "),
+		})
+	}
+
+	m.table.SetRows(rows)
+}
+
+func (m *Model) updateOffsetsTable(group models.ConsumerGroup) {
+	m.offsetsGroup = group
+
+	columns := []table.Column{
+		{Title: "Topic", Width: 40},
+		{Title: "Partition", Width: 10},
+		{Title: "Current Offset", Width: 16},
+		{Title: "End Offset", Width: 16},
+		{Title: "Lag", Width: 12},
+	}
+
+	m.offsetsTable = table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(m.height-2),
+	)
+	m.offsetsTable.SetStyles(tableStyles())
+
+	rows := []table.Row{}
+	for _, offset := range group.Status.Offsets {
+		rows = append(rows, table.Row{
+			offset.Topic,
+			strconv.Itoa(offset.Partition),
+			strconv.FormatInt(offset.CurrentOffset, 10),
+			strconv.FormatInt(offset.EndOffset, 10),
+			strconv.FormatInt(offset.Lag, 10),
+		})
+	}
+
+	m.offsetsTable.SetRows(rows)
+}
+
+func (m Model) selectedGroup() (models.ConsumerGroup, bool) {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.groups) {
+		return models.ConsumerGroup{}, false
+	}
+	return m.groups[cursor], true
+}
+
+// stateStatus maps a Kafka consumer group state onto the statuses
+// understood by styles.StatusDot.
+func stateStatus(state string) string {
+	switch state {
+	case "Stable":
+		return "RUNNING"
+	case "PreparingRebalance", "CompletingRebalance", "Empty":
+		return "PENDING"
+	case "Dead":
+		return "FAILED"
+	default:
+		return state
+	}
+}
+
+type consumerGroupsLoadedMsg struct {
+	groups []models.ConsumerGroup
+	err    error
+}
+
+type consumerGroupDetailMsg struct {
+	yaml string
+}
+
+func (m *Model) loadConsumerGroups() tea.Msg {
+	groups, err := m.client.GetAllConsumerGroups(m.ctx)
+	return consumerGroupsLoadedMsg{groups: groups, err: err}
+}
+
+func (m *Model) loadConsumerGroupDetail() tea.Msg {
+	group, ok := m.selectedGroup()
+	if !ok {
+		return nil
+	}
+
+	yaml, err := m.client.GetResourceYAML(m.ctx, "consumer-groups", group.Metadata.Name)
+	if err != nil {
+		return consumerGroupDetailMsg{yaml: fmt.Sprintf("Error loading consumer group details: %v", err)}
+	}
+
+	return consumerGroupDetailMsg{yaml: yaml}
+}
@@ -0,0 +1,429 @@
+package consumers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+	"github.com/smart-fellas/k4a/internal/ui/components/form"
+	"github.com/smart-fellas/k4a/pkg/models"
+)
+
+type resetStep int
+
+const (
+	stepMethod resetStep = iota
+	stepValue
+	stepDryRun
+	stepPreview
+	stepExecuting
+	stepDone
+)
+
+// Keys of the value form fields
+const (
+	fieldTopic = "topic"
+	fieldValue = "value"
+)
+
+var (
+	wizardTitleStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("229")).
+				MarginBottom(1)
+
+	selectedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("229")).
+			Background(lipgloss.Color("57"))
+
+	hintStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241"))
+
+	warningStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("214"))
+
+	errorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196"))
+
+	successStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("42"))
+)
+
+var methodDescriptions = map[kafkactl.ResetMethod]string{
+	kafkactl.ResetToEarliest: "Reset to the earliest available offset",
+	kafkactl.ResetToLatest:   "Reset to the latest offset (skip everything)",
+	kafkactl.ResetToDatetime: "Reset to the first offset after a date-time",
+	kafkactl.ResetByDuration: "Reset to the first offset within a duration from now",
+	kafkactl.ResetShiftBy:    "Shift the current offset by N (negative to replay)",
+	kafkactl.ResetToOffset:   "Reset every partition to an absolute offset",
+}
+
+var valuePlaceholders = map[kafkactl.ResetMethod]string{
+	kafkactl.ResetToDatetime: "2024-01-31T08:00:00Z",
+	kafkactl.ResetByDuration: "PT1H or 1h30m",
+	kafkactl.ResetShiftBy:    "-100",
+	kafkactl.ResetToOffset:   "0",
+}
+
+// ResetWizard guides the user through a consumer group offset reset. It
+// always runs a dry-run first and only executes after confirmation.
+type ResetWizard struct {
+	client *kafkactl.Client
+	ctx    context.Context
+	group  models.ConsumerGroup
+	step   resetStep
+	width  int
+	height int
+
+	method int
+	// topic is kept when going back to pick another method
+	topic string
+	form  form.Model
+
+	options kafkactl.ResetOffsetsOptions
+	preview table.Model
+	result  string
+	err     error
+	closed  bool
+}
+
+// NewResetWizard starts a reset wizard for a consumer group, optionally
+// restricted to a topic. ctx bounds the wizard's kafkactl runs.
+func NewResetWizard(ctx context.Context, client *kafkactl.Client, group models.ConsumerGroup, topic string) ResetWizard {
+	return ResetWizard{
+		client: client,
+		ctx:    ctx,
+		group:  group,
+		topic:  topic,
+	}
+}
+
+// Closed reports whether the wizard has been dismissed.
+func (w ResetWizard) Closed() bool {
+	return w.closed
+}
+
+// CapturingInput reports whether the wizard is collecting text or waiting
+// for the dry-run or the reset. Their results reach only the current view,
+// so it must not be left before they are back.
+func (w ResetWizard) CapturingInput() bool {
+	return w.step == stepValue || w.step == stepDryRun || w.step == stepExecuting
+}
+
+// Executed reports whether offsets were reset for real.
+func (w ResetWizard) Executed() bool {
+	return w.step == stepDone && w.err == nil
+}
+
+func (w *ResetWizard) SetSize(width, height int) {
+	w.width = width
+	w.height = height
+	w.preview.SetHeight(height - 8)
+}
+
+func (w ResetWizard) Update(msg tea.Msg) (ResetWizard, tea.Cmd) {
+	switch msg := msg.(type) {
+	case resetDryRunMsg:
+		if w.step != stepDryRun {
+			return w, nil
+		}
+		if msg.err != nil {
+			w.step = stepValue
+			w.form.Resume()
+			w.form.SetError("", msg.err)
+			return w, nil
+		}
+		w.buildPreview(msg.resets, msg.group)
+		w.step = stepPreview
+		return w, nil
+
+	case resetExecutedMsg:
+		w.step = stepDone
+		w.err = msg.err
+		if msg.err != nil {
+			return w, footer.Failure(fmt.Sprintf("Reset of %s failed: %v", w.group.Metadata.Name, msg.err))
+		}
+		w.result = fmt.Sprintf("Offsets of %s reset on %d partition(s)", w.group.Metadata.Name, len(msg.resets))
+		return w, footer.Success(w.result)
+
+	case tea.KeyMsg:
+		return w.handleKey(msg)
+	}
+
+	return w, nil
+}
+
+func (w ResetWizard) handleKey(msg tea.KeyMsg) (ResetWizard, tea.Cmd) {
+	switch w.step {
+	case stepMethod:
+		switch msg.String() {
+		case "up", "k":
+			w.method = (w.method + len(kafkactl.ResetMethods) - 1) % len(kafkactl.ResetMethods)
+		case "down", "j":
+			w.method = (w.method + 1) % len(kafkactl.ResetMethods)
+		case "enter":
+			w.step = stepValue
+			w.form = w.newValueForm()
+			return w, w.form.Init()
+		case "esc":
+			w.closed = true
+		}
+		return w, nil
+
+	case stepValue:
+		var cmd tea.Cmd
+		w.form, cmd = w.form.Update(msg)
+		switch {
+		case w.form.Cancelled():
+			w.topic = w.form.Value(fieldTopic)
+			w.step = stepMethod
+		case w.form.Submitted():
+			w.options = w.buildOptions(w.form.Values())
+			w.step = stepDryRun
+			return w, w.dryRun(w.options)
+		}
+		return w, cmd
+
+	case stepPreview:
+		switch msg.String() {
+		case "y":
+			w.step = stepExecuting
+			return w, w.execute(w.options)
+		case "esc", "n":
+			w.step = stepValue
+			w.form.Resume()
+			return w, nil
+		}
+
+		var cmd tea.Cmd
+		w.preview, cmd = w.preview.Update(msg)
+		return w, cmd
+
+	case stepDone:
+		switch msg.String() {
+		case "esc", "enter":
+			w.closed = true
+		}
+		return w, nil
+
+	case stepDryRun, stepExecuting:
+		return w, nil
+	}
+
+	return w, nil
+}
+
+func (w ResetWizard) selectedMethod() kafkactl.ResetMethod {
+	return kafkactl.ResetMethods[w.method]
+}
+
+// newValueForm opens the form of the topic and, for methods that take
+// one, the value of the selected method.
+func (w ResetWizard) newValueForm() form.Model {
+	method := w.selectedMethod()
+	fields := []form.Field{
+		{Key: fieldTopic, Label: "Topic", Placeholder: "all topics", Default: w.topic},
+	}
+	if method.NeedsValue() {
+		fields = append(fields, form.Field{
+			Key:         fieldValue,
+			Label:       "Value",
+			Placeholder: valuePlaceholders[method],
+			Required:    true,
+			Validate:    func(value string) error { return validateValue(method, value) },
+		})
+	}
+	return form.New("Method: --"+string(method), fields...)
+}
+
+// validateValue checks the value of a reset method.
+func validateValue(method kafkactl.ResetMethod, value string) error {
+	switch method {
+	case kafkactl.ResetToDatetime:
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return fmt.Errorf("date-time must be ISO-8601, e.g. 2024-01-31T08:00:00Z")
+		}
+	case kafkactl.ResetByDuration:
+		if !strings.HasPrefix(isoDuration(value), "P") {
+			return fmt.Errorf("duration must be ISO-8601 (PT1H) or Go style (1h30m)")
+		}
+	case kafkactl.ResetShiftBy:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("shift must be an integer")
+		}
+	case kafkactl.ResetToOffset:
+		if n, err := strconv.ParseInt(value, 10, 64); err != nil || n < 0 {
+			return fmt.Errorf("offset must be a non-negative integer")
+		}
+	case kafkactl.ResetToEarliest, kafkactl.ResetToLatest:
+	}
+	return nil
+}
+
+// buildOptions returns the dry-run options of the submitted form.
+func (w ResetWizard) buildOptions(values form.Values) kafkactl.ResetOffsetsOptions {
+	opts := kafkactl.ResetOffsetsOptions{
+		Group:  w.group.Metadata.Name,
+		Topic:  values.String(fieldTopic),
+		Method: w.selectedMethod(),
+		DryRun: true,
+	}
+
+	switch {
+	case opts.Method == kafkactl.ResetByDuration:
+		opts.Value = isoDuration(values.String(fieldValue))
+	case opts.Method.NeedsValue():
+		opts.Value = values.String(fieldValue)
+	}
+	return opts
+}
+
+// isoDuration converts a Go style duration such as "1h30m" to the ISO-8601
+// form expected by kafkactl. ISO-8601 input is returned unchanged.
+func isoDuration(value string) string {
+	if strings.HasPrefix(strings.ToUpper(value), "P") {
+		return strings.ToUpper(value)
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return value
+	}
+
+	return fmt.Sprintf("PT%dS", int64(d.Seconds()))
+}
+
+func (w *ResetWizard) buildPreview(resets []kafkactl.OffsetReset, group models.ConsumerGroup) {
+	current := map[string]int64{}
+	for _, offset := range group.Status.Offsets {
+		current[fmt.Sprintf("%s/%d", offset.Topic, offset.Partition)] = offset.CurrentOffset
+	}
+
+	columns := []table.Column{
+		{Title: "Topic", Width: 40},
+		{Title: "Partition", Width: 10},
+		{Title: "Before", Width: 16},
+		{Title: "After", Width: 16},
+		{Title: "Change", Width: 12},
+	}
+
+	rows := []table.Row{}
+	for _, reset := range resets {
+		topic := reset.Topic
+		if topic == "" {
+			topic = w.options.Topic
+		}
+
+		before, change := "-", "-"
+		if offset, ok := current[fmt.Sprintf("%s/%d", topic, reset.Partition)]; ok {
+			before = strconv.FormatInt(offset, 10)
+			change = fmt.Sprintf("%+d", reset.NewOffset-offset)
+		}
+
+		rows = append(rows, table.Row{
+			topic,
+			strconv.Itoa(reset.Partition),
+			before,
+			strconv.FormatInt(reset.NewOffset, 10),
+			change,
+		})
+	}
+
+	w.preview = table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(w.height-8),
+	)
+	w.preview.SetStyles(tableStyles())
+}
+
+func (w ResetWizard) View() string {
+	var b strings.Builder
+
+	b.WriteString(wizardTitleStyle.Render("Reset offsets of " + w.group.Metadata.Name))
+	b.WriteString("\n")
+
+	switch w.step {
+	case stepMethod:
+		if w.group.Status.State != "" && w.group.Status.State != "Empty" && w.group.Status.State != "Unknown" {
+			b.WriteString(warningStyle.Render(fmt.Sprintf("Group is %s: stop its consumers before resetting.", w.group.Status.State)))
+			b.WriteString("\n\n")
+		}
+		for i, method := range kafkactl.ResetMethods {
+			line := fmt.Sprintf("  --%-12s %s", method, methodDescriptions[method])
+			if i == w.method {
+				line = selectedStyle.Render(line)
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n" + hintStyle.Render("↑/↓ choose, enter next, esc cancel"))
+
+	case stepValue:
+		b.WriteString(w.form.View())
+
+	case stepDryRun:
+		b.WriteString("Running dry-run: kafkactl " + strings.Join(w.options.Args(), " "))
+
+	case stepPreview:
+		b.WriteString("Dry-run result for: kafkactl " + strings.Join(w.options.Args(), " ") + "\n\n")
+		b.WriteString(w.preview.View())
+		b.WriteString("\n" + warningStyle.Render("Press y to execute this reset, esc to go back"))
+
+	case stepExecuting:
+		b.WriteString("Resetting offsets...")
+
+	case stepDone:
+		if w.err != nil {
+			b.WriteString(errorStyle.Render(fmt.Sprintf("Reset failed: %v", w.err)))
+		} else {
+			b.WriteString(successStyle.Render(w.result))
+		}
+		b.WriteString("\n\n" + hintStyle.Render("Press enter to close"))
+	}
+
+	return b.String()
+}
+
+type resetDryRunMsg struct {
+	resets []kafkactl.OffsetReset
+	group  models.ConsumerGroup
+	err    error
+}
+
+type resetExecutedMsg struct {
+	resets []kafkactl.OffsetReset
+	err    error
+}
+
+func (w ResetWizard) dryRun(opts kafkactl.ResetOffsetsOptions) tea.Cmd {
+	return func() tea.Msg {
+		resets, err := w.client.ResetOffsets(w.ctx, opts)
+		if err != nil {
+			return resetDryRunMsg{err: err}
+		}
+
+		// Fetch committed offsets so the preview can show before/after.
+		group, groupErr := w.client.GetConsumerGroup(w.ctx, opts.Group)
+		if groupErr != nil {
+			group = w.group
+		}
+
+		return resetDryRunMsg{resets: resets, group: group}
+	}
+}
+
+func (w ResetWizard) execute(opts kafkactl.ResetOffsetsOptions) tea.Cmd {
+	opts.DryRun = false
+	return func() tea.Msg {
+		resets, err := w.client.ResetOffsets(w.ctx, opts)
+		return resetExecutedMsg{resets: resets, err: err}
+	}
+}
@@ -0,0 +1,137 @@
+package contexts
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/config"
+	"github.com/smart-fellas/k4a/internal/ui/keys"
+)
+
+// SelectedMsg asks the app to switch to a context. Save also makes it
+// kafkactl's current-context in the config file.
+type SelectedMsg struct {
+	Name string
+	Save bool
+}
+
+// Model lists the contexts of the kafkactl config for switching between
+// them.
+type Model struct {
+	config  *config.Config
+	current string
+	table   table.Model
+	keys    keys.KeyMap
+	width   int
+	height  int
+}
+
+func New(cfg *config.Config, current string) Model {
+	columns := []table.Column{
+		{Title: "", Width: 2},
+		{Title: "Name", Width: 30},
+		{Title: "Namespace", Width: 30},
+		{Title: "API", Width: 50},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(20),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	t.SetStyles(s)
+
+	m := Model{
+		config:  cfg,
+		current: current,
+		table:   t,
+		keys:    keys.DefaultKeyMap(),
+	}
+	m.updateTable()
+
+	return m
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.Enter):
+			return m, m.selected(false)
+		case msg.String() == "s":
+			return m, m.selected(true)
+		}
+	}
+
+	newTable, cmd := m.table.Update(msg)
+	m.table = newTable
+
+	return m, cmd
+}
+
+func (m Model) View() string {
+	if len(m.config.Contexts) == 0 {
+		return "No contexts found in the kafkactl config"
+	}
+
+	return m.table.View()
+}
+
+// CapturingInput reports whether the view is receiving keystrokes; the
+// context list never is.
+func (m Model) CapturingInput() bool {
+	return false
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.table.SetHeight(height - 2)
+}
+
+func (m *Model) updateTable() {
+	rows := []table.Row{}
+
+	for _, ctx := range m.config.Contexts {
+		marker := ""
+		if ctx.Name == m.current {
+			marker = "*"
+		}
+
+		rows = append(rows, table.Row{
+			marker,
+			ctx.Name,
+			ctx.Context.Namespace,
+			ctx.Context.API,
+		})
+	}
+
+	m.table.SetRows(rows)
+}
+
+func (m Model) selected(save bool) tea.Cmd {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.config.Contexts) {
+		return nil
+	}
+
+	name := m.config.Contexts[cursor].Name
+	return func() tea.Msg {
+		return SelectedMsg{Name: name, Save: save}
+	}
+}
@@ -0,0 +1,201 @@
+package events
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/eventstream"
+	"github.com/smart-fellas/k4a/internal/ui/components/dialog"
+	"github.com/smart-fellas/k4a/internal/ui/components/diffview"
+	"github.com/smart-fellas/k4a/internal/ui/keys"
+	"github.com/smart-fellas/k4a/internal/utils"
+)
+
+// Model lists the changes observed to the resources of the namespace,
+// newest first. Enter shows the diff of the resource's manifests.
+type Model struct {
+	stream *eventstream.Stream
+	table  table.Model
+	events []eventstream.Event
+	keys   keys.KeyMap
+	width  int
+	height int
+
+	// Diff view
+	showDiff   bool
+	diffDialog dialog.Model
+}
+
+var (
+	createdStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	deletedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	stateStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+)
+
+func New(stream *eventstream.Stream) Model {
+	columns := []table.Column{
+		{Title: "Time", Width: 15},
+		{Title: "Kind", Width: 16},
+		{Title: "Name", Width: 35},
+		{Title: "Change", Width: 80},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(20),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	t.SetStyles(s)
+
+	return Model{
+		stream:     stream,
+		table:      t,
+		keys:       keys.DefaultKeyMap(),
+		diffDialog: dialog.New(),
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadEvents
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if loaded, ok := msg.(eventsLoadedMsg); ok {
+		m.events = loaded.events
+		m.updateTable()
+		return m, nil
+	}
+
+	// Handle diff view
+	if m.showDiff {
+		if msg, ok := msg.(tea.KeyMsg); ok && key.Matches(msg, m.keys.Back) {
+			m.showDiff = false
+			return m, nil
+		}
+
+		newDialog, cmd := m.diffDialog.Update(msg)
+		m.diffDialog = newDialog
+		return m, cmd
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.Enter) || key.Matches(msg, m.keys.Describe):
+			if event, ok := m.selectedEvent(); ok {
+				m.diffDialog.SetTitle(fmt.Sprintf("%s %s: %s (ESC to close)", event.Kind, event.Name, event.Summary()))
+				m.diffDialog.SetContent(Diff(event))
+				m.showDiff = true
+				return m, nil
+			}
+
+		case key.Matches(msg, m.keys.Refresh):
+			return m, m.loadEvents
+		}
+	}
+
+	newTable, cmd := m.table.Update(msg)
+	m.table = newTable
+
+	return m, cmd
+}
+
+func (m Model) View() string {
+	if m.showDiff {
+		return m.diffDialog.View()
+	}
+
+	if len(m.events) == 0 {
+		return "No changes observed yet"
+	}
+
+	return m.table.View()
+}
+
+// CapturingInput reports whether the view is receiving keystrokes; the
+// event list never is.
+func (m Model) CapturingInput() bool {
+	return false
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.table.SetHeight(height - 2)
+	m.diffDialog.SetSize(width, height)
+}
+
+func (m *Model) updateTable() {
+	rows := make([]table.Row, 0, len(m.events))
+
+	for _, event := range m.events {
+		change := event.Summary()
+		switch event.Type {
+		case eventstream.Created:
+			change = createdStyle.Render(change)
+		case eventstream.Deleted:
+			change = deletedStyle.Render(change)
+		case eventstream.StateChanged:
+			change = stateStyle.Render(change)
+		case eventstream.Changed:
+		}
+
+		rows = append(rows, table.Row{
+			event.Time.Local().Format("01-02 15:04:05"),
+			event.Kind,
+			event.Name,
+			change,
+		})
+	}
+
+	m.table.SetRows(rows)
+}
+
+func (m Model) selectedEvent() (eventstream.Event, bool) {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.events) {
+		return eventstream.Event{}, false
+	}
+	return m.events[cursor], true
+}
+
+// Diff renders the change of an event as a unified diff of the manifests
+// of the resource before and after it.
+func Diff(event eventstream.Event) string {
+	diff := utils.DiffLines(lines(event.Before), lines(event.After))
+	return diffview.Unified(diff, "before", "after")
+}
+
+func lines(manifest string) []string {
+	manifest = strings.TrimSuffix(manifest, "\n")
+	if manifest == "" {
+		return nil
+	}
+	return strings.Split(manifest, "\n")
+}
+
+type eventsLoadedMsg struct {
+	events []eventstream.Event
+}
+
+// loadEvents reads the stream, newest event first.
+func (m Model) loadEvents() tea.Msg {
+	events := m.stream.Events()
+	slices.Reverse(events)
+	return eventsLoadedMsg{events: events}
+}
@@ -0,0 +1,198 @@
+package logs
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/eventlog"
+	"github.com/smart-fellas/k4a/internal/ui/components/dialog"
+	"github.com/smart-fellas/k4a/internal/ui/keys"
+)
+
+// Model lists the events of the session, newest first: every kafkactl run
+// and the outcome of every action.
+type Model struct {
+	log     *eventlog.Log
+	table   table.Model
+	entries []eventlog.Entry
+	keys    keys.KeyMap
+	width   int
+	height  int
+
+	// Detail view
+	showDetail   bool
+	detailDialog dialog.Model
+}
+
+var errorStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("196"))
+
+func New(log *eventlog.Log) Model {
+	columns := []table.Column{
+		{Title: "Time", Width: 10},
+		{Title: "Level", Width: 7},
+		{Title: "Message", Width: 100},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(20),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	t.SetStyles(s)
+
+	detail := dialog.New()
+	detail.SetTitle("Event (ESC to close)")
+
+	return Model{
+		log:          log,
+		table:        t,
+		keys:         keys.DefaultKeyMap(),
+		detailDialog: detail,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadEntries
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Handle detail view
+	if m.showDetail {
+		if msg, ok := msg.(tea.KeyMsg); ok && key.Matches(msg, m.keys.Back) {
+			m.showDetail = false
+			return m, nil
+		}
+
+		newDialog, cmd := m.detailDialog.Update(msg)
+		m.detailDialog = newDialog
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Enter) || key.Matches(msg, m.keys.Describe):
+			if entry, ok := m.selectedEntry(); ok {
+				m.detailDialog.SetContent(Describe(entry))
+				m.showDetail = true
+				return m, nil
+			}
+
+		case key.Matches(msg, m.keys.Refresh):
+			return m, m.loadEntries
+		}
+
+	case entriesLoadedMsg:
+		m.entries = msg.entries
+		m.updateTable()
+	}
+
+	newTable, cmd := m.table.Update(msg)
+	m.table = newTable
+
+	return m, cmd
+}
+
+func (m Model) View() string {
+	if m.showDetail {
+		return m.detailDialog.View()
+	}
+
+	if len(m.entries) == 0 {
+		return "No events yet"
+	}
+
+	return m.table.View()
+}
+
+// CapturingInput reports whether the view is receiving keystrokes; the log
+// never is.
+func (m Model) CapturingInput() bool {
+	return false
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.table.SetHeight(height - 2)
+	m.detailDialog.SetSize(width, height)
+}
+
+func (m *Model) updateTable() {
+	rows := []table.Row{}
+
+	for _, entry := range m.entries {
+		level := entry.Level.String()
+		if entry.Level == eventlog.Error {
+			level = errorStyle.Render(level)
+		}
+
+		message, _, _ := strings.Cut(entry.Message, "\n")
+		rows = append(rows, table.Row{
+			entry.Time.Format("15:04:05"),
+			level,
+			message,
+		})
+	}
+
+	m.table.SetRows(rows)
+}
+
+func (m Model) selectedEntry() (eventlog.Entry, bool) {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.entries) {
+		return eventlog.Entry{}, false
+	}
+	return m.entries[cursor], true
+}
+
+// Describe renders an entry in full, with the arguments and stderr of a
+// kafkactl run.
+func Describe(entry eventlog.Entry) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Time:     %s\n", entry.Time.Format("2006-01-02 15:04:05.000"))
+	fmt.Fprintf(&b, "Level:    %s\n", entry.Level)
+	fmt.Fprintf(&b, "Message:  %s\n", entry.Message)
+
+	if entry.Args != nil {
+		fmt.Fprintf(&b, "Command:  kafkactl %s\n", strings.Join(entry.Args, " "))
+		fmt.Fprintf(&b, "Exit:     %d\n", entry.ExitCode)
+		fmt.Fprintf(&b, "Duration: %s\n", entry.Duration.Round(time.Millisecond))
+		if entry.Stderr != "" {
+			b.WriteString("\nStderr:\n")
+			b.WriteString(entry.Stderr)
+		}
+	}
+
+	return b.String()
+}
+
+type entriesLoadedMsg struct {
+	entries []eventlog.Entry
+}
+
+// loadEntries reads the log, newest entry first.
+func (m Model) loadEntries() tea.Msg {
+	entries := m.log.Entries()
+	slices.Reverse(entries)
+	return entriesLoadedMsg{entries: entries}
+}
@@ -0,0 +1,48 @@
+package resources
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/smart-fellas/k4a/internal/ui/components/columns"
+)
+
+// DefaultColumns are the columns of kinds without columns of their own.
+var DefaultColumns = []columns.Column{
+	{Title: "Name", Width: 40, Path: "metadata.name"},
+	{Title: "Namespace", Width: 25, Path: "metadata.namespace"},
+	{Title: "Created", Width: 30, Path: "metadata.creationTimestamp"},
+}
+
+// kindColumns are the columns of the kinds k4a has no view for, by
+// lowercase kind.
+var kindColumns = map[string][]columns.Column{
+	"namespace": {
+		{Title: "Name", Width: 30, Path: "metadata.name"},
+		{Title: "Cluster", Width: 20, Path: "metadata.cluster"},
+		{Title: "Kafka User", Width: 25, Path: "spec.kafkaUser"},
+		{Title: "Connect Clusters", Width: 40, Path: "spec.connectClusters"},
+	},
+	"rolebinding": {
+		{Title: "Name", Width: 30, Path: "metadata.name"},
+		{Title: "Subject", Width: 25, Path: "spec.subject.subjectName"},
+		{Title: "Resources", Width: 40, Path: "spec.role.resourceTypes"},
+		{Title: "Verbs", Width: 30, Path: "spec.role.verbs"},
+	},
+	"resourcequota": {
+		{Title: "Name", Width: 30, Path: "metadata.name"},
+		{Title: "Topics", Width: 10, Path: "spec.count/topics", Numeric: true},
+		{Title: "Partitions", Width: 12, Path: "spec.count/partitions", Numeric: true},
+		{Title: "Disk", Width: 12, Path: "spec.disk/topics"},
+		{Title: "Connectors", Width: 12, Path: "spec.count/connectors", Numeric: true},
+	},
+}
+
+// ColumnsFor returns the columns of a kind, e.g. RoleBinding. The name
+// comes first.
+func ColumnsFor(kind string) []columns.Column {
+	if specific, ok := kindColumns[strings.ToLower(kind)]; ok {
+		return slices.Clone(specific)
+	}
+	return slices.Clone(DefaultColumns)
+}
@@ -0,0 +1,321 @@
+// Package resources lists the resources of any kind the API serves, with
+// columns read from the manifests, so kinds k4a has no view of its own for
+// can still be browsed, described, filtered and sorted.
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/config"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/columns"
+	"github.com/smart-fellas/k4a/internal/ui/components/dialog"
+	"github.com/smart-fellas/k4a/internal/ui/components/filter"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+	"github.com/smart-fellas/k4a/internal/ui/components/loaderror"
+	"github.com/smart-fellas/k4a/internal/ui/components/sorter"
+	"github.com/smart-fellas/k4a/internal/ui/keys"
+	"github.com/smart-fellas/k4a/internal/utils"
+)
+
+type Model struct {
+	client   *kafkactl.Client
+	ctx      context.Context
+	resource kafkactl.APIResource
+	table    table.Model
+	items    []map[string]any
+	visible  []map[string]any
+	keys     keys.KeyMap
+	width    int
+	height   int
+	loading  bool
+	err      error
+
+	// Detail view
+	showDetail   bool
+	detailDialog dialog.Model
+
+	// Filter bar; visible holds the rows matching it
+	filter filter.Model
+
+	// Sort order of the visible rows, kept in the state
+	sort  sorter.Model
+	state *config.State
+
+	// Columns of the kind, and those shown: base with the configured
+	// columns
+	base    []columns.Column
+	columns []columns.Column
+}
+
+// New lists the resources of a kind with the given columns, the name
+// first.
+func New(client *kafkactl.Client, state *config.State, resource kafkactl.APIResource, base []columns.Column) Model {
+	t := table.New(
+		table.WithFocused(true),
+		table.WithHeight(20),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	t.SetStyles(s)
+
+	m := Model{
+		client:       client,
+		state:        state,
+		ctx:          context.Background(),
+		resource:     resource,
+		table:        t,
+		keys:         keys.DefaultKeyMap(),
+		loading:      true,
+		detailDialog: dialog.New(),
+		filter:       filter.New(),
+		base:         base,
+	}
+	m.SetColumns(nil, false)
+	return m
+}
+
+// SetColumns sets the configured columns of the view: added after the
+// columns of the kind, or replacing every one of them but the name.
+func (m *Model) SetColumns(extra []columns.Column, replace bool) {
+	m.columns = m.base
+	if replace {
+		m.columns = m.base[:min(1, len(m.base))]
+	}
+	m.columns = slices.Concat(m.columns, extra)
+
+	tableColumns := columns.Table(m.columns)
+	m.sort = sorter.New("resources/"+strings.ToLower(m.resource.Kind), m.state, tableColumns,
+		columns.Numeric(m.columns)...)
+	// The rows are reset first, as the table renders them with the new
+	// columns as soon as they are set
+	m.table.SetRows(nil)
+	m.table.SetColumns(m.sort.Columns())
+	m.applyFilter()
+}
+
+// Resource returns the kind the view lists.
+func (m Model) Resource() kafkactl.APIResource {
+	return m.resource
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadResources
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Handle detail view
+	if m.showDetail {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			if key.Matches(msg, m.keys.Back) {
+				m.showDetail = false
+				return m, nil
+			}
+		case resourceDetailMsg:
+			m.detailDialog.SetContent(msg.yaml)
+			return m, nil
+		}
+
+		newDialog, cmd := m.detailDialog.Update(msg)
+		m.detailDialog = newDialog
+		return m, cmd
+	}
+
+	// Handle filter bar
+	if _, ok := msg.(tea.KeyMsg); ok && m.filter.Editing() {
+		var cmd tea.Cmd
+		m.filter, cmd = m.filter.Update(msg)
+		m.applyFilter()
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if sorted, cmd, ok := m.sort.Update(msg); ok {
+			m.sort = sorted
+			m.table.SetColumns(m.sort.Columns())
+			m.applyFilter()
+			return m, cmd
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.Filter):
+			var cmd tea.Cmd
+			m.filter, cmd = m.filter.Start()
+			return m, cmd
+
+		case key.Matches(msg, m.keys.Back) && m.filter.Value() != "":
+			m.filter = m.filter.Clear()
+			m.applyFilter()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Describe) || key.Matches(msg, m.keys.Enter):
+			if name := m.selectedName(); name != "" {
+				m.detailDialog.SetTitle(fmt.Sprintf("%s %s (ESC to close)", m.resource.Kind, name))
+				m.detailDialog.SetContent("Loading...")
+				m.showDetail = true
+				return m, m.loadResourceDetail
+			}
+
+		case key.Matches(msg, m.keys.Refresh):
+			if m.err != nil {
+				m.err = nil
+				m.loading = true
+			}
+			return m, m.loadResources
+		}
+
+	case resourcesLoadedMsg:
+		if errors.Is(msg.err, context.Canceled) {
+			return m, nil
+		}
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, footer.Failure(fmt.Sprintf("Failed to load %s: %v", m.resource.ResourceType(), msg.err))
+		}
+		m.items = msg.items
+		m.applyFilter()
+	}
+
+	newTable, cmd := m.table.Update(msg)
+	m.table = newTable
+
+	return m, cmd
+}
+
+func (m Model) View() string {
+	if m.showDetail {
+		return m.detailDialog.View()
+	}
+
+	if m.loading {
+		return fmt.Sprintf("Loading %s...", m.resource.ResourceType())
+	}
+
+	if m.err != nil {
+		return loaderror.View(m.resource.ResourceType(), m.err)
+	}
+
+	if len(m.items) == 0 {
+		return fmt.Sprintf("No %s in the namespace", m.resource.ResourceType())
+	}
+
+	view := m.table.View()
+	if bar := m.filter.View(); bar != "" {
+		view += "\n" + bar
+	}
+
+	return view
+}
+
+// CapturingInput reports whether the filter bar is receiving keystrokes.
+func (m Model) CapturingInput() bool {
+	return m.filter.Editing()
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.table.SetHeight(height - 3)
+	m.detailDialog.SetSize(width, height)
+}
+
+// SetContext sets the context of the view's kafkactl runs. The app cancels
+// it when the view is left, abandoning loads still in flight.
+func (m *Model) SetContext(ctx context.Context) {
+	m.ctx = ctx
+}
+
+// FilterStatus returns the active filter expression with the number of
+// matching and total resources.
+func (m Model) FilterStatus() (string, int, int) {
+	return m.filter.Value(), len(m.visible), len(m.items)
+}
+
+// applyFilter recomputes the visible rows from the filter.
+func (m *Model) applyFilter() {
+	m.visible = filter.Apply(m.filter, m.items, func(doc map[string]any) map[string]any { return doc })
+	sorter.Sort(m.sort, m.visible, m.sortValue)
+	m.updateTable()
+	if rows := len(m.table.Rows()); m.table.Cursor() >= rows {
+		m.table.SetCursor(max(rows-1, 0))
+	}
+	// The cursor is -1 once no row matched, or before any loaded; it moves
+	// back to the first row as soon as there is one
+	if m.table.Cursor() < 0 && len(m.table.Rows()) > 0 {
+		m.table.SetCursor(0)
+	}
+}
+
+func (m *Model) updateTable() {
+	rows := make([]table.Row, 0, len(m.visible))
+
+	for _, doc := range m.visible {
+		rows = append(rows, columns.Values(m.columns, doc))
+	}
+
+	m.table.SetRows(rows)
+}
+
+// sortValue returns the value a resource is sorted by in a column, by
+// title.
+func (m Model) sortValue(doc map[string]any, title string) string {
+	column, _ := columns.Find(m.columns, title)
+	return column.SortValue(doc)
+}
+
+// selectedName returns the name of the resource under the cursor.
+func (m Model) selectedName() string {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.visible) {
+		return ""
+	}
+	return utils.ExtractString(m.visible[cursor], "metadata.name", "")
+}
+
+type resourcesLoadedMsg struct {
+	items []map[string]any
+	err   error
+}
+
+type resourceDetailMsg struct {
+	yaml string
+}
+
+func (m *Model) loadResources() tea.Msg {
+	items, err := m.client.GetResources(m.ctx, m.resource.ResourceType())
+	return resourcesLoadedMsg{items: items, err: err}
+}
+
+func (m *Model) loadResourceDetail() tea.Msg {
+	name := m.selectedName()
+	if name == "" {
+		return nil
+	}
+
+	yaml, err := m.client.GetResourceYAML(m.ctx, m.resource.ResourceType(), name)
+	if err != nil {
+		return resourceDetailMsg{yaml: fmt.Sprintf("Error loading %s details: %v", name, err)}
+	}
+
+	return resourceDetailMsg{yaml: yaml}
+}
@@ -0,0 +1,285 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/trash"
+	"github.com/smart-fellas/k4a/internal/ui/components/confirm"
+	"github.com/smart-fellas/k4a/internal/ui/components/dialog"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+	"github.com/smart-fellas/k4a/internal/ui/components/loaderror"
+	"github.com/smart-fellas/k4a/internal/ui/keys"
+)
+
+// Model lists the manifests saved to the trash by deletes and re-applies
+// them on request.
+type Model struct {
+	client  *kafkactl.Client
+	ctx     context.Context
+	table   table.Model
+	entries []trash.Entry
+	keys    keys.KeyMap
+	width   int
+	height  int
+	loading bool
+	err     error
+	status  string
+
+	// Detail view
+	showDetail   bool
+	detailDialog dialog.Model
+
+	// Restore confirmation, shown over the table
+	confirmRestore bool
+	restoreConfirm confirm.Model
+	restoring      trash.Entry
+}
+
+var statusStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("220"))
+
+func New(client *kafkactl.Client) Model {
+	columns := []table.Column{
+		{Title: "Deleted At", Width: 20},
+		{Title: "Context", Width: 20},
+		{Title: "Kind", Width: 15},
+		{Title: "Name", Width: 50},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(20),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	t.SetStyles(s)
+
+	detail := dialog.New()
+	detail.SetTitle("Trashed manifest (ESC to close)")
+
+	return Model{
+		client:       client,
+		ctx:          context.Background(),
+		table:        t,
+		keys:         keys.DefaultKeyMap(),
+		loading:      true,
+		detailDialog: detail,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return loadEntries
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	// Handle detail view
+	if m.showDetail {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			if key.Matches(msg, m.keys.Back) {
+				m.showDetail = false
+				return m, nil
+			}
+		}
+
+		newDialog, cmd := m.detailDialog.Update(msg)
+		m.detailDialog = newDialog
+		return m, cmd
+	}
+
+	// Handle restore confirmation
+	if m.confirmRestore {
+		var cmd tea.Cmd
+		m.restoreConfirm, cmd = m.restoreConfirm.Update(msg)
+		switch {
+		case m.restoreConfirm.Confirmed():
+			m.confirmRestore = false
+			m.status = statusStyle.Render(fmt.Sprintf("Restoring %s %s...", m.restoring.Kind, m.restoring.Name))
+			return m, m.restore(m.restoring)
+		case m.restoreConfirm.Cancelled():
+			m.confirmRestore = false
+		}
+		if _, ok := msg.(tea.KeyMsg); ok {
+			return m, cmd
+		}
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Enter):
+			entry, ok := m.selectedEntry()
+			if !ok {
+				return m, nil
+			}
+			if entry.Context != trash.ContextDir(m.client.ContextName()) {
+				return m, footer.Failure(fmt.Sprintf("%s was deleted from context %s; switch to it to restore", entry.Name, entry.Context))
+			}
+			m.restoring = entry
+			m.restoreConfirm = confirm.NewYesNo(
+				fmt.Sprintf("Restore %s %s", entry.Kind, entry.Name),
+				"The saved manifest is applied again and removed from the trash.",
+				m.client.ContextName(),
+				m.client.Namespace(),
+			)
+			m.restoreConfirm.SetSize(m.width, m.height)
+			m.confirmRestore = true
+			return m, nil
+
+		case key.Matches(msg, m.keys.Describe):
+			if entry, ok := m.selectedEntry(); ok {
+				content, err := os.ReadFile(entry.Path)
+				if err != nil {
+					m.detailDialog.SetContent(fmt.Sprintf("Error reading %s: %v", entry.Path, err))
+				} else {
+					m.detailDialog.SetContent(string(content))
+				}
+				m.showDetail = true
+				return m, nil
+			}
+
+		case key.Matches(msg, m.keys.Refresh):
+			if m.err != nil {
+				m.err = nil
+				m.loading = true
+			}
+			return m, loadEntries
+		}
+
+	case entriesLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, footer.Failure("Failed to load trash: " + msg.err.Error())
+		}
+		m.entries = msg.entries
+		m.updateTable()
+
+	case restoredMsg:
+		m.status = ""
+		if msg.err != nil {
+			return m, footer.Failure(fmt.Sprintf("Restore of %s failed: %v", msg.entry.Name, msg.err))
+		}
+		return m, tea.Batch(footer.Success(fmt.Sprintf("Restored %s %s", msg.entry.Kind, msg.entry.Name)), loadEntries)
+	}
+
+	newTable, cmd := m.table.Update(msg)
+	m.table = newTable
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) View() string {
+	if m.showDetail {
+		return m.detailDialog.View()
+	}
+
+	if m.loading {
+		return "Loading trash..."
+	}
+
+	if m.err != nil {
+		return loaderror.View("trash", m.err)
+	}
+
+	if len(m.entries) == 0 {
+		return "Trash is empty (" + trash.Dir() + ")"
+	}
+
+	view := m.table.View()
+	if m.status != "" {
+		view += "\n" + m.status
+	}
+
+	if m.confirmRestore {
+		return m.restoreConfirm.Over(view)
+	}
+
+	return view
+}
+
+// CapturingInput reports whether a restore is awaiting confirmation.
+func (m Model) CapturingInput() bool {
+	return m.confirmRestore
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.table.SetHeight(height - 2)
+	m.detailDialog.SetSize(width, height)
+	m.restoreConfirm.SetSize(width, height)
+}
+
+// SetContext sets the context of the view's kafkactl runs. The app cancels
+// it when the view is left, abandoning loads still in flight.
+func (m *Model) SetContext(ctx context.Context) {
+	m.ctx = ctx
+}
+
+func (m *Model) updateTable() {
+	rows := []table.Row{}
+
+	for _, entry := range m.entries {
+		rows = append(rows, table.Row{
+			entry.DeletedAt.Format("2006-01-02 15:04:05"),
+			entry.Context,
+			entry.Kind,
+			entry.Name,
+		})
+	}
+
+	m.table.SetRows(rows)
+}
+
+func (m Model) selectedEntry() (trash.Entry, bool) {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.entries) {
+		return trash.Entry{}, false
+	}
+	return m.entries[cursor], true
+}
+
+type entriesLoadedMsg struct {
+	entries []trash.Entry
+	err     error
+}
+
+type restoredMsg struct {
+	entry trash.Entry
+	err   error
+}
+
+func loadEntries() tea.Msg {
+	entries, err := trash.List()
+	return entriesLoadedMsg{entries: entries, err: err}
+}
+
+func (m *Model) restore(entry trash.Entry) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := m.client.ApplyFile(m.ctx, entry.Path); err != nil {
+			return restoredMsg{entry: entry, err: err}
+		}
+		return restoredMsg{entry: entry, err: trash.Remove(entry)}
+	}
+}
@@ -0,0 +1,269 @@
+package schemas
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/dialog"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+	"github.com/smart-fellas/k4a/internal/ui/components/form"
+	"github.com/smart-fellas/k4a/internal/ui/styles"
+	"github.com/smart-fellas/k4a/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	applyFieldFile    = "file"
+	applyFieldSubject = "subject"
+)
+
+type applyStage int
+
+const (
+	applyForm applyStage = iota
+	applyChecking
+	applyConfirm
+	applyApplying
+)
+
+// applyModel registers a new schema version from a local file. It checks
+// the schema against the latest registered version of the subject and
+// applies the Schema manifest once the user confirms.
+type applyModel struct {
+	client *kafkactl.Client
+	ctx    context.Context
+	width  int
+	height int
+
+	form form.Model
+
+	stage    applyStage
+	subject  string
+	manifest []byte
+	accepted bool
+	dialog   dialog.Model
+
+	closed  bool
+	applied bool
+}
+
+// newApplyModel opens the apply form with the file and subject filled in.
+// The subject defaults to the file name without its extension.
+func newApplyModel(ctx context.Context, client *kafkactl.Client, path, subject string) applyModel {
+	if subject == "" && path != "" {
+		subject = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	m := applyModel{
+		client: client,
+		ctx:    ctx,
+		form: form.New("Register a schema",
+			form.Field{Key: applyFieldFile, Label: "File", Placeholder: "path to an .avsc, .json or .proto file",
+				Default: path, Required: true},
+			form.Field{Key: applyFieldSubject, Label: "Subject", Placeholder: "subject, e.g. orders-value",
+				Default: subject, Required: true},
+		),
+		dialog: dialog.New(),
+	}
+	if path != "" {
+		m.form.Focus(applyFieldSubject)
+	}
+
+	return m
+}
+
+// Init starts the cursor of the form blinking.
+func (m applyModel) Init() tea.Cmd {
+	return m.form.Init()
+}
+
+// Closed reports whether the flow is over, applied or not.
+func (m applyModel) Closed() bool {
+	return m.closed
+}
+
+// Applied reports whether a new version was registered.
+func (m applyModel) Applied() bool {
+	return m.applied
+}
+
+// CapturingInput reports whether the form is receiving keystrokes.
+func (m applyModel) CapturingInput() bool {
+	return m.stage == applyForm
+}
+
+func (m applyModel) Update(msg tea.Msg) (applyModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case schemaCheckedMsg:
+		if m.stage != applyChecking {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.stage = applyForm
+			m.form.Resume()
+			m.form.SetError("", msg.err)
+			return m, nil
+		}
+		m.stage = applyConfirm
+		m.manifest = msg.manifest
+		m.accepted = msg.accepted
+
+		title := fmt.Sprintf("Register %s? (enter/y to apply, esc/n to cancel)", m.subject)
+		if !m.accepted {
+			title = fmt.Sprintf("Register %s although the check failed? (enter/y to apply, esc/n to cancel)", m.subject)
+		}
+		m.dialog.SetTitle(title)
+		m.dialog.SetContent(msg.report + "\n\n" + styles.MutedText.Render("Manifest:") + "\n" + string(msg.manifest))
+		m.dialog.SetSize(m.width, m.height)
+		return m, nil
+
+	case schemaAppliedMsg:
+		m.closed = true
+		if msg.err != nil {
+			return m, footer.Failure(fmt.Sprintf("Failed to register schema %s: %v", m.subject, msg.err))
+		}
+		m.applied = true
+		return m, footer.Success(fmt.Sprintf("Registered schema %s: %s", m.subject, strings.TrimSpace(msg.output)))
+
+	case tea.KeyMsg:
+		switch m.stage {
+		case applyForm:
+			return m.updateForm(msg)
+		case applyConfirm:
+			switch msg.String() {
+			case "enter", "y":
+				m.stage = applyApplying
+				return m, m.applySchema
+			case "esc", "n":
+				m.closed = true
+				return m, footer.Info("Schema not registered")
+			}
+		case applyChecking, applyApplying:
+			return m, nil
+		}
+	}
+
+	if m.stage == applyConfirm {
+		var cmd tea.Cmd
+		m.dialog, cmd = m.dialog.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m applyModel) updateForm(msg tea.KeyMsg) (applyModel, tea.Cmd) {
+	var cmd tea.Cmd
+	m.form, cmd = m.form.Update(msg)
+	switch {
+	case m.form.Cancelled():
+		m.closed = true
+	case m.form.Submitted():
+		values := m.form.Values()
+		m.subject = values.String(applyFieldSubject)
+		m.stage = applyChecking
+		return m, m.checkSchema(expandHome(values.String(applyFieldFile)), m.subject)
+	}
+	return m, cmd
+}
+
+func (m applyModel) View() string {
+	switch m.stage {
+	case applyChecking:
+		return "Checking " + m.subject + " against its latest version..."
+	case applyConfirm:
+		return m.dialog.View()
+	case applyApplying:
+		return "Registering " + m.subject + "..."
+	}
+
+	return m.form.View()
+}
+
+func (m *applyModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.dialog.SetSize(width, height)
+}
+
+type schemaCheckedMsg struct {
+	manifest []byte
+	report   string
+	accepted bool
+	err      error
+}
+
+type schemaAppliedMsg struct {
+	output string
+	err    error
+}
+
+// checkSchema reads the schema file and checks it against the latest
+// version of the subject, with the subject's compatibility level. A new
+// subject has nothing to be checked against.
+func (m applyModel) checkSchema(path, subject string) tea.Cmd {
+	return func() tea.Msg {
+		schema, err := kafkactl.SchemaFromFile(path, subject, m.client.Namespace())
+		if err != nil {
+			return schemaCheckedMsg{err: err}
+		}
+		manifest, err := yaml.Marshal(schema)
+		if err != nil {
+			return schemaCheckedMsg{err: err}
+		}
+
+		subjects, err := m.client.ListSchemas(m.ctx)
+		if err != nil {
+			return schemaCheckedMsg{err: fmt.Errorf("failed to load schemas: %w", err)}
+		}
+		var current *models.Schema
+		for i := range subjects {
+			if subjects[i].Metadata.Name == subject {
+				current = &subjects[i]
+			}
+		}
+		if current == nil {
+			report := acceptedStyle.Render(fmt.Sprintf("%s is a new subject; there is no registered version to check against", subject))
+			return schemaCheckedMsg{manifest: manifest, report: report, accepted: true}
+		}
+
+		versions, err := m.client.ListSchemaVersions(m.ctx, subject)
+		if err != nil {
+			return schemaCheckedMsg{err: fmt.Errorf("failed to load versions of %s: %w", subject, err)}
+		}
+		latest := versions[len(versions)-1]
+
+		if !strings.EqualFold(schemaTypeOf(latest), schemaTypeOf(schema)) {
+			report := rejectedStyle.Render(fmt.Sprintf("%s is a %s subject; the file is %s", subject, schemaTypeOf(latest), schemaTypeOf(schema)))
+			return schemaCheckedMsg{manifest: manifest, report: report}
+		}
+
+		level := current.Spec.Compatibility
+		if level == "" {
+			level = "BACKWARD"
+		}
+		report, accepted := evolutionReport(schema.Spec.SchemaType, latest.Spec.Schema, schema.Spec.Schema, level, latest.Spec.Version)
+		report = fmt.Sprintf("Checked against %s v%d\n\n%s", subject, latest.Spec.Version, report)
+
+		return schemaCheckedMsg{manifest: manifest, report: report, accepted: accepted}
+	}
+}
+
+func (m applyModel) applySchema() tea.Msg {
+	output, err := m.client.ApplyManifest(m.ctx, m.manifest)
+	return schemaAppliedMsg{output: output, err: err}
+}
+
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
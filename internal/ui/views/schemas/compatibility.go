@@ -0,0 +1,162 @@
+package schemas
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+	"github.com/smart-fellas/k4a/internal/ui/keys"
+	"github.com/smart-fellas/k4a/internal/ui/styles"
+)
+
+// compatibilityPicker sets the compatibility level of one or more
+// subjects.
+type compatibilityPicker struct {
+	client   *kafkactl.Client
+	ctx      context.Context
+	keys     keys.KeyMap
+	subjects []string
+	cursor   int
+	applying bool
+	closed   bool
+	changed  bool
+	width    int
+	height   int
+}
+
+// newCompatibilityPicker opens the picker on the current level of the
+// subjects, when they share one.
+func newCompatibilityPicker(ctx context.Context, client *kafkactl.Client, subjects []string, current string) compatibilityPicker {
+	return compatibilityPicker{
+		client:   client,
+		ctx:      ctx,
+		keys:     keys.DefaultKeyMap(),
+		subjects: subjects,
+		cursor:   max(slices.Index(kafkactl.CompatibilityLevels, current), 0),
+	}
+}
+
+// Closed reports whether the picker was cancelled or the levels were set.
+func (p compatibilityPicker) Closed() bool {
+	return p.closed
+}
+
+// CapturingInput reports whether the levels are being set. Their result
+// reaches only the current view, so it must not be left before it is back.
+func (p compatibilityPicker) CapturingInput() bool {
+	return p.applying
+}
+
+func (p *compatibilityPicker) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+// Changed reports whether the level of any subject was set.
+func (p compatibilityPicker) Changed() bool {
+	return p.changed
+}
+
+func (p compatibilityPicker) Update(msg tea.Msg) (compatibilityPicker, tea.Cmd) {
+	switch msg := msg.(type) {
+	case compatibilitySetMsg:
+		p.closed = true
+		p.changed = len(msg.failed) < len(p.subjects)
+		return p, compatibilityResult(msg.level, p.subjects, msg.failed)
+
+	case tea.KeyMsg:
+		if p.applying {
+			return p, nil
+		}
+		switch {
+		case key.Matches(msg, p.keys.Back):
+			p.closed = true
+		case key.Matches(msg, p.keys.Up):
+			p.cursor = max(p.cursor-1, 0)
+		case key.Matches(msg, p.keys.Down):
+			p.cursor = min(p.cursor+1, len(kafkactl.CompatibilityLevels)-1)
+		case key.Matches(msg, p.keys.Enter):
+			p.applying = true
+			return p, p.setCompatibility(kafkactl.CompatibilityLevels[p.cursor])
+		}
+	}
+
+	return p, nil
+}
+
+func (p compatibilityPicker) View() string {
+	level := kafkactl.CompatibilityLevels[p.cursor]
+	if p.applying {
+		return fmt.Sprintf("Setting %s on %s...", level, describeSubjects(p.subjects))
+	}
+
+	var b strings.Builder
+	b.WriteString(styles.Title.Render("Compatibility of " + describeSubjects(p.subjects) + " (enter to set, esc to cancel)"))
+	b.WriteString("\n\n")
+
+	for i, option := range kafkactl.CompatibilityLevels {
+		if i == p.cursor {
+			b.WriteString(styles.Title.Render("> "+option) + "\n")
+		} else {
+			b.WriteString("  " + option + "\n")
+		}
+	}
+
+	if len(p.subjects) > 1 {
+		b.WriteString("\n" + styles.MutedText.Width(p.width).Render(strings.Join(p.subjects, ", ")))
+	}
+
+	return b.String()
+}
+
+type compatibilitySetMsg struct {
+	level string
+	// Subjects whose level could not be set, with the error
+	failed map[string]error
+}
+
+// setCompatibility sets the level of every subject, one kafkactl run each
+// so that a failure is reported against its subject.
+func (p compatibilityPicker) setCompatibility(level string) tea.Cmd {
+	return func() tea.Msg {
+		failed := map[string]error{}
+		for _, subject := range p.subjects {
+			if err := p.client.SetSchemaCompatibility(p.ctx, subject, level); err != nil {
+				failed[subject] = err
+			}
+		}
+		return compatibilitySetMsg{level: level, failed: failed}
+	}
+}
+
+// compatibilityResult summarizes the subjects whose level was set and
+// those that failed.
+func compatibilityResult(level string, subjects []string, failed map[string]error) tea.Cmd {
+	if len(failed) == 0 {
+		return footer.Success(fmt.Sprintf("Set %s on %s", level, describeSubjects(subjects)))
+	}
+	if len(subjects) == 1 {
+		return footer.Failure(fmt.Sprintf("Failed to set %s on %s: %v", level, subjects[0], failed[subjects[0]]))
+	}
+
+	failures := []string{}
+	for _, subject := range subjects {
+		if err, ok := failed[subject]; ok {
+			failures = append(failures, fmt.Sprintf("%s (%v)", subject, err))
+		}
+	}
+	return footer.Failure(fmt.Sprintf("Set %s on %d of %d subjects; failed: %s",
+		level, len(subjects)-len(failed), len(subjects), strings.Join(failures, ", ")))
+}
+
+func describeSubjects(subjects []string) string {
+	if len(subjects) == 1 {
+		return subjects[0]
+	}
+	return fmt.Sprintf("%d subjects", len(subjects))
+}
@@ -0,0 +1,63 @@
+package schemas
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/evolution"
+	"github.com/smart-fellas/k4a/internal/ui/styles"
+)
+
+var (
+	acceptedStyle = lipgloss.NewStyle().Foreground(styles.Success)
+	rejectedStyle = lipgloss.NewStyle().Foreground(styles.Error)
+)
+
+// renderReport shows the evolution report of the older and newer versions
+// in the dialog.
+func (m *versionsModel) renderReport() {
+	m.dialog.SetTitle(fmt.Sprintf("v%d → v%d evolution (ESC to close)", m.older.Spec.Version, m.newer.Spec.Version))
+	report, _ := evolutionReport(m.older.Spec.SchemaType, m.older.Spec.Schema, m.newer.Spec.Schema, m.compatibility, m.older.Spec.Version)
+	m.dialog.SetContent(report)
+	m.dialog.SetSize(m.width, m.height)
+}
+
+// evolutionReport lists the changes between two schemas with the
+// compatibility each keeps, and reports whether the subject's compatibility
+// level accepts them.
+func evolutionReport(schemaType, older, newer, level string, olderVersion int) (string, bool) {
+	report, err := evolution.Analyze(schemaType, older, newer)
+	if err != nil {
+		return rejectedStyle.Render("Cannot analyze the schemas: " + err.Error()), false
+	}
+
+	var b strings.Builder
+
+	verdict := acceptedStyle.Render("accepted")
+	if !report.Accepts(level) {
+		verdict = rejectedStyle.Render("rejected")
+	}
+	fmt.Fprintf(&b, "Changes keep:           %s compatibility\n", report.Compatibility())
+	fmt.Fprintf(&b, "Subject compatibility:  %s, %s\n", level, verdict)
+	if strings.HasSuffix(strings.ToUpper(level), "_TRANSITIVE") {
+		b.WriteString(styles.MutedText.Render(fmt.Sprintf("Transitive levels also check older versions; only v%d was compared", olderVersion)) + "\n")
+	}
+	b.WriteString("\n")
+
+	if len(report.Changes) == 0 {
+		b.WriteString(styles.MutedText.Render("No schema changes"))
+		return b.String(), true
+	}
+
+	required := evolution.Required(level)
+	for _, change := range report.Changes {
+		style := acceptedStyle
+		if change.Compatibility&required != required {
+			style = rejectedStyle
+		}
+		fmt.Fprintf(&b, "%s  %s\n", style.Render(fmt.Sprintf("%-8s", change.Compatibility)), change)
+	}
+
+	return b.String(), report.Accepts(level)
+}
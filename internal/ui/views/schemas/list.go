@@ -1,21 +1,40 @@
 package schemas
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/config"
 	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/bulk"
+	"github.com/smart-fellas/k4a/internal/ui/components/changes"
+	"github.com/smart-fellas/k4a/internal/ui/components/columns"
+	"github.com/smart-fellas/k4a/internal/ui/components/deletion"
 	"github.com/smart-fellas/k4a/internal/ui/components/dialog"
+	"github.com/smart-fellas/k4a/internal/ui/components/editor"
+	"github.com/smart-fellas/k4a/internal/ui/components/filter"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+	"github.com/smart-fellas/k4a/internal/ui/components/loaderror"
+	"github.com/smart-fellas/k4a/internal/ui/components/selection"
+	"github.com/smart-fellas/k4a/internal/ui/components/sorter"
 	"github.com/smart-fellas/k4a/internal/ui/keys"
+	"github.com/smart-fellas/k4a/pkg/models"
 )
 
 type Model struct {
 	client  *kafkactl.Client
+	ctx     context.Context
 	table   table.Model
-	schemas []map[string]any
+	schemas []models.Schema
+	visible []models.Schema
 	keys    keys.KeyMap
 	width   int
 	height  int
@@ -25,19 +44,61 @@ type Model struct {
 	// Detail view
 	showDetail   bool
 	detailDialog dialog.Model
+
+	// Edit round trip and delete workflows
+	editor  editor.Model
+	deleter deletion.Model
+
+	// Filter bar; visible holds the rows matching it
+	filter filter.Model
+
+	// Sort order of the visible rows, kept in the state
+	sort  sorter.Model
+	state *config.State
+
+	// Columns configured for the view: added after the built-in columns,
+	// or with replaceColumns, in place of all of them but the name
+	extra          []columns.Column
+	replaceColumns bool
+
+	// Version history of the selected subject
+	showVersions bool
+	versions     versionsModel
+
+	// Registration of a schema from a local file
+	showApply bool
+	apply     applyModel
+
+	// Subjects marked with space, and the bulk action run on them
+	marks selection.Model
+	bulk  bulk.Model
+
+	// Changes found by the last refreshes; removed holds the removed
+	// subjects matching the filter, listed while they are highlighted
+	changes changes.Tracker
+	removed []models.Schema
+
+	// Compatibility level picker
+	showCompatibility bool
+	compatibility     compatibilityPicker
 }
 
-func New(client *kafkactl.Client) Model {
-	columns := []table.Column{
-		{Title: "Subject", Width: 50},
-		{Title: "Version", Width: 10},
-		{Title: "ID", Width: 10},
-		{Title: "Type", Width: 15},
-		{Title: "Compatibility", Width: 20},
-	}
+// builtinColumns are the columns of the view before any configured ones;
+// configured columns replace all of them but the marker and the name.
+var builtinColumns = []table.Column{
+	{Title: "", Width: 2},
+	{Title: "Subject", Width: 50},
+	{Title: "Version", Width: 10},
+	{Title: "ID", Width: 10},
+	{Title: "Type", Width: 15},
+	{Title: "Compatibility", Width: 20},
+}
+
+// builtinNumeric are the built-in columns sorted as numbers.
+var builtinNumeric = []string{"Version", "ID"}
 
+func New(client *kafkactl.Client, state *config.State) Model {
 	t := table.New(
-		table.WithColumns(columns),
 		table.WithFocused(true),
 		table.WithHeight(20),
 	)
@@ -54,12 +115,22 @@ func New(client *kafkactl.Client) Model {
 		Bold(false)
 	t.SetStyles(s)
 
-	return Model{
+	m := Model{
 		client:       client,
+		state:        state,
+		ctx:          context.Background(),
 		table:        t,
 		keys:         keys.DefaultKeyMap(),
+		loading:      true,
 		detailDialog: dialog.New(),
+		editor:       editor.New(client),
+		deleter:      deletion.New(client),
+		filter:       filter.New(),
+		marks:        selection.New(),
+		bulk:         bulk.New(client),
 	}
+	m.SetColumns(nil, false)
+	return m
 }
 
 func (m Model) Init() tea.Cmd {
@@ -69,6 +140,52 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	// Snapshots are applied whatever is open, so that the list is current
+	// once it is closed, and keeps loading behind :schema apply
+	switch msg := msg.(type) {
+	case schemasLoadedMsg:
+		return m.loaded(msg)
+	case changes.ExpiredMsg:
+		m.applyFilter()
+		return m, nil
+	}
+
+	// Handle edit round trip
+	if m.editor.Active() {
+		newEditor, cmd := m.editor.Update(msg)
+		m.editor = newEditor
+		if !m.editor.Active() {
+			if m.editor.Applied() {
+				return m, tea.Batch(cmd, m.loadSchemas)
+			}
+		}
+		return m, cmd
+	}
+
+	// Handle delete confirmation
+	if m.deleter.Active() {
+		newDeleter, cmd := m.deleter.Update(msg)
+		m.deleter = newDeleter
+		if !m.deleter.Active() {
+			if m.deleter.Deleted() {
+				return m, tea.Batch(cmd, m.loadSchemas)
+			}
+		}
+		return m, cmd
+	}
+
+	// Handle bulk action
+	if m.bulk.Active() {
+		var cmd tea.Cmd
+		m.bulk, cmd = m.bulk.Update(msg)
+		// Marks are for one action
+		if m.bulk.Finished() && m.marks.Len() > 0 {
+			m.marks = selection.New()
+			m.updateTable()
+		}
+		return m, cmd
+	}
+
 	// Handle detail view
 	if m.showDetail {
 		switch msg := msg.(type) {
@@ -77,6 +194,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.showDetail = false
 				return m, nil
 			}
+		case schemaDetailMsg:
+			m.detailDialog.SetContent(msg.yaml)
+			return m, nil
 		}
 
 		newDialog, cmd := m.detailDialog.Update(msg)
@@ -84,24 +204,147 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	// Handle version history
+	if m.showVersions {
+		newVersions, cmd := m.versions.Update(msg)
+		m.versions = newVersions
+		if m.versions.Closed() {
+			m.showVersions = false
+		}
+		return m, cmd
+	}
+
+	// Handle schema registration
+	if m.showApply {
+		newApply, cmd := m.apply.Update(msg)
+		m.apply = newApply
+		if m.apply.Closed() {
+			m.showApply = false
+			if m.apply.Applied() {
+				return m, tea.Batch(cmd, m.loadSchemas)
+			}
+		}
+		return m, cmd
+	}
+
+	// Handle compatibility level picker
+	if m.showCompatibility {
+		newPicker, cmd := m.compatibility.Update(msg)
+		m.compatibility = newPicker
+		if m.compatibility.Closed() {
+			m.showCompatibility = false
+			if m.compatibility.Changed() {
+				m.marks = selection.New()
+				return m, tea.Batch(cmd, m.loadSchemas)
+			}
+		}
+		return m, cmd
+	}
+
+	// Handle filter bar
+	if _, ok := msg.(tea.KeyMsg); ok && m.filter.Editing() {
+		var cmd tea.Cmd
+		m.filter, cmd = m.filter.Update(msg)
+		m.applyFilter()
+		return m, cmd
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if sorted, cmd, ok := m.sort.Update(msg); ok {
+			m.sort = sorted
+			m.table.SetColumns(m.sort.Columns())
+			m.applyFilter()
+			return m, cmd
+		}
+
 		switch {
+		case key.Matches(msg, m.keys.Filter):
+			var cmd tea.Cmd
+			m.filter, cmd = m.filter.Start()
+			return m, cmd
+
+		case key.Matches(msg, m.keys.Back) && m.filter.Value() != "":
+			m.filter = m.filter.Clear()
+			m.applyFilter()
+			return m, nil
+
+		case m.marks.Handles(msg):
+			current := ""
+			if schema, ok := m.selectedSchema(); ok {
+				current = schema.Metadata.Name
+			}
+			m.marks = m.marks.Update(msg, current, schemaNames(m.visible))
+			m.updateTable()
+			return m, nil
+
+		case msg.String() == "C":
+			// Set the compatibility level of the selected subjects, or of
+			// the subject under the cursor
+			subjects, current := m.compatibilityTargets()
+			if len(subjects) > 0 {
+				m.compatibility = newCompatibilityPicker(m.ctx, m.client, subjects, current)
+				m.compatibility.SetSize(m.width, m.height)
+				m.showCompatibility = true
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Enter):
+			// Browse the versions registered for the subject
+			if schema, ok := m.selectedSchema(); ok {
+				m.versions = newVersionsModel(m.ctx, m.client, schema.Metadata.Name, compatibilityOf(schema))
+				m.versions.SetSize(m.width, m.height)
+				m.showVersions = true
+				return m, m.versions.Init()
+			}
+
+		case msg.String() == "a":
+			// Register a new version of the selected subject, or a new
+			// subject, from a local file
+			subject := ""
+			if schema, ok := m.selectedSchema(); ok {
+				subject = schema.Metadata.Name
+			}
+			cmd := m.StartApply("", subject)
+			return m, cmd
+
+		case key.Matches(msg, m.keys.Describe) && m.marks.Len() > 0:
+			return m.startBulk(bulk.Describe(m.client, "schema"))
+
+		case key.Matches(msg, m.keys.Delete) && m.marks.Len() > 0:
+			return m.startBulk(bulk.Delete(m.client, "schema", m.reload))
+
+		case msg.String() == "X":
+			// Export the marked subjects, or the subject under the cursor
+			return m.startBulk(bulk.Export(m.client, "schema"))
+
 		case key.Matches(msg, m.keys.Describe):
-			if len(m.schemas) > 0 {
+			if len(m.visible) > 0 {
 				m.showDetail = true
 				return m, m.loadSchemaDetail
 			}
 
+		case key.Matches(msg, m.keys.Edit):
+			if schema, ok := m.selectedSchema(); ok {
+				var cmd tea.Cmd
+				m.editor, cmd = m.editor.Start(m.ctx, "schema", schema.Metadata.Name)
+				return m, cmd
+			}
+
+		case key.Matches(msg, m.keys.Delete):
+			if schema, ok := m.selectedSchema(); ok {
+				m.deleter = m.deleter.Start(m.ctx, "schema", schema.Metadata.Name)
+				return m, nil
+			}
+
 		case key.Matches(msg, m.keys.Refresh):
+			if m.err != nil {
+				m.err = nil
+				m.loading = true
+			}
 			return m, m.loadSchemas
 		}
 
-	case schemasLoadedMsg:
-		m.schemas = msg.schemas
-		m.loading = false
-		m.updateTable()
-
 	case schemaDetailMsg:
 		m.detailDialog.SetContent(msg.yaml)
 		m.showDetail = true
@@ -115,75 +358,319 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) View() string {
+	if m.editor.Active() {
+		return m.editor.View()
+	}
+
+	if m.deleter.Active() {
+		return m.deleter.View()
+	}
+
+	if m.bulk.Active() {
+		return m.bulk.View()
+	}
+
 	if m.showDetail {
 		return m.detailDialog.View()
 	}
 
+	if m.showVersions {
+		return m.versions.View()
+	}
+
+	if m.showApply {
+		return m.apply.View()
+	}
+
+	if m.showCompatibility {
+		return m.compatibility.View()
+	}
+
 	if m.loading {
 		return "Loading schemas..."
 	}
 
 	if m.err != nil {
-		return fmt.Sprintf("Error: %v", m.err)
+		return loaderror.View("schemas", m.err)
+	}
+
+	view := m.table.View()
+	if bar := m.filter.View(); bar != "" {
+		view += "\n" + bar
 	}
 
-	return m.table.View()
+	return view
+}
+
+// CapturingInput reports whether an edit, a delete confirmation, the
+// schema registration form or the filter bar is receiving keystrokes, or
+// compatibility levels are being set.
+func (m Model) CapturingInput() bool {
+	return m.editor.Active() || m.deleter.Active() || m.bulk.CapturingInput() || m.filter.Editing() ||
+		(m.showApply && m.apply.CapturingInput()) || (m.showCompatibility && m.compatibility.CapturingInput())
+}
+
+// StartApply opens the registration of a schema from a local file. An
+// empty subject defaults to the file name without its extension.
+func (m *Model) StartApply(path, subject string) tea.Cmd {
+	m.showDetail = false
+	m.showVersions = false
+	m.apply = newApplyModel(m.ctx, m.client, path, subject)
+	m.apply.SetSize(m.width, m.height)
+	m.showApply = true
+	return m.apply.Init()
 }
 
 func (m *Model) SetSize(width, height int) {
 	m.width = width
 	m.height = height
-	m.table.SetHeight(height - 2)
+	m.table.SetHeight(height - 3)
+	m.detailDialog.SetSize(width, height)
+	m.editor.SetSize(width, height)
+	m.deleter.SetSize(width, height)
+	m.bulk.SetSize(width, height)
+	m.versions.SetSize(width, height)
+	m.apply.SetSize(width, height)
+	m.compatibility.SetSize(width, height)
+}
+
+// SetContext sets the context of the view's kafkactl runs. The app cancels
+// it when the view is left, abandoning loads still in flight.
+func (m *Model) SetContext(ctx context.Context) {
+	m.ctx = ctx
+}
+
+// SetColumns sets the configured columns of the view: added after the
+// built-in columns, or with replace, in place of all of them but the name.
+func (m *Model) SetColumns(extra []columns.Column, replace bool) {
+	m.extra, m.replaceColumns = extra, replace
+
+	builtin, numeric := builtinColumns, builtinNumeric
+	if replace {
+		builtin, numeric = builtinColumns[:2], nil
+	}
+	tableColumns := slices.Concat(builtin, columns.Table(extra))
+	m.sort = sorter.New("schemas", m.state, tableColumns, slices.Concat(numeric, columns.Numeric(extra))...)
+	// The rows are reset first, as the table renders them with the new
+	// columns as soon as they are set
+	m.table.SetRows(nil)
+	m.table.SetColumns(m.sort.Columns())
+	m.applyFilter()
+}
+
+// withColumns applies the configured columns to a row of built-in values.
+func (m Model) withColumns(row table.Row, doc map[string]any) table.Row {
+	if m.replaceColumns {
+		row = row[:2]
+	}
+	return append(row, columns.Values(m.extra, doc)...)
+}
+
+// AutoRefresh reloads the subjects, unless a dialog, form or workflow is
+// open or the filter is being edited.
+func (m Model) AutoRefresh() tea.Cmd {
+	if m.loading || m.editor.Active() || m.deleter.Active() || m.bulk.Active() || m.showDetail ||
+		m.showVersions || m.showApply || m.showCompatibility || m.filter.Editing() {
+		return nil
+	}
+	return m.loadSchemas
+}
+
+// FilterStatus returns the active filter expression with the number of
+// matching and total schemas.
+func (m Model) FilterStatus() (string, int, int) {
+	return m.filter.Value(), len(m.visible), len(m.schemas)
+}
+
+// applyFilter recomputes the visible rows from the filter.
+func (m *Model) applyFilter() {
+	m.visible = filter.Apply(m.filter, m.schemas, func(s models.Schema) map[string]any { return s.Raw })
+	sorter.Sort(m.sort, m.visible, m.sortValue)
+	removed, _ := models.DecodeList[models.Schema](m.changes.Removed(time.Now()))
+	m.removed = filter.Apply(m.filter, removed, func(s models.Schema) map[string]any { return s.Raw })
+	m.updateTable()
+	if rows := len(m.table.Rows()); m.table.Cursor() >= rows {
+		m.table.SetCursor(max(rows-1, 0))
+	}
+	// The cursor is -1 once no row matched, or before any loaded; it moves
+	// back to the first row as soon as there is one
+	if m.table.Cursor() < 0 && len(m.table.Rows()) > 0 {
+		m.table.SetCursor(0)
+	}
 }
 
 func (m *Model) updateTable() {
 	rows := []table.Row{}
+	now := time.Now()
+	subjectWidth := m.table.Columns()[1].Width
 
-	for _, schema := range m.schemas {
-		metadata, ok := schema["metadata"].(map[string]any)
-		if !ok {
-			continue
-		}
-
-		subject, ok := metadata["name"].(string)
-		if !ok {
-			continue
+	for _, schema := range slices.Concat(m.visible, m.removed) {
+		version := "latest"
+		if schema.Spec.Version > 0 {
+			version = strconv.Itoa(schema.Spec.Version)
 		}
 
-		version := "latest"
 		id := "-"
-		schemaType := "AVRO"
-		compatibility := "BACKWARD"
+		if schema.Spec.ID > 0 {
+			id = strconv.Itoa(schema.Spec.ID)
+		}
 
-		if spec, specOk := schema["spec"].(map[string]any); specOk {
-			if v, versionOk := spec["version"]; versionOk {
-				version = fmt.Sprintf("%v", v)
-			}
-			if i, idOk := spec["id"]; idOk {
-				id = fmt.Sprintf("%v", i)
-			}
-			if t, typeOk := spec["type"]; typeOk {
-				schemaType = fmt.Sprintf("%v", t)
-			}
-			if c, compatOk := spec["compatibility"]; compatOk {
-				compatibility = fmt.Sprintf("%v", c)
-			}
+		marker := m.marks.Marker(schema.Metadata.Name)
+		if marker == "" {
+			marker = m.changes.Symbol(schema.Metadata.Name, now)
 		}
 
-		rows = append(rows, table.Row{
-			subject,
+		rows = append(rows, m.withColumns(table.Row{
+			marker,
+			m.changes.Render(schema.Metadata.Name, schema.Metadata.Name, subjectWidth, now),
 			version,
 			id,
-			schemaType,
-			compatibility,
-		})
+			schemaTypeOf(schema),
+			compatibilityOf(schema),
+		}, schema.Raw))
 	}
 
 	m.table.SetRows(rows)
 }
 
+// sortValue returns the value a subject is sorted by in a column, configured
+// or built-in.
+func (m Model) sortValue(schema models.Schema, column string) string {
+	if configured, ok := columns.Find(m.extra, column); ok {
+		return configured.SortValue(schema.Raw)
+	}
+	return schemaSortValue(schema, column)
+}
+
+// schemaSortValue returns the value a subject is sorted by in a column.
+func schemaSortValue(schema models.Schema, column string) string {
+	switch column {
+	case "Version":
+		return strconv.Itoa(schema.Spec.Version)
+	case "ID":
+		return strconv.Itoa(schema.Spec.ID)
+	case "Type":
+		return schemaTypeOf(schema)
+	case "Compatibility":
+		return compatibilityOf(schema)
+	}
+	return schema.Metadata.Name
+}
+
+// compatibilityOf returns the compatibility level of a subject; the Schema
+// Registry default, BACKWARD, when none is set.
+func compatibilityOf(schema models.Schema) string {
+	if schema.Spec.Compatibility == "" {
+		return "BACKWARD"
+	}
+	return schema.Spec.Compatibility
+}
+
+// selectedSchema returns the schema under the cursor.
+func (m Model) selectedSchema() (models.Schema, bool) {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.visible) {
+		return models.Schema{}, false
+	}
+	return m.visible[cursor], true
+}
+
+// startBulk runs action on the marked subjects the filter shows, or on the
+// subject under the cursor when none are marked. When every marked
+// subject is filtered out, nothing is run.
+func (m Model) startBulk(action bulk.Action) (Model, tea.Cmd) {
+	subjects := m.marks.Names(schemaNames(m.visible))
+	if len(subjects) == 0 && m.marks.Len() > 0 {
+		return m, footer.Info("No visible marked rows")
+	}
+	if len(subjects) == 0 {
+		schema, ok := m.selectedSchema()
+		if !ok {
+			return m, nil
+		}
+		subjects = []string{schema.Metadata.Name}
+	}
+
+	var cmd tea.Cmd
+	m.bulk, cmd = m.bulk.Start(m.ctx, action, subjects)
+	return m, cmd
+}
+
+// reload reloads the subjects once a bulk action is done.
+func (m Model) reload([]kafkactl.BulkResult) tea.Cmd {
+	return m.loadSchemas
+}
+
+func schemaNames(schemas []models.Schema) []string {
+	names := make([]string, len(schemas))
+	for i, schema := range schemas {
+		names[i] = schema.Metadata.Name
+	}
+	return names
+}
+
+// compatibilityTargets returns the subjects marked with space that the
+// filter shows, or the subject under the cursor, and their level when they
+// all share one.
+func (m Model) compatibilityTargets() ([]string, string) {
+	var subjects, levels []string
+	for _, schema := range m.visible {
+		if m.marks.Has(schema.Metadata.Name) {
+			subjects = append(subjects, schema.Metadata.Name)
+			levels = append(levels, compatibilityOf(schema))
+		}
+	}
+	if len(subjects) == 0 {
+		schema, ok := m.selectedSchema()
+		if !ok {
+			return nil, ""
+		}
+		return []string{schema.Metadata.Name}, compatibilityOf(schema)
+	}
+
+	for _, level := range levels {
+		if level != levels[0] {
+			return subjects, ""
+		}
+	}
+	return subjects, levels[0]
+}
+
+// schemaTypeOf returns the type of a schema; Avro when none is set.
+func schemaTypeOf(schema models.Schema) string {
+	if schema.Spec.SchemaType == "" {
+		return "AVRO"
+	}
+	return schema.Spec.SchemaType
+}
+
+// loaded applies a snapshot of the subjects, highlighting what changed
+// since the previous one.
+func (m Model) loaded(msg schemasLoadedMsg) (tea.Model, tea.Cmd) {
+	if errors.Is(msg.err, context.Canceled) {
+		return m, nil
+	}
+	m.loading = false
+	m.err = nil
+	if msg.err != nil && !errors.Is(msg.err, models.ErrDecode) {
+		m.err = msg.err
+		return m, footer.Failure("Failed to load schemas: " + msg.err.Error())
+	}
+
+	var cmd tea.Cmd
+	m.changes, cmd = m.changes.Update(changes.Snapshot(msg.schemas, func(s models.Schema) (string, map[string]any) {
+		return s.Metadata.Name, s.Raw
+	}), time.Now())
+	m.schemas = msg.schemas
+	m.applyFilter()
+	if msg.err != nil {
+		cmd = tea.Batch(cmd, footer.Failure("Some schemas could not be read: "+msg.err.Error()))
+	}
+	return m, cmd
+}
+
 type schemasLoadedMsg struct {
-	schemas []map[string]any
+	schemas []models.Schema
 	err     error
 }
 
@@ -192,22 +679,22 @@ type schemaDetailMsg struct {
 }
 
 func (m *Model) loadSchemas() tea.Msg {
-	schemas, err := m.client.GetSchemas()
+	schemas, err := m.client.ListSchemas(m.ctx)
 	return schemasLoadedMsg{schemas: schemas, err: err}
 }
 
 func (m *Model) loadSchemaDetail() tea.Msg {
-	if len(m.schemas) == 0 {
+	if len(m.visible) == 0 {
 		return nil
 	}
 
-	selectedRow := m.table.SelectedRow()
-	if len(selectedRow) == 0 {
+	schema, ok := m.selectedSchema()
+	if !ok {
 		return nil
 	}
 
-	schemaName := selectedRow[0]
-	yaml, err := m.client.GetResourceYAML("schema", schemaName)
+	schemaName := schema.Metadata.Name
+	yaml, err := m.client.GetResourceYAML(m.ctx, "schema", schemaName)
 	if err != nil {
 		return schemaDetailMsg{yaml: fmt.Sprintf("Error loading schema details: %v", err)}
 	}
@@ -0,0 +1,338 @@
+package schemas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/dialog"
+	"github.com/smart-fellas/k4a/internal/ui/components/diffview"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+	"github.com/smart-fellas/k4a/internal/ui/components/loaderror"
+	"github.com/smart-fellas/k4a/internal/ui/keys"
+	"github.com/smart-fellas/k4a/internal/ui/styles"
+	"github.com/smart-fellas/k4a/internal/utils"
+	"github.com/smart-fellas/k4a/pkg/models"
+)
+
+// versionsModel lists the registered versions of a subject and diffs any
+// two of them.
+type versionsModel struct {
+	client   *kafkactl.Client
+	ctx      context.Context
+	subject  string
+	versions []models.Schema
+	// Compatibility level configured for the subject
+	compatibility string
+	table         table.Model
+	keys          keys.KeyMap
+	width         int
+	height        int
+	loading       bool
+	err           error
+	closed        bool
+
+	// Versions marked for diffing, at most two
+	marked []int
+
+	// Schema, diff or evolution report dialog; older and newer are the
+	// compared versions
+	showDialog bool
+	dialog     dialog.Model
+	diffing    bool
+	sideBySide bool
+	older      models.Schema
+	newer      models.Schema
+}
+
+func newVersionsModel(ctx context.Context, client *kafkactl.Client, subject, compatibility string) versionsModel {
+	columns := []table.Column{
+		{Title: "", Width: 2},
+		{Title: "Version", Width: 10},
+		{Title: "ID", Width: 10},
+		{Title: "Type", Width: 12},
+		{Title: "Registered", Width: 12},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(20),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	t.SetStyles(s)
+
+	return versionsModel{
+		client:        client,
+		ctx:           ctx,
+		subject:       subject,
+		compatibility: compatibility,
+		table:         t,
+		keys:          keys.DefaultKeyMap(),
+		loading:       true,
+		dialog:        dialog.New(),
+	}
+}
+
+func (m versionsModel) Init() tea.Cmd {
+	return m.loadVersions
+}
+
+// Closed reports whether the user left the version history.
+func (m versionsModel) Closed() bool {
+	return m.closed
+}
+
+func (m versionsModel) Update(msg tea.Msg) (versionsModel, tea.Cmd) {
+	if m.showDialog {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			switch {
+			case key.Matches(msg, m.keys.Back):
+				m.showDialog = false
+				return m, nil
+			case msg.String() == "s" && m.diffing:
+				m.sideBySide = !m.sideBySide
+				m.renderDiff()
+				return m, nil
+			}
+		}
+
+		var cmd tea.Cmd
+		m.dialog, cmd = m.dialog.Update(msg)
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Back):
+			m.closed = true
+			return m, nil
+
+		case msg.String() == " ":
+			if version, ok := m.selectedVersion(); ok {
+				m.toggleMark(version.Spec.Version)
+				m.updateTable()
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Enter):
+			if cmd, ok := m.selectPair(); !ok {
+				return m, cmd
+			}
+			m.diffing = true
+			m.showDialog = true
+			m.renderDiff()
+			return m, nil
+
+		case msg.String() == "a":
+			if cmd, ok := m.selectPair(); !ok {
+				return m, cmd
+			}
+			m.diffing = false
+			m.showDialog = true
+			m.renderReport()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Describe):
+			if version, ok := m.selectedVersion(); ok {
+				m.dialog.SetTitle(fmt.Sprintf("%s v%d (ESC to close)", m.subject, version.Spec.Version))
+				m.dialog.SetContent(utils.PrettySchema(version.Spec.Schema))
+				m.dialog.SetSize(m.width, m.height)
+				m.diffing = false
+				m.showDialog = true
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Refresh):
+			if m.err != nil {
+				m.err = nil
+				m.loading = true
+			}
+			return m, m.loadVersions
+		}
+
+	case versionsLoadedMsg:
+		if msg.subject != m.subject || errors.Is(msg.err, context.Canceled) {
+			return m, nil
+		}
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, footer.Failure(fmt.Sprintf("Failed to load versions of %s: %v", m.subject, msg.err))
+		}
+		m.versions = msg.versions
+		m.marked = slices.DeleteFunc(m.marked, func(version int) bool {
+			_, ok := m.version(version)
+			return !ok
+		})
+		m.updateTable()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+func (m versionsModel) View() string {
+	if m.showDialog {
+		return m.dialog.View()
+	}
+
+	if m.loading {
+		return "Loading versions of " + m.subject + "..."
+	}
+
+	if m.err != nil {
+		return loaderror.View("schema versions", m.err)
+	}
+
+	hint := "space mark, enter diff marked (or with previous), a evolution report, d show schema, esc back"
+	return m.subject + "\n" + m.table.View() + "\n" + styles.MutedText.Render(hint)
+}
+
+func (m *versionsModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.table.SetHeight(height - 4)
+	m.dialog.SetSize(width, height)
+	if m.showDialog && m.diffing {
+		m.renderDiff()
+	}
+}
+
+func (m *versionsModel) updateTable() {
+	rows := []table.Row{}
+
+	for i, version := range m.versions {
+		marker := ""
+		if slices.Contains(m.marked, version.Spec.Version) {
+			marker = "●"
+		}
+
+		rows = append(rows, table.Row{
+			marker,
+			"v" + strconv.Itoa(version.Spec.Version),
+			strconv.Itoa(version.Spec.ID),
+			schemaTypeOf(version),
+			"#" + strconv.Itoa(i+1),
+		})
+	}
+
+	m.table.SetRows(rows)
+	if cursor := m.table.Cursor(); cursor >= len(rows) {
+		m.table.SetCursor(max(len(rows)-1, 0))
+	}
+}
+
+// toggleMark marks or unmarks a version. Marking a third version drops the
+// one marked first.
+func (m *versionsModel) toggleMark(version int) {
+	if i := slices.Index(m.marked, version); i >= 0 {
+		m.marked = slices.Delete(m.marked, i, i+1)
+		return
+	}
+	m.marked = append(m.marked, version)
+	if len(m.marked) > 2 {
+		m.marked = m.marked[1:]
+	}
+}
+
+// selectPair picks the versions to compare: the two marked versions, the
+// marked version and the selected one, or the selected version and the
+// version before it. It returns false, with a message to show if any,
+// when there is no pair to compare.
+func (m *versionsModel) selectPair() (tea.Cmd, bool) {
+	selected, ok := m.selectedVersion()
+	if !ok {
+		return nil, false
+	}
+
+	var a, b int
+	switch {
+	case len(m.marked) == 2:
+		a, b = m.marked[0], m.marked[1]
+	case len(m.marked) == 1 && m.marked[0] != selected.Spec.Version:
+		a, b = m.marked[0], selected.Spec.Version
+	default:
+		cursor := m.table.Cursor()
+		if cursor == 0 {
+			return footer.Info(fmt.Sprintf("v%d is the first version of %s", selected.Spec.Version, m.subject)), false
+		}
+		a, b = m.versions[cursor-1].Spec.Version, selected.Spec.Version
+	}
+
+	m.older, _ = m.version(min(a, b))
+	m.newer, _ = m.version(max(a, b))
+
+	return nil, true
+}
+
+// renderDiff renders the diff of the older and newer versions in the dialog in
+// the current layout.
+func (m *versionsModel) renderDiff() {
+	oldName := fmt.Sprintf("%s v%d", m.subject, m.older.Spec.Version)
+	newName := fmt.Sprintf("%s v%d", m.subject, m.newer.Spec.Version)
+	diff := utils.DiffLines(
+		strings.Split(utils.PrettySchema(m.older.Spec.Schema), "\n"),
+		strings.Split(utils.PrettySchema(m.newer.Spec.Schema), "\n"),
+	)
+
+	layout := "side-by-side"
+	content := diffview.Unified(diff, oldName, newName)
+	if m.sideBySide {
+		layout = "unified"
+		content = diffview.SideBySide(diff, oldName, newName, m.width-8)
+	}
+
+	m.dialog.SetTitle(fmt.Sprintf("v%d → v%d (s for %s, ESC to close)", m.older.Spec.Version, m.newer.Spec.Version, layout))
+	m.dialog.SetContent(content)
+	m.dialog.SetSize(m.width, m.height)
+}
+
+func (m versionsModel) selectedVersion() (models.Schema, bool) {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.versions) {
+		return models.Schema{}, false
+	}
+	return m.versions[cursor], true
+}
+
+func (m versionsModel) version(number int) (models.Schema, bool) {
+	for _, version := range m.versions {
+		if version.Spec.Version == number {
+			return version, true
+		}
+	}
+	return models.Schema{}, false
+}
+
+type versionsLoadedMsg struct {
+	subject  string
+	versions []models.Schema
+	err      error
+}
+
+func (m versionsModel) loadVersions() tea.Msg {
+	versions, err := m.client.ListSchemaVersions(m.ctx, m.subject)
+	return versionsLoadedMsg{subject: m.subject, versions: versions, err: err}
+}
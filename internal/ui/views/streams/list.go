@@ -0,0 +1,240 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/deletion"
+	"github.com/smart-fellas/k4a/internal/ui/components/dialog"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+	"github.com/smart-fellas/k4a/internal/ui/components/loaderror"
+	"github.com/smart-fellas/k4a/internal/ui/keys"
+	"github.com/smart-fellas/k4a/pkg/models"
+)
+
+type Model struct {
+	client  *kafkactl.Client
+	ctx     context.Context
+	table   table.Model
+	streams []models.KafkaStream
+	keys    keys.KeyMap
+	width   int
+	height  int
+	loading bool
+	err     error
+
+	// Detail view
+	showDetail   bool
+	detailDialog dialog.Model
+
+	// Delete workflow
+	deleter deletion.Model
+}
+
+func New(client *kafkactl.Client) Model {
+	columns := []table.Column{
+		{Title: "Name", Width: 50},
+		{Title: "Namespace", Width: 30},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(20),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	t.SetStyles(s)
+
+	return Model{
+		client:       client,
+		ctx:          context.Background(),
+		table:        t,
+		keys:         keys.DefaultKeyMap(),
+		loading:      true,
+		detailDialog: dialog.New(),
+		deleter:      deletion.New(client),
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadStreams
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	// Handle delete confirmation
+	if m.deleter.Active() {
+		newDeleter, cmd := m.deleter.Update(msg)
+		m.deleter = newDeleter
+		if !m.deleter.Active() {
+			if m.deleter.Deleted() {
+				return m, tea.Batch(cmd, m.loadStreams)
+			}
+		}
+		return m, cmd
+	}
+
+	// Handle detail view
+	if m.showDetail {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			if key.Matches(msg, m.keys.Back) {
+				m.showDetail = false
+				return m, nil
+			}
+		case streamDetailMsg:
+			m.detailDialog.SetContent(msg.yaml)
+			return m, nil
+		}
+
+		newDialog, cmd := m.detailDialog.Update(msg)
+		m.detailDialog = newDialog
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Describe):
+			if len(m.streams) > 0 {
+				m.showDetail = true
+				return m, m.loadStreamDetail
+			}
+
+		case key.Matches(msg, m.keys.Delete):
+			if row := m.table.SelectedRow(); len(row) > 0 {
+				m.deleter = m.deleter.Start(m.ctx, "stream", row[0])
+				return m, nil
+			}
+
+		case key.Matches(msg, m.keys.Refresh):
+			if m.err != nil {
+				m.err = nil
+				m.loading = true
+			}
+			return m, m.loadStreams
+		}
+
+	case streamsLoadedMsg:
+		if errors.Is(msg.err, context.Canceled) {
+			return m, nil
+		}
+		m.loading = false
+		m.err = nil
+		if msg.err != nil && !errors.Is(msg.err, models.ErrDecode) {
+			m.err = msg.err
+			return m, footer.Failure("Failed to load streams: " + msg.err.Error())
+		}
+		m.streams = msg.streams
+		m.updateTable()
+		if msg.err != nil {
+			return m, footer.Failure("Some streams could not be read: " + msg.err.Error())
+		}
+	}
+
+	newTable, cmd := m.table.Update(msg)
+	m.table = newTable
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) View() string {
+	if m.deleter.Active() {
+		return m.deleter.View()
+	}
+
+	if m.showDetail {
+		return m.detailDialog.View()
+	}
+
+	if m.loading {
+		return "Loading streams..."
+	}
+
+	if m.err != nil {
+		return loaderror.View("streams", m.err)
+	}
+
+	return m.table.View()
+}
+
+// CapturingInput reports whether the delete confirmation is receiving
+// keystrokes.
+func (m Model) CapturingInput() bool {
+	return m.deleter.Active()
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.table.SetHeight(height - 2)
+	m.detailDialog.SetSize(width, height)
+	m.deleter.SetSize(width, height)
+}
+
+// SetContext sets the context of the view's kafkactl runs. The app cancels
+// it when the view is left, abandoning loads still in flight.
+func (m *Model) SetContext(ctx context.Context) {
+	m.ctx = ctx
+}
+
+func (m *Model) updateTable() {
+	rows := []table.Row{}
+
+	for _, stream := range m.streams {
+		namespace := "-"
+		if stream.Metadata.Namespace != "" {
+			namespace = stream.Metadata.Namespace
+		}
+
+		rows = append(rows, table.Row{stream.Metadata.Name, namespace})
+	}
+
+	m.table.SetRows(rows)
+}
+
+type streamsLoadedMsg struct {
+	streams []models.KafkaStream
+	err     error
+}
+
+type streamDetailMsg struct {
+	yaml string
+}
+
+func (m *Model) loadStreams() tea.Msg {
+	streams, err := m.client.ListStreams(m.ctx)
+	return streamsLoadedMsg{streams: streams, err: err}
+}
+
+func (m *Model) loadStreamDetail() tea.Msg {
+	selectedRow := m.table.SelectedRow()
+	if len(selectedRow) == 0 {
+		return nil
+	}
+
+	yaml, err := m.client.GetResourceYAML(m.ctx, "stream", selectedRow[0])
+	if err != nil {
+		return streamDetailMsg{yaml: fmt.Sprintf("Error loading stream details: %v", err)}
+	}
+
+	return streamDetailMsg{yaml: yaml}
+}
@@ -0,0 +1,123 @@
+package topics
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/kafkaconfig"
+	"github.com/smart-fellas/k4a/internal/ui/components/dialog"
+	"github.com/smart-fellas/k4a/internal/ui/keys"
+	"github.com/smart-fellas/k4a/internal/ui/styles"
+	"github.com/smart-fellas/k4a/pkg/models"
+)
+
+var overriddenStyle = lipgloss.NewStyle().Foreground(styles.Warning)
+
+// configsModel lists the configs of a topic in human units, marks those
+// that differ from the broker defaults and documents each one.
+type configsModel struct {
+	topic  models.Topic
+	keys   keys.KeyMap
+	raw    bool
+	dialog dialog.Model
+	closed bool
+}
+
+func newConfigsModel(topic models.Topic, raw bool, width, height int) configsModel {
+	m := configsModel{
+		topic:  topic,
+		keys:   keys.DefaultKeyMap(),
+		raw:    raw,
+		dialog: dialog.New(),
+	}
+	m.dialog.SetSize(width, height)
+	m.render()
+	return m
+}
+
+// Closed reports whether the panel was dismissed.
+func (m configsModel) Closed() bool {
+	return m.closed
+}
+
+func (m configsModel) Update(msg tea.Msg) (configsModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.Back):
+			m.closed = true
+			return m, nil
+		case msg.String() == "u":
+			m.raw = !m.raw
+			m.render()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.dialog, cmd = m.dialog.Update(msg)
+	return m, cmd
+}
+
+func (m configsModel) View() string {
+	return m.dialog.View()
+}
+
+func (m *configsModel) SetSize(width, height int) {
+	m.dialog.SetSize(width, height)
+}
+
+func (m *configsModel) render() {
+	m.dialog.SetTitle(fmt.Sprintf("Configs of %s (u toggles raw values, ESC to close)", m.topic.Metadata.Name))
+	m.dialog.SetContent(renderConfigs(m.topic.Spec.Configs, m.raw))
+}
+
+// renderConfigs lists every config with its value and documentation.
+// Values that differ from the broker default are marked with the default.
+func renderConfigs(configs map[string]string, raw bool) string {
+	if len(configs) == 0 {
+		return styles.MutedText.Render("The topic sets no configs; the broker defaults apply")
+	}
+
+	names := make([]string, 0, len(configs))
+	width := 0
+	for name := range configs {
+		names = append(names, name)
+		width = max(width, len(name))
+	}
+	slices.Sort(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		value := configs[name]
+		config, known := kafkaconfig.Lookup(name)
+
+		line := fmt.Sprintf("  %-*s  %s", width, name, displayConfig(name, value, raw))
+		if known && !config.IsDefault(value) {
+			line = overriddenStyle.Render(fmt.Sprintf("* %-*s  %s", width, name, displayConfig(name, value, raw))) +
+				styles.MutedText.Render("  (default "+displayConfig(name, config.Default, raw)+")")
+		}
+		b.WriteString(line + "\n")
+
+		doc := "No documentation for this config"
+		if known {
+			doc = config.Doc
+		}
+		b.WriteString(styles.MutedText.Render("    "+doc) + "\n")
+	}
+
+	b.WriteString("\n" + styles.MutedText.Render("* differs from the Apache Kafka broker default"))
+	return b.String()
+}
+
+// displayConfig renders a config value in human units unless raw values
+// were asked for.
+func displayConfig(name, value string, raw bool) string {
+	if raw {
+		return value
+	}
+	return kafkaconfig.Humanize(name, value)
+}
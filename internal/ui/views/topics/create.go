@@ -0,0 +1,327 @@
+package topics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/dialog"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+	"github.com/smart-fellas/k4a/internal/ui/components/form"
+	"github.com/smart-fellas/k4a/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Keys of the form fields. Those of the topic settings are also the keys
+// of their topic validator constraints.
+const (
+	fieldName              = "name"
+	fieldPartitions        = "partitions"
+	fieldReplication       = "replication.factor"
+	fieldCleanupPolicy     = "cleanup.policy"
+	fieldRetention         = "retention.ms"
+	fieldMinInsyncReplicas = "min.insync.replicas"
+	fieldDescription       = "description"
+)
+
+// Kafka topic names are at most 249 of these characters
+var topicNamePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,249}$`)
+
+type createStage int
+
+const (
+	createForm createStage = iota
+	createPreview
+	createApplying
+)
+
+// createWizard collects the settings of a new topic, validates them
+// against the namespace's owned prefixes and topic validator, previews the
+// Topic manifest and applies it.
+type createWizard struct {
+	client   *kafkactl.Client
+	ctx      context.Context
+	width    int
+	height   int
+	existing []string
+
+	// Owned topic prefixes, owned topic names and topic validator of the
+	// namespace, when they could be loaded
+	prefixes  []string
+	names     []string
+	validator *models.TopicValidator
+
+	form     form.Model
+	stage    createStage
+	manifest []byte
+	preview  dialog.Model
+
+	closed  bool
+	created bool
+}
+
+func newCreateWizard(ctx context.Context, client *kafkactl.Client, existing []string) createWizard {
+	w := createWizard{
+		client:   client,
+		ctx:      ctx,
+		existing: existing,
+		form: form.New("Create topic",
+			form.Field{Key: fieldName, Label: "Name", Placeholder: "topic name", Required: true},
+			form.Field{Key: fieldPartitions, Label: "Partitions", Kind: form.Int, Default: "3", Required: true, Validate: form.Positive},
+			form.Field{Key: fieldReplication, Label: "Replication", Kind: form.Int, Default: "3", Required: true, Validate: form.Positive},
+			form.Field{Key: fieldCleanupPolicy, Label: "Cleanup Policy", Kind: form.Select, Options: []string{"delete", "compact", "delete,compact"}},
+			form.Field{Key: fieldRetention, Label: "Retention", Kind: form.Duration, Default: "7d", Required: true,
+				Placeholder: "e.g. 7d, 12h, 30m or -1 for infinite"},
+			form.Field{Key: fieldMinInsyncReplicas, Label: "Min ISR", Kind: form.Int, Default: "2", Required: true, Validate: form.Positive},
+			form.Field{Key: fieldDescription, Label: "Description", Placeholder: "optional"},
+		),
+		preview: dialog.New(),
+	}
+	w.form.SetCheck(w.check)
+	w.preview.SetTitle("Create this topic? (enter/y to apply, esc/n to go back)")
+
+	return w
+}
+
+func (w createWizard) Init() tea.Cmd {
+	return tea.Batch(w.form.Init(), w.loadConstraints)
+}
+
+// Closed reports whether the wizard was cancelled or the topic applied.
+func (w createWizard) Closed() bool {
+	return w.closed
+}
+
+// Created reports whether the topic was applied.
+func (w createWizard) Created() bool {
+	return w.created
+}
+
+// CapturingInput reports whether the form is receiving keystrokes.
+func (w createWizard) CapturingInput() bool {
+	return w.stage == createForm
+}
+
+func (w createWizard) Update(msg tea.Msg) (createWizard, tea.Cmd) {
+	switch msg := msg.(type) {
+	case createConstraintsMsg:
+		w.prefixes = msg.prefixes
+		w.names = msg.names
+		w.validator = msg.validator
+		w.form.SetCheck(w.check)
+		if len(w.prefixes) > 0 && w.form.Value(fieldName) == "" {
+			w.form.SetValue(fieldName, w.prefixes[0])
+		}
+		if owned := w.owned(); owned != "" {
+			w.form.SetNote("Owned " + owned)
+		}
+		if w.validator != nil {
+			for key, constraint := range w.validator.ValidationConstraints {
+				w.form.SetHint(key, constraint.String())
+			}
+		}
+		return w, nil
+
+	case topicCreatedMsg:
+		w.closed = true
+		if msg.err != nil {
+			return w, footer.Failure(fmt.Sprintf("Failed to create topic %s: %v", msg.name, msg.err))
+		}
+		w.created = true
+		return w, footer.Success(fmt.Sprintf("Created topic %s: %s", msg.name, strings.TrimSpace(msg.output)))
+	}
+
+	switch w.stage {
+	case createForm:
+		var cmd tea.Cmd
+		w.form, cmd = w.form.Update(msg)
+		switch {
+		case w.form.Cancelled():
+			w.closed = true
+		case w.form.Submitted():
+			manifest, err := w.Manifest()
+			if err != nil {
+				w.form.Resume()
+				w.form.SetError("", err)
+				return w, nil
+			}
+			w.manifest = manifest
+			w.preview.SetContent(string(manifest))
+			w.preview.SetSize(w.width, w.height)
+			w.stage = createPreview
+		}
+		return w, cmd
+
+	case createPreview:
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			switch msg.String() {
+			case "enter", "y":
+				w.stage = createApplying
+				return w, w.apply
+			case "esc", "n":
+				w.stage = createForm
+				w.form.Resume()
+				return w, nil
+			}
+		}
+		var cmd tea.Cmd
+		w.preview, cmd = w.preview.Update(msg)
+		return w, cmd
+
+	case createApplying:
+	}
+
+	return w, nil
+}
+
+// check validates the name and the settings against the namespace, once
+// each field is valid on its own.
+func (w createWizard) check(values form.Values) map[string]error {
+	errs := map[string]error{}
+
+	name := values.String(fieldName)
+	switch {
+	case name == "." || name == ".." || !topicNamePattern.MatchString(name):
+		errs[fieldName] = errors.New("use up to 249 letters, digits, '.', '_' or '-'")
+	case slices.Contains(w.existing, name):
+		errs[fieldName] = errors.New("topic already exists")
+	case len(w.prefixes) > 0 && len(w.names) == 0 && !w.owns(name):
+		errs[fieldName] = errors.New("must start with an owned prefix: " + strings.Join(w.prefixes, ", "))
+	case len(w.names) > 0 && !w.owns(name):
+		errs[fieldName] = errors.New("not owned by the namespace; owned " + w.owned())
+	}
+
+	if values.Int(fieldMinInsyncReplicas) > values.Int(fieldReplication) {
+		errs[fieldMinInsyncReplicas] = errors.New("must not exceed the replication factor")
+	}
+
+	if w.validator != nil {
+		for key, value := range settings(values) {
+			constraint, ok := w.validator.ValidationConstraints[key]
+			if _, invalid := errs[key]; invalid || !ok {
+				continue
+			}
+			if err := constraint.Validate(value); err != nil {
+				errs[key] = err
+			}
+		}
+	}
+
+	return errs
+}
+
+// owns reports whether name starts with an owned prefix or is an owned
+// topic name.
+func (w createWizard) owns(name string) bool {
+	return slices.Contains(w.names, name) ||
+		slices.ContainsFunc(w.prefixes, func(prefix string) bool { return strings.HasPrefix(name, prefix) })
+}
+
+// owned describes the owned prefixes and topic names, empty when there are
+// none.
+func (w createWizard) owned() string {
+	var parts []string
+	if len(w.prefixes) > 0 {
+		parts = append(parts, "prefixes: "+strings.Join(w.prefixes, ", "))
+	}
+	if len(w.names) > 0 {
+		parts = append(parts, "topics: "+strings.Join(w.names, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// settings returns the topic settings as they are written in the
+// manifest, by topic validator key.
+func settings(values form.Values) map[string]string {
+	return map[string]string{
+		fieldPartitions:        values.String(fieldPartitions),
+		fieldReplication:       values.String(fieldReplication),
+		fieldCleanupPolicy:     values.String(fieldCleanupPolicy),
+		fieldRetention:         strconv.FormatInt(values.Duration(fieldRetention), 10),
+		fieldMinInsyncReplicas: values.String(fieldMinInsyncReplicas),
+	}
+}
+
+// Manifest renders the Topic described by the form.
+func (w createWizard) Manifest() ([]byte, error) {
+	values := w.form.Values()
+	configs := settings(values)
+
+	topic := models.Topic{
+		BaseResource: models.BaseResource{
+			APIVersion: "v1",
+			Kind:       "Topic",
+			Metadata: models.ResourceMetadata{
+				Name:      values.String(fieldName),
+				Namespace: w.client.Namespace(),
+			},
+		},
+		Spec: models.TopicSpec{
+			Partitions:        values.Int(fieldPartitions),
+			ReplicationFactor: values.Int(fieldReplication),
+			Configs: map[string]string{
+				fieldCleanupPolicy:     configs[fieldCleanupPolicy],
+				fieldRetention:         configs[fieldRetention],
+				fieldMinInsyncReplicas: configs[fieldMinInsyncReplicas],
+			},
+			Description: values.String(fieldDescription),
+		},
+	}
+
+	return yaml.Marshal(topic)
+}
+
+func (w createWizard) View() string {
+	switch w.stage {
+	case createPreview:
+		return w.preview.View()
+	case createApplying:
+		return "Creating topic " + w.form.Value(fieldName) + "..."
+	case createForm:
+	}
+	return w.form.View()
+}
+
+func (w *createWizard) SetSize(width, height int) {
+	w.width = width
+	w.height = height
+	w.preview.SetSize(width, height)
+}
+
+type createConstraintsMsg struct {
+	prefixes  []string
+	names     []string
+	validator *models.TopicValidator
+}
+
+type topicCreatedMsg struct {
+	name   string
+	output string
+	err    error
+}
+
+// loadConstraints loads the owned topic prefixes and names and the topic
+// validator.
+// Either may be unavailable, in which case the form goes without it.
+func (w createWizard) loadConstraints() tea.Msg {
+	var msg createConstraintsMsg
+	if acls, err := w.client.ListACLs(w.ctx); err == nil {
+		msg.prefixes = models.OwnedPrefixes(acls, w.client.Namespace(), "TOPIC")
+		msg.names = models.OwnedNames(acls, w.client.Namespace(), "TOPIC")
+	}
+	if namespace, err := w.client.GetNamespace(w.ctx); err == nil {
+		msg.validator = namespace.Spec.TopicValidator
+	}
+	return msg
+}
+
+func (w createWizard) apply() tea.Msg {
+	output, err := w.client.ApplyManifest(w.ctx, w.manifest)
+	return topicCreatedMsg{name: w.form.Value(fieldName), output: output, err: err}
+}
@@ -1,21 +1,41 @@
 package topics
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/smart-fellas/k4a/internal/config"
 	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/bulk"
+	"github.com/smart-fellas/k4a/internal/ui/components/changes"
+	"github.com/smart-fellas/k4a/internal/ui/components/columns"
+	"github.com/smart-fellas/k4a/internal/ui/components/deletion"
 	"github.com/smart-fellas/k4a/internal/ui/components/dialog"
+	"github.com/smart-fellas/k4a/internal/ui/components/editor"
+	"github.com/smart-fellas/k4a/internal/ui/components/filter"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+	"github.com/smart-fellas/k4a/internal/ui/components/loaderror"
+	"github.com/smart-fellas/k4a/internal/ui/components/selection"
+	"github.com/smart-fellas/k4a/internal/ui/components/sorter"
 	"github.com/smart-fellas/k4a/internal/ui/keys"
+	"github.com/smart-fellas/k4a/internal/ui/views/consumers"
+	"github.com/smart-fellas/k4a/pkg/models"
 )
 
 type Model struct {
 	client  *kafkactl.Client
+	ctx     context.Context
 	table   table.Model
-	topics  []map[string]any
+	topics  []models.Topic
+	visible []models.Topic
 	keys    keys.KeyMap
 	width   int
 	height  int
@@ -26,22 +46,69 @@ type Model struct {
 	showDetail   bool
 	detailDialog dialog.Model
 
+	// Edit round trip and delete workflows
+	editor  editor.Model
+	deleter deletion.Model
+
+	// Filter bar; visible holds the rows matching it
+	filter filter.Model
+
+	// Sort order of the visible rows, kept in the state
+	sort  sorter.Model
+	state *config.State
+
+	// Columns configured for the view: added after the built-in columns,
+	// or with replaceColumns, in place of all of them but the name
+	extra          []columns.Column
+	replaceColumns bool
+
 	// Consumer groups view
 	showConsumers  bool
 	consumersTable table.Model
+	consumerGroups []models.ConsumerGroup
+	consumersTopic string
+
+	// Offset reset wizard
+	showReset bool
+	reset     consumers.ResetWizard
+
+	// Topic creation wizard
+	showCreate bool
+	create     createWizard
+
+	// Config panel of the selected topic
+	showConfigs bool
+	configs     configsModel
+
+	// Show config values as they are rather than in human units
+	rawValues bool
+
+	// Topics marked with space, and the bulk action run on them
+	marks selection.Model
+	bulk  bulk.Model
+
+	// Changes found by the last refreshes; removed holds the removed
+	// topics matching the filter, listed while they are highlighted
+	changes changes.Tracker
+	removed []models.Topic
 }
 
-func New(client *kafkactl.Client) Model {
-	columns := []table.Column{
-		{Title: "Name", Width: 40},
-		{Title: "Partitions", Width: 12},
-		{Title: "Replication", Width: 12},
-		{Title: "Retention", Width: 15},
-		{Title: "Description", Width: 30},
-	}
+// builtinColumns are the columns of the view before any configured ones;
+// configured columns replace all of them but the marker and the name.
+var builtinColumns = []table.Column{
+	{Title: "", Width: 2},
+	{Title: "Name", Width: 40},
+	{Title: "Partitions", Width: 12},
+	{Title: "Replication", Width: 12},
+	{Title: "Retention", Width: 15},
+	{Title: "Description", Width: 30},
+}
+
+// builtinNumeric are the built-in columns sorted as numbers.
+var builtinNumeric = []string{"Partitions", "Replication", "Retention"}
 
+func New(client *kafkactl.Client, state *config.State) Model {
 	t := table.New(
-		table.WithColumns(columns),
 		table.WithFocused(true),
 		table.WithHeight(20),
 	)
@@ -58,12 +125,22 @@ func New(client *kafkactl.Client) Model {
 		Bold(false)
 	t.SetStyles(s)
 
-	return Model{
+	m := Model{
 		client:       client,
+		state:        state,
+		ctx:          context.Background(),
 		table:        t,
 		keys:         keys.DefaultKeyMap(),
+		loading:      true,
 		detailDialog: dialog.New(),
+		editor:       editor.New(client),
+		deleter:      deletion.New(client),
+		filter:       filter.New(),
+		marks:        selection.New(),
+		bulk:         bulk.New(client),
 	}
+	m.SetColumns(nil, false)
+	return m
 }
 
 func (m Model) Init() tea.Cmd {
@@ -73,6 +150,88 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	// Snapshots are applied whatever is open, so that the list is current
+	// once it is closed
+	switch msg := msg.(type) {
+	case topicsLoadedMsg:
+		return m.loaded(msg)
+	case changes.ExpiredMsg:
+		m.applyFilter()
+		return m, nil
+	}
+
+	// Handle edit round trip
+	if m.editor.Active() {
+		newEditor, cmd := m.editor.Update(msg)
+		m.editor = newEditor
+		if !m.editor.Active() {
+			if m.editor.Applied() {
+				return m, tea.Batch(cmd, m.loadTopics)
+			}
+		}
+		return m, cmd
+	}
+
+	// Handle delete confirmation
+	if m.deleter.Active() {
+		newDeleter, cmd := m.deleter.Update(msg)
+		m.deleter = newDeleter
+		if !m.deleter.Active() {
+			if m.deleter.Deleted() {
+				return m, tea.Batch(cmd, m.loadTopics)
+			}
+		}
+		return m, cmd
+	}
+
+	// Handle bulk action
+	if m.bulk.Active() {
+		var cmd tea.Cmd
+		m.bulk, cmd = m.bulk.Update(msg)
+		// Marks are for one action
+		if m.bulk.Finished() && m.marks.Len() > 0 {
+			m.marks = selection.New()
+			m.updateTable()
+		}
+		return m, cmd
+	}
+
+	// Handle offset reset wizard
+	if m.showReset {
+		newWizard, cmd := m.reset.Update(msg)
+		m.reset = newWizard
+		if m.reset.Closed() {
+			m.showReset = false
+			if m.reset.Executed() {
+				return m, m.loadConsumerGroups
+			}
+		}
+		return m, cmd
+	}
+
+	// Handle topic creation wizard
+	if m.showCreate {
+		newWizard, cmd := m.create.Update(msg)
+		m.create = newWizard
+		if m.create.Closed() {
+			m.showCreate = false
+			if m.create.Created() {
+				return m, tea.Batch(cmd, m.loadTopics)
+			}
+		}
+		return m, cmd
+	}
+
+	// Handle config panel
+	if m.showConfigs {
+		newConfigs, cmd := m.configs.Update(msg)
+		m.configs = newConfigs
+		if m.configs.Closed() {
+			m.showConfigs = false
+		}
+		return m, cmd
+	}
+
 	// Handle detail view
 	if m.showDetail {
 		switch msg := msg.(type) {
@@ -81,6 +240,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.showDetail = false
 				return m, nil
 			}
+		case topicDetailMsg:
+			m.detailDialog.SetContent(msg.yaml)
+			return m, nil
 		}
 
 		newDialog, cmd := m.detailDialog.Update(msg)
@@ -96,6 +258,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.showConsumers = false
 				return m, nil
 			}
+			if msg.String() == "o" {
+				// Reset offsets of the selected group on this topic
+				cursor := m.consumersTable.Cursor()
+				if cursor >= 0 && cursor < len(m.consumerGroups) {
+					m.reset = consumers.NewResetWizard(m.ctx, m.client, m.consumerGroups[cursor], m.consumersTopic)
+					m.reset.SetSize(m.width, m.height)
+					m.showReset = true
+				}
+				return m, nil
+			}
+		case consumerGroupsMsg:
+			if errors.Is(msg.err, context.Canceled) {
+				return m, nil
+			}
+			if msg.err != nil {
+				m.showConsumers = false
+				return m, footer.Failure(fmt.Sprintf("Failed to load consumer groups of %s: %v", msg.topic, msg.err))
+			}
+			m.updateConsumersTable(msg.topic, msg.groups)
+			return m, nil
 		}
 
 		newTable, cmd := m.consumersTable.Update(msg)
@@ -103,39 +285,111 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	// Handle filter bar
+	if _, ok := msg.(tea.KeyMsg); ok && m.filter.Editing() {
+		var cmd tea.Cmd
+		m.filter, cmd = m.filter.Update(msg)
+		m.applyFilter()
+		return m, cmd
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if sorted, cmd, ok := m.sort.Update(msg); ok {
+			m.sort = sorted
+			m.table.SetColumns(m.sort.Columns())
+			m.applyFilter()
+			return m, cmd
+		}
+
 		switch {
+		case key.Matches(msg, m.keys.Filter):
+			var cmd tea.Cmd
+			m.filter, cmd = m.filter.Start()
+			return m, cmd
+
+		case key.Matches(msg, m.keys.Back) && m.filter.Value() != "":
+			m.filter = m.filter.Clear()
+			m.applyFilter()
+			return m, nil
+
+		case m.marks.Handles(msg):
+			m.marks = m.marks.Update(msg, m.selectedName(), topicNames(m.visible))
+			m.updateTable()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Describe) && m.marks.Len() > 0:
+			return m.startBulk(bulk.Describe(m.client, "topic"))
+
+		case key.Matches(msg, m.keys.Delete) && m.marks.Len() > 0:
+			return m.startBulk(bulk.Delete(m.client, "topic", m.reload))
+
+		case msg.String() == "X":
+			// Export the marked topics, or the topic under the cursor
+			return m.startBulk(bulk.Export(m.client, "topic"))
+
 		case key.Matches(msg, m.keys.Enter):
 			// Show consumer groups for selected topic
-			if len(m.topics) > 0 {
+			if len(m.visible) > 0 {
 				m.showConsumers = true
 				return m, m.loadConsumerGroups
 			}
 
 		case key.Matches(msg, m.keys.Describe):
 			// Show YAML detail
-			if len(m.topics) > 0 {
+			if len(m.visible) > 0 {
 				m.showDetail = true
 				return m, m.loadTopicDetail
 			}
 
+		case key.Matches(msg, m.keys.Edit):
+			if name := m.selectedName(); name != "" {
+				var cmd tea.Cmd
+				m.editor, cmd = m.editor.Start(m.ctx, "topic", name)
+				return m, cmd
+			}
+
+		case key.Matches(msg, m.keys.Delete):
+			if name := m.selectedName(); name != "" {
+				m.deleter = m.deleter.Start(m.ctx, "topic", name)
+				return m, nil
+			}
+
+		case msg.String() == "c":
+			existing := make([]string, 0, len(m.topics))
+			for _, topic := range m.topics {
+				existing = append(existing, topic.Metadata.Name)
+			}
+			m.create = newCreateWizard(m.ctx, m.client, existing)
+			m.create.SetSize(m.width, m.height)
+			m.showCreate = true
+			return m, m.create.Init()
+
+		case msg.String() == "C":
+			// Show the configs of the selected topic
+			if topic, ok := m.selectedTopic(); ok {
+				m.configs = newConfigsModel(topic, m.rawValues, m.width, m.height)
+				m.showConfigs = true
+			}
+			return m, nil
+
+		case msg.String() == "u":
+			// Toggle config values between human units and raw values
+			m.rawValues = !m.rawValues
+			m.updateTable()
+			return m, nil
+
 		case key.Matches(msg, m.keys.Refresh):
+			if m.err != nil {
+				m.err = nil
+				m.loading = true
+			}
 			return m, m.loadTopics
 		}
 
-	case topicsLoadedMsg:
-		m.topics = msg.topics
-		m.loading = false
-		m.updateTable()
-
 	case topicDetailMsg:
 		m.detailDialog.SetContent(msg.yaml)
 		m.showDetail = true
-
-	case consumerGroupsMsg:
-		m.updateConsumersTable(msg.groups)
-		m.showConsumers = true
 	}
 
 	newTable, cmd := m.table.Update(msg)
@@ -146,6 +400,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) View() string {
+	if m.editor.Active() {
+		return m.editor.View()
+	}
+
+	if m.deleter.Active() {
+		return m.deleter.View()
+	}
+
+	if m.bulk.Active() {
+		return m.bulk.View()
+	}
+
+	if m.showReset {
+		return m.reset.View()
+	}
+
+	if m.showCreate {
+		return m.create.View()
+	}
+
+	if m.showConfigs {
+		return m.configs.View()
+	}
+
 	if m.showDetail {
 		return m.detailDialog.View()
 	}
@@ -159,65 +437,220 @@ func (m Model) View() string {
 	}
 
 	if m.err != nil {
-		return fmt.Sprintf("Error: %v", m.err)
+		return loaderror.View("topics", m.err)
+	}
+
+	view := m.table.View()
+	if bar := m.filter.View(); bar != "" {
+		view += "\n" + bar
 	}
 
-	return m.table.View()
+	return view
 }
 
 func (m *Model) SetSize(width, height int) {
 	m.width = width
 	m.height = height
-	m.table.SetHeight(height - 2)
+	m.table.SetHeight(height - 3)
+	m.detailDialog.SetSize(width, height)
+	m.editor.SetSize(width, height)
+	m.deleter.SetSize(width, height)
+	m.bulk.SetSize(width, height)
+	m.create.SetSize(width, height)
+	m.configs.SetSize(width, height)
 }
 
-func (m *Model) updateTable() {
-	rows := []table.Row{}
+// SetContext sets the context of the view's kafkactl runs. The app cancels
+// it when the view is left, abandoning loads still in flight.
+func (m *Model) SetContext(ctx context.Context) {
+	m.ctx = ctx
+}
 
-	for _, topic := range m.topics {
-		metadata, ok := topic["metadata"].(map[string]any)
-		if !ok {
-			continue
-		}
+// SetColumns sets the configured columns of the view: added after the
+// built-in columns, or with replace, in place of all of them but the name.
+func (m *Model) SetColumns(extra []columns.Column, replace bool) {
+	m.extra, m.replaceColumns = extra, replace
 
-		spec, ok := topic["spec"].(map[string]any)
-		if !ok {
-			continue
-		}
+	builtin, numeric := builtinColumns, builtinNumeric
+	if replace {
+		builtin, numeric = builtinColumns[:2], nil
+	}
+	tableColumns := slices.Concat(builtin, columns.Table(extra))
+	m.sort = sorter.New("topics", m.state, tableColumns, slices.Concat(numeric, columns.Numeric(extra))...)
+	// The rows are reset first, as the table renders them with the new
+	// columns as soon as they are set
+	m.table.SetRows(nil)
+	m.table.SetColumns(m.sort.Columns())
+	m.applyFilter()
+}
 
-		name, ok := metadata["name"].(string)
-		if !ok {
-			continue
-		}
+// withColumns applies the configured columns to a row of built-in values.
+func (m Model) withColumns(row table.Row, doc map[string]any) table.Row {
+	if m.replaceColumns {
+		row = row[:2]
+	}
+	return append(row, columns.Values(m.extra, doc)...)
+}
+
+// CapturingInput reports whether an edit, a delete confirmation, the
+// offset reset or topic creation wizard or the filter bar is receiving
+// keystrokes.
+func (m Model) CapturingInput() bool {
+	return m.editor.Active() || m.deleter.Active() || m.bulk.CapturingInput() || (m.showReset && m.reset.CapturingInput()) ||
+		(m.showCreate && m.create.CapturingInput()) || m.filter.Editing()
+}
 
-		partitions := fmt.Sprintf("%v", spec["partitions"])
-		replication := fmt.Sprintf("%v", spec["replicationFactor"])
+// AutoRefresh reloads the topics, unless a dialog, form or workflow is open
+// or the filter is being edited.
+func (m Model) AutoRefresh() tea.Cmd {
+	if m.loading || m.editor.Active() || m.deleter.Active() || m.bulk.Active() || m.showReset ||
+		m.showCreate || m.showConfigs || m.showDetail || m.showConsumers || m.filter.Editing() {
+		return nil
+	}
+	return m.loadTopics
+}
+
+// FilterStatus returns the active filter expression with the number of
+// matching and total topics.
+func (m Model) FilterStatus() (string, int, int) {
+	return m.filter.Value(), len(m.visible), len(m.topics)
+}
 
+// applyFilter recomputes the visible rows from the filter.
+func (m *Model) applyFilter() {
+	m.visible = filter.Apply(m.filter, m.topics, func(t models.Topic) map[string]any { return t.Raw })
+	sorter.Sort(m.sort, m.visible, m.sortValue)
+	removed, _ := models.DecodeList[models.Topic](m.changes.Removed(time.Now()))
+	m.removed = filter.Apply(m.filter, removed, func(t models.Topic) map[string]any { return t.Raw })
+	m.updateTable()
+	if rows := len(m.table.Rows()); m.table.Cursor() >= rows {
+		m.table.SetCursor(max(rows-1, 0))
+	}
+	// The cursor is -1 once no row matched, or before any loaded; it moves
+	// back to the first row as soon as there is one
+	if m.table.Cursor() < 0 && len(m.table.Rows()) > 0 {
+		m.table.SetCursor(0)
+	}
+}
+
+func (m *Model) updateTable() {
+	rows := []table.Row{}
+	now := time.Now()
+	nameWidth := m.table.Columns()[1].Width
+
+	for _, topic := range slices.Concat(m.visible, m.removed) {
 		retention := "-"
-		if configs, configsOk := spec["configs"].(map[string]any); configsOk {
-			if ret, retOk := configs["retention.ms"]; retOk {
-				retention = fmt.Sprintf("%v", ret)
-			}
+		if ret, ok := topic.Spec.Configs["retention.ms"]; ok {
+			retention = displayConfig("retention.ms", ret, m.rawValues)
 		}
 
 		description := "-"
-		if desc, descOk := spec["description"].(string); descOk {
-			description = desc
+		if topic.Spec.Description != "" {
+			description = topic.Spec.Description
 		}
 
-		rows = append(rows, table.Row{
-			name,
-			partitions,
-			replication,
+		marker := m.marks.Marker(topic.Metadata.Name)
+		if marker == "" {
+			marker = m.changes.Symbol(topic.Metadata.Name, now)
+		}
+
+		rows = append(rows, m.withColumns(table.Row{
+			marker,
+			m.changes.Render(topic.Metadata.Name, topic.Metadata.Name, nameWidth, now),
+			strconv.Itoa(topic.Spec.Partitions),
+			strconv.Itoa(topic.Spec.ReplicationFactor),
 			retention,
 			description,
-		})
+		}, topic.Raw))
 	}
 
 	m.table.SetRows(rows)
 }
 
-func (m *Model) updateConsumersTable(groups []map[string]any) {
+// sortValue returns the value a topic is sorted by in a column, configured
+// or built-in.
+func (m Model) sortValue(topic models.Topic, column string) string {
+	if configured, ok := columns.Find(m.extra, column); ok {
+		return configured.SortValue(topic.Raw)
+	}
+	return topicSortValue(topic, column)
+}
+
+// topicSortValue returns the value a topic is sorted by in a column:
+// retention in milliseconds, with unlimited retention last.
+func topicSortValue(topic models.Topic, column string) string {
+	switch column {
+	case "Partitions":
+		return strconv.Itoa(topic.Spec.Partitions)
+	case "Replication":
+		return strconv.Itoa(topic.Spec.ReplicationFactor)
+	case "Retention":
+		if retention := topic.Spec.Configs["retention.ms"]; retention != "-1" {
+			return retention
+		}
+		return "+Inf"
+	case "Description":
+		return topic.Spec.Description
+	}
+	return topic.Metadata.Name
+}
+
+// selectedTopic returns the topic under the cursor.
+func (m Model) selectedTopic() (models.Topic, bool) {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.visible) {
+		return models.Topic{}, false
+	}
+	return m.visible[cursor], true
+}
+
+// selectedName returns the name of the topic under the cursor.
+func (m Model) selectedName() string {
+	if topic, ok := m.selectedTopic(); ok {
+		return topic.Metadata.Name
+	}
+	return ""
+}
+
+// startBulk runs action on the marked topics the filter shows, or on the
+// topic under the cursor when none are marked. When the filter hides
+// every marked topic, nothing is run.
+func (m Model) startBulk(action bulk.Action) (Model, tea.Cmd) {
+	names := m.marks.Names(topicNames(m.visible))
+	if len(names) == 0 && m.marks.Len() > 0 {
+		return m, footer.Info("No visible marked rows")
+	}
+	if len(names) == 0 {
+		if name := m.selectedName(); name != "" {
+			names = []string{name}
+		}
+	}
+	if len(names) == 0 {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.bulk, cmd = m.bulk.Start(m.ctx, action, names)
+	return m, cmd
+}
+
+// reload reloads the topics once a bulk action is done.
+func (m Model) reload([]kafkactl.BulkResult) tea.Cmd {
+	return m.loadTopics
+}
+
+func topicNames(topics []models.Topic) []string {
+	names := make([]string, len(topics))
+	for i, topic := range topics {
+		names[i] = topic.Metadata.Name
+	}
+	return names
+}
+
+func (m *Model) updateConsumersTable(topic string, groups []models.ConsumerGroup) {
+	m.consumerGroups = groups
+	m.consumersTopic = topic
+
 	columns := []table.Column{
 		{Title: "Group ID", Width: 30},
 		{Title: "State", Width: 15},
@@ -231,24 +664,49 @@ func (m *Model) updateConsumersTable(groups []map[string]any) {
 		table.WithHeight(m.height-2),
 	)
 
-	// Add consumer group rows
 	rows := []table.Row{}
 	for _, group := range groups {
-		// Parse group data and add rows
-		// This is a placeholder - actual implementation depends on kafkactl output structure
-		groupId := "-"
-		if id, ok := group["metadata"].(map[string]any)["name"].(string); ok {
-			groupId = id
-		}
-		rows = append(rows, table.Row{groupId, "Stable", "3", "0"})
+		rows = append(rows, table.Row{
+			group.Metadata.Name,
+			group.Status.State,
+			strconv.Itoa(group.Status.Members),
+			strconv.FormatInt(group.TopicLag(topic), 10),
+		})
 	}
 
 	m.consumersTable.SetRows(rows)
 }
 
+// loaded applies a snapshot of the topics, highlighting what changed
+// since the previous one.
+func (m Model) loaded(msg topicsLoadedMsg) (tea.Model, tea.Cmd) {
+	if errors.Is(msg.err, context.Canceled) {
+		return m, nil
+	}
+	m.loading = false
+	// Topics whose manifests fail to decode are left out, and the
+	// others shown all the same
+	m.err = nil
+	if msg.err != nil && !errors.Is(msg.err, models.ErrDecode) {
+		m.err = msg.err
+		return m, footer.Failure("Failed to load topics: " + msg.err.Error())
+	}
+
+	var cmd tea.Cmd
+	m.changes, cmd = m.changes.Update(changes.Snapshot(msg.topics, func(t models.Topic) (string, map[string]any) {
+		return t.Metadata.Name, t.Raw
+	}), time.Now())
+	m.topics = msg.topics
+	m.applyFilter()
+	if msg.err != nil {
+		cmd = tea.Batch(cmd, footer.Failure("Some topics could not be read: "+msg.err.Error()))
+	}
+	return m, cmd
+}
+
 // Command messages.
 type topicsLoadedMsg struct {
-	topics []map[string]any
+	topics []models.Topic
 	err    error
 }
 
@@ -257,26 +715,23 @@ type topicDetailMsg struct {
 }
 
 type consumerGroupsMsg struct {
-	groups []map[string]any
+	topic  string
+	groups []models.ConsumerGroup
+	err    error
 }
 
 func (m *Model) loadTopics() tea.Msg {
-	topics, err := m.client.GetTopics()
+	topics, err := m.client.ListTopics(m.ctx)
 	return topicsLoadedMsg{topics: topics, err: err}
 }
 
 func (m *Model) loadTopicDetail() tea.Msg {
-	if len(m.topics) == 0 {
-		return nil
-	}
-
-	selectedRow := m.table.SelectedRow()
-	if len(selectedRow) == 0 {
+	topicName := m.selectedName()
+	if topicName == "" {
 		return nil
 	}
 
-	topicName := selectedRow[0]
-	yaml, err := m.client.GetResourceYAML("topic", topicName)
+	yaml, err := m.client.GetResourceYAML(m.ctx, "topic", topicName)
 	if err != nil {
 		return topicDetailMsg{yaml: fmt.Sprintf("Error loading topic details: %v", err)}
 	}
@@ -285,16 +740,11 @@ func (m *Model) loadTopicDetail() tea.Msg {
 }
 
 func (m *Model) loadConsumerGroups() tea.Msg {
-	selectedRow := m.table.SelectedRow()
-	if len(selectedRow) == 0 {
-		return nil
-	}
-
-	topicName := selectedRow[0]
-	groups, err := m.client.GetConsumerGroups(topicName)
-	if err != nil {
+	topicName := m.selectedName()
+	if topicName == "" {
 		return nil
 	}
 
-	return consumerGroupsMsg{groups: groups}
+	groups, err := m.client.GetConsumerGroups(m.ctx, topicName)
+	return consumerGroupsMsg{topic: topicName, groups: groups, err: err}
 }
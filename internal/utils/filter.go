@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// selectorPattern matches a single field selector such as
+// spec.partitions>=12 or spec.configs.cleanup.policy=compact.
+var selectorPattern = regexp.MustCompile(`^\s*([A-Za-z0-9_.\-/]+)\s*(==|!=|>=|<=|=~|=|>|<)\s*(.*?)\s*$`)
+
+// Filter is a compiled search expression. It is one of:
+//
+//   - a plain substring, matched case-insensitively against metadata.name
+//   - a regular expression between slashes, e.g. /^orders-.*-v[0-9]+$/,
+//     matched against metadata.name
+//   - comma-separated field selectors, e.g. spec.partitions>=12, where each
+//     field is resolved with ExtractValue and all selectors must match
+type Filter struct {
+	substring string
+	regex     *regexp.Regexp
+	selectors []selector
+}
+
+type selector struct {
+	path  string
+	op    string
+	value string
+	regex *regexp.Regexp
+}
+
+// CompileFilter parses a search expression.
+func CompileFilter(expr string) (*Filter, error) {
+	expr = strings.TrimSpace(expr)
+
+	if len(expr) >= 2 && strings.HasPrefix(expr, "/") && strings.HasSuffix(expr, "/") {
+		re, err := regexp.Compile(expr[1 : len(expr)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex: %w", err)
+		}
+		return &Filter{regex: re}, nil
+	}
+
+	if selectors, ok, err := parseSelectors(expr); ok {
+		if err != nil {
+			return nil, err
+		}
+		return &Filter{selectors: selectors}, nil
+	}
+
+	return &Filter{substring: strings.ToLower(expr)}, nil
+}
+
+// parseSelectors reports ok only when every comma-separated part of expr is
+// a field selector.
+func parseSelectors(expr string) ([]selector, bool, error) {
+	if expr == "" {
+		return nil, false, nil
+	}
+
+	var selectors []selector
+	for _, part := range strings.Split(expr, ",") {
+		match := selectorPattern.FindStringSubmatch(part)
+		if match == nil {
+			return nil, false, nil
+		}
+
+		sel := selector{path: match[1], op: match[2], value: match[3]}
+		if sel.op == "=~" {
+			re, err := regexp.Compile(sel.value)
+			if err != nil {
+				return nil, true, fmt.Errorf("invalid regex for %s: %w", sel.path, err)
+			}
+			sel.regex = re
+		}
+		selectors = append(selectors, sel)
+	}
+
+	return selectors, true, nil
+}
+
+// Match reports whether a resource satisfies the filter.
+func (f *Filter) Match(resource map[string]any) bool {
+	switch {
+	case f.regex != nil:
+		return f.regex.MatchString(ExtractString(resource, "metadata.name", ""))
+	case len(f.selectors) > 0:
+		for _, sel := range f.selectors {
+			if !sel.match(resource) {
+				return false
+			}
+		}
+		return true
+	default:
+		name := ExtractString(resource, "metadata.name", "")
+		return strings.Contains(strings.ToLower(name), f.substring)
+	}
+}
+
+func (s selector) match(resource map[string]any) bool {
+	raw, err := ExtractValue(resource, s.path)
+	if err != nil {
+		// A missing field only satisfies a negated selector.
+		return s.op == "!="
+	}
+	actual := fmt.Sprintf("%v", raw)
+
+	if s.op == "=~" {
+		return s.regex.MatchString(actual)
+	}
+
+	actualNum, actualErr := strconv.ParseFloat(actual, 64)
+	wantNum, wantErr := strconv.ParseFloat(s.value, 64)
+	numeric := actualErr == nil && wantErr == nil
+
+	switch s.op {
+	case "=", "==":
+		if numeric {
+			return actualNum == wantNum
+		}
+		return strings.EqualFold(actual, s.value)
+	case "!=":
+		if numeric {
+			return actualNum != wantNum
+		}
+		return !strings.EqualFold(actual, s.value)
+	case ">":
+		return numeric && actualNum > wantNum
+	case ">=":
+		return numeric && actualNum >= wantNum
+	case "<":
+		return numeric && actualNum < wantNum
+	case "<=":
+		return numeric && actualNum <= wantNum
+	default:
+		return false
+	}
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -61,3 +62,80 @@ func PadRight(s string, length int) string {
 
 	return s + strings.Repeat(" ", length-len(s))
 }
+
+// ParseDuration parses a human duration such as 7d, 12h, 30m, 45s or 500ms
+// into milliseconds. A bare number is taken as milliseconds and -1 means
+// infinite, as in Kafka's retention.ms.
+func ParseDuration(s string) (int64, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if s == "-1" {
+		return -1, nil
+	}
+
+	units := []struct {
+		suffix string
+		ms     int64
+	}{
+		{"ms", 1},
+		{"s", 1000},
+		{"m", 60 * 1000},
+		{"h", 60 * 60 * 1000},
+		{"d", 24 * 60 * 60 * 1000},
+		{"w", 7 * 24 * 60 * 60 * 1000},
+	}
+
+	number, multiplier := s, int64(1)
+	for _, unit := range units {
+		if rest, ok := strings.CutSuffix(s, unit.suffix); ok {
+			number, multiplier = rest, unit.ms
+			break
+		}
+	}
+
+	value, err := strconv.ParseInt(strings.TrimSpace(number), 10, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid duration %q, use a number with ms, s, m, h, d or w", s)
+	}
+
+	return value * multiplier, nil
+}
+
+// Formatter renders the text of a table cell.
+type Formatter func(value string) string
+
+// NamedFormatter returns the formatter of a column format:
+//
+//   - duration renders milliseconds as a duration, e.g. 7.0d
+//   - bytes renders bytes in binary units, e.g. 1.0 GiB
+//   - truncate:N cuts text to N characters
+//
+// An empty format leaves values as they are, as do duration and bytes for
+// values that are not whole numbers of zero or more.
+func NamedFormatter(format string) (Formatter, error) {
+	name, arg, hasArg := strings.Cut(format, ":")
+	switch {
+	case format == "":
+		return func(value string) string { return value }, nil
+	case name == "duration" && !hasArg:
+		return numberFormatter(FormatDuration), nil
+	case name == "bytes" && !hasArg:
+		return numberFormatter(FormatBytes), nil
+	case name == "truncate" && hasArg:
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid length %q in format %s", arg, format)
+		}
+		return func(value string) string { return TruncateString(value, n) }, nil
+	}
+	return nil, fmt.Errorf("unknown format %q, use duration, bytes or truncate:N", format)
+}
+
+func numberFormatter(format func(int64) string) Formatter {
+	return func(value string) string {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n < 0 {
+			return value
+		}
+		return format(n)
+	}
+}
@@ -2,27 +2,42 @@ package utils
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 )
 
-// ExtractValue safely extracts a value from nested maps.
+// ExtractValue safely extracts a value from nested maps. Keys that
+// themselves contain dots, such as spec.configs.cleanup.policy, are
+// resolved by trying the longest matching key first.
 func ExtractValue(data map[string]any, path string) (any, error) {
-	keys := strings.Split(path, ".")
-	current := data
+	return extractKeys(data, strings.Split(path, "."), path)
+}
 
-	for i, key := range keys {
-		if i == len(keys)-1 {
-			if val, ok := current[key]; ok {
-				return val, nil
-			}
-			return nil, fmt.Errorf("key %s not found", key)
+func extractKeys(current map[string]any, keys []string, path string) (any, error) {
+	for end := len(keys); end > 0; end-- {
+		key := strings.Join(keys[:end], ".")
+		val, ok := current[key]
+		if !ok {
+			continue
 		}
 
-		if next, ok := current[key].(map[string]any); ok {
-			current = next
-		} else {
-			return nil, fmt.Errorf("invalid path at %s", key)
+		if end == len(keys) {
+			return val, nil
 		}
+
+		if next, isMap := val.(map[string]any); isMap {
+			if found, err := extractKeys(next, keys[end:], path); err == nil {
+				return found, nil
+			}
+		}
+	}
+
+	if len(keys) == 1 {
+		return nil, fmt.Errorf("key %s not found", keys[0])
+	}
+
+	if _, ok := current[keys[0]].(map[string]any); !ok {
+		return nil, fmt.Errorf("invalid path at %s", keys[0])
 	}
 
 	return nil, fmt.Errorf("path %s not found", path)
@@ -61,21 +76,36 @@ func ExtractInt(data map[string]any, path string, defaultValue int) int {
 	}
 }
 
-// FilterResources filters resources based on a search string.
-func FilterResources(resources []map[string]any, search string) []map[string]any {
+// FilterResources returns the resources whose document, as returned by
+// doc, matches a search expression. See CompileFilter for the supported
+// syntax; an invalid expression matches nothing. The result never shares
+// its array with resources, so it can be sorted in place.
+func FilterResources[T any](resources []T, search string, doc func(T) map[string]any) []T {
 	if search == "" {
-		return resources
+		return slices.Clone(resources)
 	}
 
-	search = strings.ToLower(search)
-	var filtered []map[string]any
+	filter, err := CompileFilter(search)
+	if err != nil {
+		return nil
+	}
 
+	var filtered []T
 	for _, resource := range resources {
-		name := ExtractString(resource, "metadata.name", "")
-		if strings.Contains(strings.ToLower(name), search) {
+		if filter.Match(doc(resource)) {
 			filtered = append(filtered, resource)
 		}
 	}
 
 	return filtered
 }
+
+// SafeFileName makes a name, such as a context name, safe to use as a
+// single path element: separators and .. become _, and an empty name
+// becomes default.
+func SafeFileName(name string) string {
+	if name == "" {
+		return "default"
+	}
+	return strings.NewReplacer("/", "_", "\\", "_", ":", "_", "..", "_").Replace(name)
+}
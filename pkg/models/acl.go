@@ -0,0 +1,48 @@
+package models
+
+// AccessControlEntry is an ns4kafka ACL granting a namespace access to the
+// resources of another.
+type AccessControlEntry struct {
+	BaseResource `yaml:",inline"`
+
+	Spec AccessControlEntrySpec `yaml:"spec" json:"spec"`
+}
+
+// AccessControlEntrySpec describes the granted resource and permission.
+type AccessControlEntrySpec struct {
+	ResourceType        string `yaml:"resourceType" json:"resourceType"`
+	Resource            string `yaml:"resource" json:"resource"`
+	ResourcePatternType string `yaml:"resourcePatternType" json:"resourcePatternType"`
+	Permission          string `yaml:"permission" json:"permission"`
+	GrantedTo           string `yaml:"grantedTo" json:"grantedTo"`
+}
+
+// OwnedPrefixes returns the resources of the given type, such as TOPIC,
+// that the PREFIXED OWNER entries among acls grant to namespace.
+func OwnedPrefixes(acls []AccessControlEntry, namespace, resourceType string) []string {
+	return owned(acls, namespace, resourceType, "PREFIXED")
+}
+
+// OwnedNames returns the resources of the given type that the LITERAL
+// OWNER entries among acls grant to namespace. Each owns that exact name
+// only, not the names starting with it.
+func OwnedNames(acls []AccessControlEntry, namespace, resourceType string) []string {
+	return owned(acls, namespace, resourceType, "LITERAL")
+}
+
+func owned(acls []AccessControlEntry, namespace, resourceType, patternType string) []string {
+	var resources []string
+	for _, acl := range acls {
+		if acl.Spec.Permission != "OWNER" ||
+			acl.Spec.ResourceType != resourceType ||
+			acl.Spec.ResourcePatternType != patternType ||
+			acl.Spec.GrantedTo != namespace {
+			continue
+		}
+		if acl.Spec.Resource != "" {
+			resources = append(resources, acl.Spec.Resource)
+		}
+	}
+
+	return resources
+}
@@ -0,0 +1,19 @@
+package models
+
+// ConnectCluster is a Kafka Connect cluster self-deployed by a namespace.
+type ConnectCluster struct {
+	BaseResource `yaml:",inline"`
+
+	Spec ConnectClusterSpec `yaml:"spec" json:"spec"`
+}
+
+// ConnectClusterSpec holds the cluster URL and credentials. ns4kafka masks
+// the password in its responses.
+type ConnectClusterSpec struct {
+	URL          string `yaml:"url" json:"url"`
+	Username     string `yaml:"username,omitempty" json:"username,omitempty"`
+	Password     string `yaml:"password,omitempty" json:"password,omitempty"`
+	Aes256Key    string `yaml:"aes256Key,omitempty" json:"aes256Key,omitempty"`
+	Aes256Salt   string `yaml:"aes256Salt,omitempty" json:"aes256Salt,omitempty"`
+	Aes256Format string `yaml:"aes256Format,omitempty" json:"aes256Format,omitempty"`
+}
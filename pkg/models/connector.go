@@ -0,0 +1,33 @@
+package models
+
+// Connector is an ns4kafka Connector.
+type Connector struct {
+	BaseResource `yaml:",inline"`
+
+	Spec   ConnectorSpec   `yaml:"spec" json:"spec"`
+	Status ConnectorStatus `yaml:"status,omitempty" json:"status,omitempty"`
+}
+
+// ConnectorSpec names the Connect cluster running the connector and holds
+// its configuration, e.g. connector.class and tasks.max.
+type ConnectorSpec struct {
+	ConnectCluster string            `yaml:"connectCluster" json:"connectCluster"`
+	Config         map[string]string `yaml:"config,omitempty" json:"config,omitempty"`
+}
+
+// ConnectorStatus is the state reported by the Connect cluster.
+type ConnectorStatus struct {
+	State          string       `yaml:"state,omitempty" json:"state,omitempty"`
+	WorkerID       string       `yaml:"worker_id,omitempty" json:"worker_id,omitempty"`
+	Tasks          []TaskStatus `yaml:"tasks,omitempty" json:"tasks,omitempty"`
+	LastUpdateTime string       `yaml:"lastUpdateTime,omitempty" json:"lastUpdateTime,omitempty"`
+}
+
+// TaskStatus is the state of one connector task. Trace holds the stack
+// trace of a failed task.
+type TaskStatus struct {
+	ID       int    `yaml:"id" json:"id"`
+	State    string `yaml:"state" json:"state"`
+	WorkerID string `yaml:"worker_id,omitempty" json:"worker_id,omitempty"`
+	Trace    string `yaml:"trace,omitempty" json:"trace,omitempty"`
+}
@@ -0,0 +1,46 @@
+package models
+
+// ConsumerGroup is a consumer group with its state, members and lag.
+type ConsumerGroup struct {
+	BaseResource `yaml:",inline"`
+
+	Status ConsumerGroupStatus `yaml:"status" json:"status"`
+}
+
+// ConsumerGroupStatus summarizes a group: its state, member count, the
+// topics it consumes and the committed offset of each partition.
+type ConsumerGroupStatus struct {
+	State   string            `yaml:"state" json:"state"`
+	Members int               `yaml:"members" json:"members"`
+	Topics  []string          `yaml:"topics,omitempty" json:"topics,omitempty"`
+	Offsets []PartitionOffset `yaml:"offsets,omitempty" json:"offsets,omitempty"`
+}
+
+// PartitionOffset holds the committed offset and lag of one partition.
+type PartitionOffset struct {
+	Topic         string `yaml:"topic" json:"topic"`
+	Partition     int    `yaml:"partition" json:"partition"`
+	CurrentOffset int64  `yaml:"currentOffset" json:"currentOffset"`
+	EndOffset     int64  `yaml:"endOffset" json:"endOffset"`
+	Lag           int64  `yaml:"lag" json:"lag"`
+}
+
+// TotalLag returns the lag summed over all partitions.
+func (g ConsumerGroup) TotalLag() int64 {
+	var total int64
+	for _, o := range g.Status.Offsets {
+		total += o.Lag
+	}
+	return total
+}
+
+// TopicLag returns the lag summed over the partitions of a single topic.
+func (g ConsumerGroup) TopicLag(topic string) int64 {
+	var total int64
+	for _, o := range g.Status.Offsets {
+		if o.Topic == topic {
+			total += o.Lag
+		}
+	}
+	return total
+}
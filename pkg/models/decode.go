@@ -0,0 +1,64 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrDecode is wrapped by the errors of manifests that do not fit their
+// model.
+var ErrDecode = errors.New("failed to decode manifest")
+
+// RawSetter is implemented by pointers to resources embedding BaseResource.
+type RawSetter[T any] interface {
+	*T
+	SetRaw(doc map[string]any)
+}
+
+// Decode converts a generic manifest into a typed resource. The manifest is
+// kept in Raw so that fields unknown to the model survive a round trip.
+func Decode[T any, PT RawSetter[T]](doc map[string]any) (T, error) {
+	var resource T
+
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return resource, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	if err = yaml.Unmarshal(data, &resource); err != nil {
+		return resource, fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+
+	PT(&resource).SetRaw(doc)
+
+	return resource, nil
+}
+
+// DecodeList converts generic manifests into typed resources. A manifest
+// that fails to decode is left out rather than failing the whole list; the
+// others are returned with the joined errors of those left out.
+func DecodeList[T any, PT RawSetter[T]](docs []map[string]any) ([]T, error) {
+	resources := make([]T, 0, len(docs))
+	var errs []error
+	for _, doc := range docs {
+		resource, err := Decode[T, PT](doc)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", manifestName(doc), err))
+			continue
+		}
+		resources = append(resources, resource)
+	}
+	return resources, errors.Join(errs...)
+}
+
+// manifestName returns the name in the metadata of a manifest.
+func manifestName(doc map[string]any) string {
+	if metadata, ok := doc["metadata"].(map[string]any); ok {
+		if name, ok := metadata["name"].(string); ok && name != "" {
+			return name
+		}
+	}
+	return "unnamed manifest"
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Namespace is an ns4kafka Namespace. Its topic validator holds the
+// constraints that the topics of the namespace must meet.
+type Namespace struct {
+	BaseResource `yaml:",inline"`
+
+	Spec NamespaceSpec `yaml:"spec" json:"spec"`
+}
+
+// NamespaceSpec describes the Kafka user and the policies of a namespace.
+type NamespaceSpec struct {
+	KafkaUser       string          `yaml:"kafkaUser,omitempty" json:"kafkaUser,omitempty"`
+	ConnectClusters []string        `yaml:"connectClusters,omitempty" json:"connectClusters,omitempty"`
+	TopicValidator  *TopicValidator `yaml:"topicValidator,omitempty" json:"topicValidator,omitempty"`
+}
+
+// TopicValidator maps topic settings, such as partitions,
+// replication.factor or retention.ms, to the constraint they must meet.
+type TopicValidator struct {
+	ValidationConstraints map[string]ValidationConstraint `yaml:"validationConstraints" json:"validationConstraints"`
+}
+
+// ValidationConstraint is an ns4kafka validator: a Range of numbers, a
+// ValidList or ValidString of allowed values, or a NonEmptyString.
+type ValidationConstraint struct {
+	ValidationType string   `yaml:"validation-type" json:"validation-type"`
+	Min            *float64 `yaml:"min,omitempty" json:"min,omitempty"`
+	Max            *float64 `yaml:"max,omitempty" json:"max,omitempty"`
+	ValidStrings   []string `yaml:"validStrings,omitempty" json:"validStrings,omitempty"`
+}
+
+// Validate checks a value against the constraint. Validators of unknown
+// types accept any value.
+func (c ValidationConstraint) Validate(value string) error {
+	switch c.ValidationType {
+	case "Range":
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("must be a number")
+		}
+		if c.Min != nil && number < *c.Min {
+			return fmt.Errorf("must be at least %s", formatNumber(*c.Min))
+		}
+		if c.Max != nil && number > *c.Max {
+			return fmt.Errorf("must be at most %s", formatNumber(*c.Max))
+		}
+	case "ValidList":
+		for item := range strings.SplitSeq(value, ",") {
+			if !slices.Contains(c.ValidStrings, strings.TrimSpace(item)) {
+				return fmt.Errorf("must be a list of %s", strings.Join(c.ValidStrings, ", "))
+			}
+		}
+	case "ValidString":
+		if !slices.Contains(c.ValidStrings, value) {
+			return fmt.Errorf("must be one of %s", strings.Join(c.ValidStrings, ", "))
+		}
+	case "NonEmptyString":
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("must not be empty")
+		}
+	}
+
+	return nil
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// String describes the values the constraint accepts, such as "3 to 6" or
+// "one of delete, compact".
+func (c ValidationConstraint) String() string {
+	switch c.ValidationType {
+	case "Range":
+		switch {
+		case c.Min != nil && c.Max != nil:
+			return formatNumber(*c.Min) + " to " + formatNumber(*c.Max)
+		case c.Min != nil:
+			return "at least " + formatNumber(*c.Min)
+		case c.Max != nil:
+			return "at most " + formatNumber(*c.Max)
+		}
+	case "ValidList":
+		return "list of " + strings.Join(c.ValidStrings, ", ")
+	case "ValidString":
+		return "one of " + strings.Join(c.ValidStrings, ", ")
+	case "NonEmptyString":
+		return "not empty"
+	}
+	return ""
+}
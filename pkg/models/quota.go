@@ -0,0 +1,9 @@
+package models
+
+// ResourceQuota limits the resources of a namespace. Spec maps quota names
+// such as count/topics or disk/topics to their limit.
+type ResourceQuota struct {
+	BaseResource `yaml:",inline"`
+
+	Spec map[string]string `yaml:"spec" json:"spec"`
+}
@@ -9,17 +9,21 @@ type Resource interface {
 	GetNamespace() string
 }
 
-// BaseResource contains common fields for all resources.
+// BaseResource contains common fields for all resources. Raw holds the
+// document the resource was decoded from, with every field including those
+// the typed model does not know about, for describe and edit.
 type BaseResource struct {
 	APIVersion string           `yaml:"apiVersion" json:"apiVersion"`
 	Kind       string           `yaml:"kind" json:"kind"`
 	Metadata   ResourceMetadata `yaml:"metadata" json:"metadata"`
+	Raw        map[string]any   `yaml:"-" json:"-"`
 }
 
 // ResourceMetadata contains metadata for resources.
 type ResourceMetadata struct {
 	Name        string            `yaml:"name" json:"name"`
 	Namespace   string            `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	Cluster     string            `yaml:"cluster,omitempty" json:"cluster,omitempty"`
 	Labels      map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
 	Annotations map[string]string `yaml:"annotations,omitempty" json:"annotations,omitempty"`
 	CreatedAt   *time.Time        `yaml:"createdAt,omitempty" json:"createdAt,omitempty"`
@@ -37,3 +41,8 @@ func (r *BaseResource) GetKind() string {
 func (r *BaseResource) GetNamespace() string {
 	return r.Metadata.Namespace
 }
+
+// SetRaw keeps the document the resource was decoded from.
+func (r *BaseResource) SetRaw(doc map[string]any) {
+	r.Raw = doc
+}
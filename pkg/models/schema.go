@@ -0,0 +1,26 @@
+package models
+
+// Schema is an ns4kafka Schema, named after its subject.
+type Schema struct {
+	BaseResource `yaml:",inline"`
+
+	Spec SchemaSpec `yaml:"spec" json:"spec"`
+}
+
+// SchemaSpec is a schema version registered for the subject.
+type SchemaSpec struct {
+	ID            int               `yaml:"id,omitempty" json:"id,omitempty"`
+	Version       int               `yaml:"version,omitempty" json:"version,omitempty"`
+	Schema        string            `yaml:"schema,omitempty" json:"schema,omitempty"`
+	SchemaFile    string            `yaml:"schemaFile,omitempty" json:"schemaFile,omitempty"`
+	SchemaType    string            `yaml:"schemaType,omitempty" json:"schemaType,omitempty"`
+	Compatibility string            `yaml:"compatibility,omitempty" json:"compatibility,omitempty"`
+	References    []SchemaReference `yaml:"references,omitempty" json:"references,omitempty"`
+}
+
+// SchemaReference points to a schema imported by another.
+type SchemaReference struct {
+	Name    string `yaml:"name" json:"name"`
+	Subject string `yaml:"subject" json:"subject"`
+	Version int    `yaml:"version" json:"version"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// KafkaStream is a Kafka Streams application registered by a namespace. It
+// has no spec: ns4kafka derives its ACLs from the application id, which is
+// the resource name.
+type KafkaStream struct {
+	BaseResource `yaml:",inline"`
+}
@@ -0,0 +1,26 @@
+package models
+
+// Topic is an ns4kafka Topic.
+type Topic struct {
+	BaseResource `yaml:",inline"`
+
+	Spec   TopicSpec   `yaml:"spec" json:"spec"`
+	Status TopicStatus `yaml:"status,omitempty" json:"status,omitempty"`
+}
+
+// TopicSpec is the desired state of a topic. Configs holds Kafka topic
+// configs such as retention.ms or cleanup.policy.
+type TopicSpec struct {
+	ReplicationFactor int               `yaml:"replicationFactor" json:"replicationFactor"`
+	Partitions        int               `yaml:"partitions" json:"partitions"`
+	Configs           map[string]string `yaml:"configs,omitempty" json:"configs,omitempty"`
+	Description       string            `yaml:"description,omitempty" json:"description,omitempty"`
+	Tags              []string          `yaml:"tags,omitempty" json:"tags,omitempty"`
+}
+
+// TopicStatus reports whether the topic was created on the cluster.
+type TopicStatus struct {
+	Phase          string `yaml:"phase,omitempty" json:"phase,omitempty"`
+	Message        string `yaml:"message,omitempty" json:"message,omitempty"`
+	LastUpdateTime string `yaml:"lastUpdateTime,omitempty" json:"lastUpdateTime,omitempty"`
+}
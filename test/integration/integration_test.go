@@ -22,8 +22,8 @@ func TestKafkactlInstalled(t *testing.T) {
 	}
 }
 
-// TestKafkactlClient_GetTopics tests fetching topics from a real Kafka cluster
-func TestKafkactlClient_GetTopics(t *testing.T) {
+// TestKafkactlClient_ListTopics tests fetching topics from a real Kafka cluster
+func TestKafkactlClient_ListTopics(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test in short mode")
 	}
@@ -41,7 +41,7 @@ func TestKafkactlClient_GetTopics(t *testing.T) {
 	}
 
 	client := kafkactl.NewClient(cfg)
-	topics, err := client.GetTopics()
+	topics, err := client.ListTopics(t.Context())
 	if err != nil {
 		t.Logf("Failed to get topics (this may be expected if no Kafka is running): %v", err)
 		return
@@ -50,8 +50,8 @@ func TestKafkactlClient_GetTopics(t *testing.T) {
 	t.Logf("Successfully retrieved %d topics", len(topics))
 }
 
-// TestKafkactlClient_GetSchemas tests fetching schemas from Schema Registry
-func TestKafkactlClient_GetSchemas(t *testing.T) {
+// TestKafkactlClient_ListSchemas tests fetching schemas from Schema Registry
+func TestKafkactlClient_ListSchemas(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test in short mode")
 	}
@@ -68,7 +68,7 @@ func TestKafkactlClient_GetSchemas(t *testing.T) {
 	}
 
 	client := kafkactl.NewClient(cfg)
-	schemas, err := client.GetSchemas()
+	schemas, err := client.ListSchemas(t.Context())
 	if err != nil {
 		t.Logf("Failed to get schemas (this may be expected if Schema Registry is not configured): %v", err)
 		return
@@ -126,7 +126,7 @@ func TestKafkactlClient_ExecuteCommand(t *testing.T) {
 	}
 
 	client := kafkactl.NewClient(cfg)
-	output, err := client.ExecuteCommand("version")
+	output, err := client.ExecuteCommand(t.Context(), "version")
 	if err != nil {
 		t.Fatalf("ExecuteCommand(version) failed: %v", err)
 	}
@@ -0,0 +1,97 @@
+package unit
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/views/acls"
+)
+
+func TestACLsView_Grant(t *testing.T) {
+	tests := []struct {
+		name     string
+		grantee  string
+		resource string
+		keys     []tea.KeyMsg
+		want     []string
+		wantErr  string
+	}{
+		{
+			name:     "prefixed read",
+			grantee:  "team-billing",
+			resource: "orders-",
+			want: []string{
+				"name: team-orders-acl-team-billing-orders-prefixed-read",
+				"resource: orders-",
+				"resourcePatternType: PREFIXED",
+				"permission: READ",
+				"grantedTo: team-billing",
+			},
+		},
+		{
+			name:     "literal write",
+			grantee:  "team-billing",
+			resource: "orders-created-v1",
+			keys:     []tea.KeyMsg{{Type: tea.KeyRight}, {Type: tea.KeyTab}, {Type: tea.KeyRight}},
+			want: []string{
+				"name: team-orders-acl-team-billing-orders-created-v1-literal-write",
+				"resourcePatternType: LITERAL",
+				"permission: WRITE",
+			},
+		},
+		{
+			name:     "own namespace",
+			grantee:  "team-orders",
+			resource: "orders-",
+			wantErr:  "cannot grant access to your own namespace",
+		},
+		{
+			name:     "unowned resource",
+			grantee:  "team-billing",
+			resource: "payments-",
+			wantErr:  "payments- is not under an owned prefix (orders-)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := kafkactl.NewFakeExecutor()
+			fake.Add(kafkactl.Result{Stdout: ordersOwnerACL}, "get", "acls", "-o", "yaml")
+
+			view := acls.New(kafkactl.NewClientWithExecutor(testConfig(), fake))
+			view.SetSize(160, 40)
+			updated, _ := view.Update(view.Init()())
+			view = updated.(acls.Model)
+
+			keys := []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("g")},
+				{Type: tea.KeyRunes, Runes: []rune(tt.grantee)},
+				{Type: tea.KeyTab},
+				{Type: tea.KeyCtrlU},
+				{Type: tea.KeyRunes, Runes: []rune(tt.resource)},
+				{Type: tea.KeyTab},
+			}
+			keys = append(keys, tt.keys...)
+			keys = append(keys, tea.KeyMsg{Type: tea.KeyEnter})
+			for _, key := range keys {
+				updated, _ = view.Update(key)
+				view = updated.(acls.Model)
+			}
+
+			out := view.View()
+			if tt.wantErr != "" {
+				if !strings.Contains(out, tt.wantErr) {
+					t.Errorf("View() does not contain %q:\n%s", tt.wantErr, out)
+				}
+				return
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("preview does not contain %q:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
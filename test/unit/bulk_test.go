@@ -0,0 +1,318 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/config"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+	"github.com/smart-fellas/k4a/internal/ui/components/selection"
+	"github.com/smart-fellas/k4a/internal/ui/views/connectors"
+	"github.com/smart-fellas/k4a/internal/ui/views/topics"
+)
+
+func TestSelection(t *testing.T) {
+	visible := []string{"a", "b", "c"}
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+	all := tea.KeyMsg{Type: tea.KeyCtrlA}
+	invert := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("*")}
+	esc := tea.KeyMsg{Type: tea.KeyEsc}
+
+	tests := []struct {
+		name string
+		keys []tea.KeyMsg
+		want []string
+	}{
+		{name: "space marks the current row", keys: []tea.KeyMsg{space}, want: []string{"b"}},
+		{name: "space again unmarks it", keys: []tea.KeyMsg{space, space}},
+		{name: "select all", keys: []tea.KeyMsg{space, all}, want: []string{"a", "b", "c"}},
+		{name: "invert", keys: []tea.KeyMsg{space, invert}, want: []string{"a", "c"}},
+		{name: "esc clears", keys: []tea.KeyMsg{all, esc}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := selection.New()
+			for _, key := range tt.keys {
+				if !m.Handles(key) {
+					t.Fatalf("Handles(%q) = false", key.String())
+				}
+				m = m.Update(key, "b", visible)
+			}
+			if got := m.Names(visible); !slices.Equal(got, tt.want) {
+				t.Errorf("Names() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if selection.New().Handles(esc) {
+		t.Error("Handles(esc) = true with nothing marked")
+	}
+}
+
+func TestRunBulk(t *testing.T) {
+	client := kafkactl.NewClientWithExecutor(testConfig(), kafkactl.NewFakeExecutor())
+
+	items := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+	var running, peak atomic.Int32
+	results := client.RunBulk(context.Background(), items, func(_ context.Context, item string) (string, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		if item == "c" {
+			return "", errors.New("boom")
+		}
+		return "done " + item, nil
+	})
+
+	got := make([]kafkactl.BulkResult, len(items))
+	for result := range results {
+		got[result.Index] = result
+	}
+
+	if p := peak.Load(); p > config.DefaultParallelism {
+		t.Errorf("%d items ran at once, want at most %d", p, config.DefaultParallelism)
+	}
+	for i, result := range got {
+		switch {
+		case result.Item != items[i]:
+			t.Errorf("result %d is for %q, want %q", i, result.Item, items[i])
+		case items[i] == "c" && result.Err == nil:
+			t.Error("result of c has no error")
+		case items[i] != "c" && result.Output != "done "+items[i]:
+			t.Errorf("output of %s = %q", items[i], result.Output)
+		}
+	}
+}
+
+func TestConnectorsView_BulkRestart(t *testing.T) {
+	fake := kafkactl.NewFakeExecutor()
+	fake.Add(kafkactl.Result{Stdout: `apiVersion: v1
+kind: Connector
+metadata:
+  name: orders-sink
+---
+apiVersion: v1
+kind: Connector
+metadata:
+  name: payments-sink
+---
+apiVersion: v1
+kind: Connector
+metadata:
+  name: refunds-sink
+`}, "get", "connectors", "-o", "yaml")
+	fake.Add(kafkactl.Result{}, "connector", "restart", "orders-sink")
+	fake.Add(kafkactl.Result{Stderr: "Connect cluster unreachable", ExitCode: 1}, "connector", "restart", "payments-sink")
+	fake.Add(kafkactl.Result{}, "connector", "restart", "refunds-sink")
+
+	view := connectors.New(kafkactl.NewClientWithExecutor(testConfig(), fake), nil)
+	view.SetSize(120, 40)
+	updated, _ := view.Update(view.Init()())
+	view = updated.(connectors.Model)
+
+	// Mark every connector and restart them once confirmed
+	var cmd tea.Cmd
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyCtrlA},
+		{Type: tea.KeyRunes, Runes: []rune("R")},
+	} {
+		updated, cmd = view.Update(key)
+		view = updated.(connectors.Model)
+	}
+	if out := view.View(); !strings.Contains(out, "Restart 3 connectors") {
+		t.Fatalf("View() does not ask to restart 3 connectors:\n%s", out)
+	}
+
+	updated, cmd = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	view = updated.(connectors.Model)
+	for i := 0; cmd != nil && i < 10 && !strings.Contains(view.View(), "ESC to close"); i++ {
+		updated, cmd = view.Update(cmd())
+		view = updated.(connectors.Model)
+	}
+
+	out := view.View()
+	for _, want := range []string{"Restarted 2 of 3 connectors", "✓ orders-sink", "✗ payments-sink", "✓ refunds-sink"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("View() does not show %q:\n%s", want, out)
+		}
+	}
+
+	restarted := 0
+	for _, call := range fake.Calls() {
+		if len(call) > 1 && call[0] == "connector" && call[1] == "restart" {
+			restarted++
+		}
+	}
+	if restarted != 3 {
+		t.Errorf("ran %d restarts, want 3", restarted)
+	}
+}
+
+func TestConnectorsView_BulkSkipsFilteredMarks(t *testing.T) {
+	fake := kafkactl.NewFakeExecutor()
+	fake.Add(kafkactl.Result{Stdout: `apiVersion: v1
+kind: Connector
+metadata:
+  name: orders-sink
+---
+apiVersion: v1
+kind: Connector
+metadata:
+  name: payments-sink
+`}, "get", "connectors", "-o", "yaml")
+	fake.Add(kafkactl.Result{}, "connector", "restart", "orders-sink")
+
+	view := connectors.New(kafkactl.NewClientWithExecutor(testConfig(), fake), nil)
+	view.SetSize(120, 40)
+	updated, _ := view.Update(view.Init()())
+	view = updated.(connectors.Model)
+	row := func(out, name string) string {
+		for _, line := range strings.Split(out, "\n") {
+			if strings.Contains(line, name) {
+				return line
+			}
+		}
+		return ""
+	}
+	unmarked := row(view.View(), "payments-sink")
+
+	// Mark both connectors, then hide payments-sink behind a filter
+	var cmd tea.Cmd
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyCtrlA},
+		{Type: tea.KeyRunes, Runes: []rune("/")},
+		{Type: tea.KeyRunes, Runes: []rune("orders")},
+		{Type: tea.KeyEnter},
+		{Type: tea.KeyRunes, Runes: []rune("R")},
+	} {
+		updated, cmd = view.Update(key)
+		view = updated.(connectors.Model)
+	}
+	if out := view.View(); !strings.Contains(out, "Restart 1 connector") {
+		t.Fatalf("View() does not ask to restart the visible connector only:\n%s", out)
+	}
+
+	updated, cmd = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	view = updated.(connectors.Model)
+	for i := 0; cmd != nil && i < 10 && !strings.Contains(view.View(), "ESC to close"); i++ {
+		updated, cmd = view.Update(cmd())
+		view = updated.(connectors.Model)
+	}
+	for _, call := range fake.Calls() {
+		if slices.Equal(call, []string{"connector", "restart", "payments-sink"}) {
+			t.Error("restarted payments-sink, which the filter hides")
+		}
+	}
+
+	// The marks are cleared once the action is done
+	for _, key := range []tea.KeyMsg{{Type: tea.KeyEsc}, {Type: tea.KeyEsc}} {
+		updated, _ = view.Update(key)
+		view = updated.(connectors.Model)
+	}
+	if got := row(view.View(), "payments-sink"); got != unmarked {
+		t.Errorf("payments-sink row after the action = %q, want it unmarked: %q", got, unmarked)
+	}
+}
+
+func TestConnectorsView_BulkRunCapturesInputUntilClosed(t *testing.T) {
+	fake := kafkactl.NewFakeExecutor()
+	fake.Add(kafkactl.Result{Stdout: `apiVersion: v1
+kind: Connector
+metadata:
+  name: orders-sink
+---
+apiVersion: v1
+kind: Connector
+metadata:
+  name: payments-sink
+`}, "get", "connectors", "-o", "yaml")
+
+	view := connectors.New(kafkactl.NewClientWithExecutor(testConfig(), fake), nil)
+	view.SetSize(120, 40)
+	updated, _ := view.Update(view.Init()())
+	view = updated.(connectors.Model)
+
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyCtrlA},
+		{Type: tea.KeyRunes, Runes: []rune("R")},
+		{Type: tea.KeyRunes, Runes: []rune("y")},
+	} {
+		updated, _ = view.Update(key)
+		view = updated.(connectors.Model)
+	}
+	// The results reach only the current view, which must not be left
+	if !view.CapturingInput() {
+		t.Fatal("CapturingInput() = false while the restarts run")
+	}
+
+	// The first esc cancels the rest, the second closes the dialog
+	updated, _ = view.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	view = updated.(connectors.Model)
+	if out := view.View(); !strings.Contains(out, "cancelling (ESC to close)") {
+		t.Fatalf("View() after esc does not show the run cancelling:\n%s", out)
+	}
+	updated, _ = view.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	view = updated.(connectors.Model)
+	if out := view.View(); strings.Contains(out, "Restart 2 connectors") {
+		t.Errorf("View() after the second esc still shows the dialog:\n%s", out)
+	}
+	if view.CapturingInput() {
+		t.Error("CapturingInput() = true once the dialog is closed")
+	}
+}
+
+func TestTopicsView_BulkRefusesHiddenMarks(t *testing.T) {
+	fake := kafkactl.NewFakeExecutor()
+	fake.Add(kafkactl.Result{Stdout: `apiVersion: v1
+kind: Topic
+metadata:
+  name: orders-v1
+---
+apiVersion: v1
+kind: Topic
+metadata:
+  name: payments-v1
+`}, "get", "topics", "-o", "yaml")
+
+	view := topics.New(kafkactl.NewClientWithExecutor(testConfig(), fake), nil)
+	view.SetSize(120, 40)
+	updated, _ := view.Update(view.Init()())
+	view = updated.(topics.Model)
+
+	// Mark orders-v1, then show only payments-v1 and press delete
+	var cmd tea.Cmd
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeySpace, Runes: []rune(" ")},
+		{Type: tea.KeyRunes, Runes: []rune("/")},
+		{Type: tea.KeyRunes, Runes: []rune("payments")},
+		{Type: tea.KeyEnter},
+		{Type: tea.KeyCtrlD},
+	} {
+		updated, cmd = view.Update(key)
+		view = updated.(topics.Model)
+	}
+
+	if out := view.View(); strings.Contains(out, "Delete 1 topic") {
+		t.Fatalf("View() asks to delete the unmarked topic under the cursor:\n%s", out)
+	}
+	if cmd == nil {
+		t.Fatal("delete returned no command, want a footer message")
+	}
+	if msg, ok := cmd().(footer.MessageMsg); !ok || msg.Text != "No visible marked rows" {
+		t.Errorf("delete message = %+v, want No visible marked rows", msg)
+	}
+}
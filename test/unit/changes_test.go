@@ -0,0 +1,156 @@
+package unit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/config"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/changes"
+	"github.com/smart-fellas/k4a/internal/ui/views/connectors"
+)
+
+func TestTracker(t *testing.T) {
+	doc := func(partitions int) map[string]any {
+		return map[string]any{"spec": map[string]any{"partitions": partitions}}
+	}
+	start := time.Now()
+
+	tracker, cmd := changes.Tracker{}.Update(map[string]map[string]any{
+		"orders":   doc(6),
+		"payments": doc(3),
+		"refunds":  doc(1),
+	}, start)
+	if cmd != nil || tracker.Kind("orders", start) != changes.Unchanged {
+		t.Fatal("the first snapshot is highlighted, want it to set the baseline")
+	}
+
+	tracker, cmd = tracker.Update(map[string]map[string]any{
+		"orders":    doc(12),
+		"payments":  doc(3),
+		"shipments": doc(3),
+	}, start)
+	if cmd == nil {
+		t.Error("Update() returned no command to expire the highlights")
+	}
+
+	tests := []struct {
+		name string
+		want changes.Kind
+	}{
+		{name: "orders", want: changes.Changed},
+		{name: "payments", want: changes.Unchanged},
+		{name: "shipments", want: changes.Added},
+		{name: "refunds", want: changes.Removed},
+	}
+	for _, tt := range tests {
+		if got := tracker.Kind(tt.name, start); got != tt.want {
+			t.Errorf("Kind(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := tracker.Kind(tt.name, start.Add(changes.Highlight)); got != changes.Unchanged {
+			t.Errorf("Kind(%s) once expired = %v, want Unchanged", tt.name, got)
+		}
+	}
+
+	if removed := tracker.Removed(start); len(removed) != 1 || removed[0]["spec"].(map[string]any)["partitions"] != 1 {
+		t.Errorf("Removed() = %v, want the last document of refunds", removed)
+	}
+}
+
+func TestConnectorsView_HighlightsChanges(t *testing.T) {
+	connector := func(name, state string) string {
+		return "apiVersion: v1\nkind: Connector\nmetadata:\n  name: " + name + "\nstatus:\n  state: " + state + "\n"
+	}
+
+	fake := kafkactl.NewFakeExecutor()
+	fake.Add(kafkactl.Result{Stdout: connector("orders-sink", "RUNNING") + "---\n" + connector("payments-sink", "RUNNING")},
+		"get", "connectors", "-o", "yaml")
+	fake.Add(kafkactl.Result{Stdout: connector("orders-sink", "FAILED") + "---\n" + connector("refunds-sink", "RUNNING")},
+		"get", "connectors", "-o", "yaml")
+
+	view := connectors.New(kafkactl.NewClientWithExecutor(testConfig(), fake), nil)
+	view.SetSize(120, 40)
+	updated, _ := view.Update(view.Init()())
+	view = updated.(connectors.Model)
+
+	refresh := view.AutoRefresh()
+	if refresh == nil {
+		t.Fatal("AutoRefresh() = nil with no dialog open")
+	}
+	updated, expire := view.Update(refresh())
+	view = updated.(connectors.Model)
+	if expire == nil {
+		t.Error("Update() returned no command to expire the highlights")
+	}
+
+	rows := map[string]string{}
+	for _, line := range strings.Split(view.View(), "\n") {
+		for _, name := range []string{"orders-sink", "payments-sink", "refunds-sink"} {
+			if strings.Contains(line, name) {
+				rows[name] = strings.TrimSpace(line)
+			}
+		}
+	}
+	for name, marker := range map[string]string{"orders-sink": "~", "refunds-sink": "+", "payments-sink": "-"} {
+		if !strings.HasPrefix(rows[name], marker) {
+			t.Errorf("row of %s = %q, want it marked %s", name, rows[name], marker)
+		}
+	}
+
+	// Auto-refresh holds off while a dialog is open
+	updated, _ = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	view = updated.(connectors.Model)
+	if view.AutoRefresh() != nil {
+		t.Error("AutoRefresh() returned a reload with the describe dialog open")
+	}
+}
+
+func TestSettings_RefreshInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]time.Duration
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			want: map[string]time.Duration{"topics": config.DefaultRefresh, "connectors": 10 * time.Second},
+		},
+		{
+			name:    "per view and off",
+			content: "refresh:\n  default: 1m\n  topics: off\n",
+			want:    map[string]time.Duration{"topics": 0, "schemas": time.Minute, "connectors": 10 * time.Second},
+		},
+		{
+			name:    "too short",
+			content: "refresh:\n  topics: 100ms\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yml")
+			t.Setenv("K4A_CONFIG", path)
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			settings, err := config.LoadSettings()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for view, want := range tt.want {
+				if got := settings.RefreshInterval(view); got != want {
+					t.Errorf("RefreshInterval(%s) = %s, want %s", view, got, want)
+				}
+			}
+		})
+	}
+}
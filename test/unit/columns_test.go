@@ -0,0 +1,135 @@
+package unit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/smart-fellas/k4a/internal/config"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/columns"
+	"github.com/smart-fellas/k4a/internal/ui/views/topics"
+)
+
+func TestColumn_Value(t *testing.T) {
+	doc := map[string]any{
+		"metadata": map[string]any{"name": "orders", "labels": map[string]any{"team": "payments", "tier": 1}},
+		"spec": map[string]any{
+			"role":    map[string]any{"verbs": []any{"GET", "PUT"}},
+			"configs": map[string]any{"min.insync.replicas": "2"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		column columns.Column
+		want   string
+	}{
+		{name: "string", column: columns.Column{Path: "metadata.name"}, want: "orders"},
+		{name: "list", column: columns.Column{Path: "spec.role.verbs"}, want: "GET, PUT"},
+		{name: "map", column: columns.Column{Path: "metadata.labels"}, want: "team=payments, tier=1"},
+		{name: "dotted key", column: columns.Column{Path: "spec.configs.min.insync.replicas"}, want: "2"},
+		{name: "missing", column: columns.Column{Path: "spec.subject.subjectName"}, want: "-"},
+		{
+			name:   "formatter",
+			column: columns.Column{Path: "metadata.name", Format: strings.ToUpper},
+			want:   "ORDERS",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.column.Value(doc); got != tt.want {
+				t.Errorf("Value() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromPreset(t *testing.T) {
+	cols, err := columns.FromPreset(config.ColumnPreset{Columns: []config.ColumnSpec{
+		{Title: "Min ISR", Path: "spec.configs.min.insync.replicas", Numeric: true},
+		{Title: "Segment", Path: "spec.configs.segment.bytes", Width: 10, Format: "bytes"},
+		{Title: "Team", Path: "metadata.labels.team", Format: "truncate:8"},
+	}})
+	if err != nil {
+		t.Fatalf("FromPreset() error = %v", err)
+	}
+
+	if cols[0].Width != columns.DefaultWidth || cols[1].Width != 10 {
+		t.Errorf("widths = %d, %d, want %d, 10", cols[0].Width, cols[1].Width, columns.DefaultWidth)
+	}
+	if got := columns.Numeric(cols); strings.Join(got, ",") != "Min ISR,Segment" {
+		t.Errorf("Numeric() = %v, want Min ISR and Segment", got)
+	}
+
+	doc := map[string]any{
+		"metadata": map[string]any{"labels": map[string]any{"team": "payments-core"}},
+		"spec":     map[string]any{"configs": map[string]any{"segment.bytes": 1073741824}},
+	}
+	got := columns.Values(cols, doc)
+	if want := []string{"-", "1.0 GiB", "payme..."}; strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Values() = %q, want %q", got, want)
+	}
+	if got := cols[1].SortValue(doc); got != "1073741824" {
+		t.Errorf("SortValue() = %q, want the number of bytes", got)
+	}
+
+	if _, err := columns.FromPreset(config.ColumnPreset{Columns: []config.ColumnSpec{
+		{Title: "Team", Path: "metadata.labels.team", Format: "upper"},
+	}}); err == nil {
+		t.Error("FromPreset() with an unknown format returned no error")
+	}
+}
+
+func TestTopicsView_SetColumns(t *testing.T) {
+	t.Setenv("K4A_HOME", t.TempDir())
+
+	fake := kafkactl.NewFakeExecutor()
+	fake.Add(kafkactl.Result{Stdout: `---
+apiVersion: v1
+kind: Topic
+metadata:
+  name: orders-created-v1
+  labels:
+    team: payments
+spec:
+  partitions: 6
+  replicationFactor: 3
+  configs:
+    min.insync.replicas: "2"
+    retention.ms: "604800000"
+`}, "get", "topics", "-o", "yaml")
+
+	view := topics.New(kafkactl.NewClientWithExecutor(testConfig(), fake), nil)
+	view.SetSize(200, 30)
+	updated, _ := view.Update(view.Init()())
+	view = updated.(topics.Model)
+
+	extra := []columns.Column{
+		{Title: "Min ISR", Width: 8, Path: "spec.configs.min.insync.replicas"},
+		{Title: "Team", Width: 10, Path: "metadata.labels.team"},
+	}
+
+	view.SetColumns(extra, false)
+	out := view.View()
+	for _, want := range []string{"Partitions", "Min ISR", "Team", "orders-created-v1", "payments"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("View() with added columns does not contain %q:\n%s", want, out)
+		}
+	}
+
+	view.SetColumns(extra, true)
+	out = view.View()
+	if strings.Contains(out, "Partitions") {
+		t.Errorf("View() with replaced columns still shows Partitions:\n%s", out)
+	}
+	for _, want := range []string{"Name", "orders-created-v1", "Min ISR", "payments"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("View() with replaced columns does not contain %q:\n%s", want, out)
+		}
+	}
+
+	view.SetColumns(nil, false)
+	if out := view.View(); strings.Contains(out, "Min ISR") || !strings.Contains(out, "Partitions") {
+		t.Errorf("View() with the built-in columns:\n%s", out)
+	}
+}
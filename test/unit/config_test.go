@@ -3,7 +3,9 @@ package unit
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/smart-fellas/k4a/internal/config"
 )
@@ -116,3 +118,182 @@ func TestConfig_Save(t *testing.T) {
 		t.Error("Config file is empty")
 	}
 }
+
+func TestConfig_UseContext(t *testing.T) {
+	cfg := &config.Config{
+		CurrentContext: "dev",
+		Contexts: []config.Context{
+			{Name: "dev", Context: config.ContextDetails{Namespace: "dev-ns"}},
+			{Name: "prod", Context: config.ContextDetails{Namespace: "prod-ns"}},
+		},
+	}
+
+	if err := cfg.UseContext("missing"); err == nil {
+		t.Error("UseContext() expected error for unknown context")
+	}
+	if cfg.CurrentContext != "dev" {
+		t.Errorf("CurrentContext = %s after failed switch, want dev", cfg.CurrentContext)
+	}
+
+	if err := cfg.UseContext("prod"); err != nil {
+		t.Fatalf("UseContext() error = %v", err)
+	}
+	ctx, err := cfg.GetCurrentContext()
+	if err != nil {
+		t.Fatalf("GetCurrentContext() error = %v", err)
+	}
+	if ctx.Context.Namespace != "prod-ns" {
+		t.Errorf("namespace = %s, want prod-ns", ctx.Context.Namespace)
+	}
+}
+
+func TestConfig_ContextForNamespace(t *testing.T) {
+	cfg := &config.Config{
+		Contexts: []config.Context{
+			{Name: "dev", Context: config.ContextDetails{API: "https://dev", Namespace: "orders"}},
+			{Name: "prod", Context: config.ContextDetails{API: "https://prod", Namespace: "orders"}},
+			{Name: "payments", Context: config.ContextDetails{API: "https://dev", Namespace: "payments"}},
+		},
+	}
+
+	tests := []struct {
+		namespace string
+		api       string
+		want      string
+	}{
+		{namespace: "orders", api: "https://prod", want: "prod"},
+		{namespace: "orders", api: "https://other", want: "dev"},
+		{namespace: "payments", api: "https://prod", want: "payments"},
+	}
+	for _, tt := range tests {
+		ctx, err := cfg.ContextForNamespace(tt.namespace, tt.api)
+		if err != nil {
+			t.Fatalf("ContextForNamespace(%s, %s) error = %v", tt.namespace, tt.api, err)
+		}
+		if ctx.Name != tt.want {
+			t.Errorf("ContextForNamespace(%s, %s) = %s, want %s", tt.namespace, tt.api, ctx.Name, tt.want)
+		}
+	}
+
+	if _, err := cfg.ContextForNamespace("missing", ""); err == nil {
+		t.Error("ContextForNamespace() expected error for unknown namespace")
+	}
+}
+
+func TestConfig_SavePreservesOtherSettings(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	t.Setenv("KAFKACTL_CONFIG", configPath)
+
+	existing := `kafkactl:
+  version: 1.0.0
+  current-context: dev
+  contexts:
+    - name: dev
+      context:
+        api: http://dev:8080
+        namespace: dev-ns
+    - name: prod
+      context:
+        api: http://prod:8080
+        namespace: prod-ns
+micronaut:
+  http:
+    client:
+      read-timeout: 30s
+`
+	if err := os.WriteFile(configPath, []byte(existing), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err = cfg.UseContext("prod"); err != nil {
+		t.Fatal(err)
+	}
+	if err = cfg.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"current-context: prod", "version: 1.0.0", "read-timeout: 30s"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("saved config is missing %q:\n%s", want, data)
+		}
+	}
+
+	reloaded, err := config.Load()
+	if err != nil {
+		t.Fatalf("Load() after Save() error = %v", err)
+	}
+	if reloaded.CurrentContext != "prod" || len(reloaded.Contexts) != 2 {
+		t.Errorf("reloaded config = %+v", reloaded)
+	}
+}
+
+func TestLoadSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]time.Duration
+		wantErr bool
+	}{
+		{
+			name: "missing file uses defaults",
+			want: map[string]time.Duration{
+				"get":   config.DefaultTimeout,
+				"apply": time.Minute,
+			},
+		},
+		{
+			name:    "file overrides defaults",
+			content: "timeouts:\n  default: 10s\n  get: 5s\n",
+			want: map[string]time.Duration{
+				"get":     5 * time.Second,
+				"connect": 10 * time.Second,
+				"apply":   time.Minute,
+			},
+		},
+		{
+			name:    "invalid duration",
+			content: "timeouts:\n  get: soon\n",
+			wantErr: true,
+		},
+		{
+			name:    "column preset without path",
+			content: "columns:\n  topics:\n    default:\n      columns:\n        - title: Team\n",
+			wantErr: true,
+		},
+		{
+			name:    "column preset with unknown format",
+			content: "columns:\n  topics:\n    ops:\n      columns:\n        - {title: ISR, path: spec.configs.min.insync.replicas, format: upper}\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yml")
+			t.Setenv("K4A_CONFIG", path)
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			settings, err := config.LoadSettings()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for operation, want := range tt.want {
+				if got := settings.Timeout(operation); got != want {
+					t.Errorf("Timeout(%s) = %s, want %s", operation, got, want)
+				}
+			}
+		})
+	}
+}
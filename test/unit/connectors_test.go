@@ -0,0 +1,84 @@
+package unit
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/views/connectors"
+)
+
+func TestConnectorsView_TasksRestart(t *testing.T) {
+	fake := kafkactl.NewFakeExecutor()
+	fake.Add(kafkactl.Result{Stdout: `apiVersion: v1
+kind: Connector
+metadata:
+  name: orders-sink
+`}, "get", "connectors", "-o", "yaml")
+	fake.Add(kafkactl.Result{Stdout: `apiVersion: v1
+kind: Connector
+metadata:
+  name: orders-sink
+status:
+  state: RUNNING
+  tasks:
+    - id: 0
+      state: FAILED
+      trace: "org.apache.kafka.connect.errors.ConnectException: boom"
+`}, "get", "connector", "orders-sink", "-o", "yaml")
+	fake.Add(kafkactl.Result{}, "connector", "restart", "orders-sink")
+
+	view := connectors.New(kafkactl.NewClientWithExecutor(testConfig(), fake), nil)
+	view.SetSize(160, 40)
+	updated, _ := view.Update(view.Init()())
+	view = updated.(connectors.Model)
+
+	// Open the tasks of the connector
+	updated, cmd := view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	view = updated.(connectors.Model)
+	updated, _ = view.Update(cmd())
+	view = updated.(connectors.Model)
+	if out := view.View(); !strings.Contains(out, "R restart the whole connector") {
+		t.Fatalf("View() does not say R restarts the whole connector:\n%s", out)
+	}
+
+	restarted := func() bool {
+		return slices.ContainsFunc(fake.Calls(), func(call []string) bool {
+			return slices.Equal(call, []string{"connector", "restart", "orders-sink"})
+		})
+	}
+
+	// R asks first, as in the list, and n cancels the restart
+	updated, _ = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	view = updated.(connectors.Model)
+	if out := view.View(); !strings.Contains(out, "Restart connector orders-sink") || !view.CapturingInput() {
+		t.Fatalf("View() does not ask to restart orders-sink:\n%s", out)
+	}
+	updated, cmd = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	view = updated.(connectors.Model)
+	if cmd != nil {
+		cmd()
+	}
+	if restarted() || view.CapturingInput() {
+		t.Fatal("the restart ran or is still asked after n")
+	}
+
+	for _, k := range []string{"R", "y"} {
+		updated, cmd = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		view = updated.(connectors.Model)
+	}
+	if out := view.View(); !strings.Contains(out, "Restarting connector orders-sink and all of its tasks") {
+		t.Errorf("View() does not show the restart:\n%s", out)
+	}
+	updated, _ = view.Update(cmd())
+	view = updated.(connectors.Model)
+
+	if !restarted() {
+		t.Errorf("Calls() = %v, want the connector restarted once confirmed", fake.Calls())
+	}
+	if out := view.View(); strings.Contains(out, "Restarting") {
+		t.Errorf("View() still shows the restart once done:\n%s", out)
+	}
+}
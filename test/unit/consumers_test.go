@@ -0,0 +1,69 @@
+package unit
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/views/consumers"
+	"github.com/smart-fellas/k4a/pkg/models"
+)
+
+func TestResetWizard_Form(t *testing.T) {
+	group := models.ConsumerGroup{}
+	group.Metadata.Name = "orders-app"
+
+	client := kafkactl.NewClientWithExecutor(testConfig(), kafkactl.NewFakeExecutor())
+	wizard := consumers.NewResetWizard(context.Background(), client, group, "orders-v1")
+	wizard.SetSize(120, 40)
+
+	press := func(keys ...tea.KeyMsg) {
+		t.Helper()
+		for _, key := range keys {
+			wizard, _ = wizard.Update(key)
+		}
+	}
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	// --by-duration asks for the topic, kept from the consumers view, and a
+	// duration
+	press(down, down, down, enter)
+	if !wizard.CapturingInput() {
+		t.Fatal("CapturingInput() = false while the value form is open")
+	}
+	press(tab, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("soon")}, enter)
+	if out := wizard.View(); !strings.Contains(out, "duration must be ISO-8601 (PT1H) or Go style (1h30m)") {
+		t.Fatalf("View() does not reject soon:\n%s", out)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyCtrlU}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1h30m")}, enter)
+	want := "kafkactl reset-offsets --group orders-app --topic orders-v1 --by-duration PT5400S --dry-run"
+	if out := wizard.View(); !strings.Contains(out, want) {
+		t.Errorf("View() does not run %q:\n%s", want, out)
+	}
+	if !wizard.CapturingInput() {
+		t.Error("CapturingInput() = false while the dry-run is pending")
+	}
+}
+
+func TestResetWizard_ClosesOnEscOnly(t *testing.T) {
+	group := models.ConsumerGroup{}
+	group.Metadata.Name = "orders-app"
+
+	client := kafkactl.NewClientWithExecutor(testConfig(), kafkactl.NewFakeExecutor())
+	wizard := consumers.NewResetWizard(context.Background(), client, group, "orders-v1")
+
+	// q is left to the app, which quits unless a view captures input
+	wizard, _ = wizard.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if wizard.Closed() {
+		t.Error("q closed the wizard, want only esc to close it")
+	}
+	wizard, _ = wizard.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !wizard.Closed() {
+		t.Error("esc did not close the wizard")
+	}
+}
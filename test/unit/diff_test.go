@@ -0,0 +1,122 @@
+package unit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/smart-fellas/k4a/internal/ui/components/diffview"
+	"github.com/smart-fellas/k4a/internal/utils"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []string
+		b    []string
+		want string
+	}{
+		{
+			name: "identical",
+			a:    []string{"a", "b"},
+			b:    []string{"a", "b"},
+			want: " a  b",
+		},
+		{
+			name: "changed line",
+			a:    []string{"a", "b", "c"},
+			b:    []string{"a", "x", "c"},
+			want: " a -b +x  c",
+		},
+		{
+			name: "added and removed lines",
+			a:    []string{"a", "b"},
+			b:    []string{"b", "c"},
+			want: "-a  b +c",
+		},
+		{
+			name: "from empty",
+			a:    nil,
+			b:    []string{"a"},
+			want: "+a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, line := range utils.DiffLines(tt.a, tt.b) {
+				prefix := map[utils.DiffOp]string{utils.DiffEqual: " ", utils.DiffDelete: "-", utils.DiffInsert: "+"}[line.Op]
+				got = append(got, prefix+line.Text)
+			}
+			if strings.Join(got, " ") != tt.want {
+				t.Errorf("DiffLines() = %q, want %q", strings.Join(got, " "), tt.want)
+			}
+		})
+	}
+}
+
+func TestPrettySchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{
+			name:   "avro keeps key order",
+			schema: `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}]}`,
+			want: `{
+  "type": "record",
+  "name": "Order",
+  "fields": [
+    {
+      "name": "id",
+      "type": "string"
+    }
+  ]
+}`,
+		},
+		{
+			name:   "protobuf unchanged",
+			schema: "syntax = \"proto3\";\nmessage Order {}",
+			want:   "syntax = \"proto3\";\nmessage Order {}",
+		},
+		{
+			name:   "invalid json unchanged",
+			schema: `{"type":`,
+			want:   `{"type":`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.PrettySchema(tt.schema); got != tt.want {
+				t.Errorf("PrettySchema() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffView_CollapsesUnchangedLines(t *testing.T) {
+	a := strings.Fields("1 2 3 4 5 6 7 8 9 10")
+	b := strings.Fields("1 2 3 4 5 6 7 8 9 ten")
+	diff := utils.DiffLines(a, b)
+
+	unified := diffview.Unified(diff, "v1", "v2")
+	for _, want := range []string{"--- v1", "+++ v2", "@@ 6 unchanged lines @@", "-10", "+ten"} {
+		if !strings.Contains(unified, want) {
+			t.Errorf("Unified() = %q, want it to contain %q", unified, want)
+		}
+	}
+	if strings.Contains(unified, " 6\n") {
+		t.Errorf("Unified() shows line 6, want it collapsed")
+	}
+
+	sideBySide := diffview.SideBySide(diff, "v1", "v2", 40)
+	if !strings.Contains(sideBySide, "@@ 6 unchanged lines @@") || !strings.Contains(sideBySide, "ten") {
+		t.Errorf("SideBySide() = %q, want the change with collapsed context", sideBySide)
+	}
+
+	if same := diffview.Unified(utils.DiffLines(a, a), "v1", "v1"); !strings.Contains(same, "No differences") {
+		t.Errorf("Unified() of identical texts = %q, want No differences", same)
+	}
+}
@@ -0,0 +1,90 @@
+package unit
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/smart-fellas/k4a/internal/eventlog"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/views/logs"
+)
+
+func TestEventLog_KeepsLatestEntries(t *testing.T) {
+	log := eventlog.New()
+	for i := range eventlog.MaxEntries + 10 {
+		log.Info(fmt.Sprintf("event %d", i))
+	}
+	log.Error("last")
+
+	entries := log.Entries()
+	if len(entries) != eventlog.MaxEntries {
+		t.Fatalf("Entries() has %d entries, want %d", len(entries), eventlog.MaxEntries)
+	}
+
+	last := entries[len(entries)-1]
+	if last.Message != "last" || last.Level != eventlog.Error {
+		t.Errorf("last entry = %+v, want the ERROR entry added last", last)
+	}
+	if last.Time.IsZero() {
+		t.Error("Add() did not stamp the entry with the current time")
+	}
+}
+
+func TestLoggingExecutor(t *testing.T) {
+	fake := kafkactl.NewFakeExecutor()
+	fake.Add(kafkactl.Result{Stdout: "---\n"}, "get", "topics", "-o", "yaml")
+	fake.Add(kafkactl.Result{Stderr: "Topic \"missing\" not found", ExitCode: 1}, "get", "topic", "missing", "-o", "yaml")
+
+	log := eventlog.New()
+	client := kafkactl.NewClientWithExecutor(testConfig(), kafkactl.NewLoggingExecutor(fake, log))
+
+	if _, err := client.ListTopics(t.Context()); err != nil {
+		t.Fatalf("ListTopics() error = %v", err)
+	}
+	if _, err := client.GetResourceYAML(t.Context(), "topic", "missing"); err == nil {
+		t.Fatal("GetResourceYAML() expected an error for a failed run")
+	}
+
+	entries := log.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("log has %d entries, want 2", len(entries))
+	}
+
+	tests := []struct {
+		name      string
+		entry     eventlog.Entry
+		wantLevel eventlog.Level
+		wantArgs  string
+		wantInfo  string
+	}{
+		{
+			name:      "successful run",
+			entry:     entries[0],
+			wantLevel: eventlog.Info,
+			wantArgs:  "get topics -o yaml",
+			wantInfo:  "Exit:     0",
+		},
+		{
+			name:      "failed run keeps stderr",
+			entry:     entries[1],
+			wantLevel: eventlog.Error,
+			wantArgs:  "get topic missing -o yaml",
+			wantInfo:  `Topic "missing" not found`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.entry.Level != tt.wantLevel {
+				t.Errorf("Level = %v, want %v", tt.entry.Level, tt.wantLevel)
+			}
+			if got := strings.Join(tt.entry.Args, " "); got != tt.wantArgs {
+				t.Errorf("Args = %q, want %q", got, tt.wantArgs)
+			}
+			if details := logs.Describe(tt.entry); !strings.Contains(details, tt.wantInfo) {
+				t.Errorf("Describe() = %q, want it to contain %q", details, tt.wantInfo)
+			}
+		})
+	}
+}
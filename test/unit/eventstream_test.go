@@ -0,0 +1,254 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/eventstream"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/views/events"
+)
+
+func connectorDoc(name, state string, tasks int) map[string]any {
+	return map[string]any{
+		"metadata": map[string]any{"name": name, "resourceVersion": state + "-1"},
+		"spec":     map[string]any{"config": map[string]any{"tasks.max": tasks}},
+		"status":   map[string]any{"state": state},
+	}
+}
+
+func TestStream_Observe(t *testing.T) {
+	now := time.Now()
+	stream := eventstream.New()
+
+	baseline, err := stream.Observe("connectors", []map[string]any{
+		connectorDoc("orders-sink", "RUNNING", 1),
+		connectorDoc("payments-sink", "RUNNING", 1),
+	}, now)
+	if err != nil || len(baseline) != 0 {
+		t.Fatalf("Observe() of the first listing = %v, %v, want it to set the baseline", baseline, err)
+	}
+
+	observed, err := stream.Observe("connectors", []map[string]any{
+		connectorDoc("orders-sink", "FAILED", 3),
+		connectorDoc("refunds-sink", "RUNNING", 1),
+	}, now)
+	if err != nil {
+		t.Fatalf("Observe() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		typ     eventstream.Type
+		field   string
+		summary string
+	}{
+		{name: "orders-sink", typ: eventstream.Changed, field: "spec.config.tasks.max", summary: "spec.config.tasks.max changed from 1 to 3"},
+		{name: "orders-sink", typ: eventstream.StateChanged, field: "status.state", summary: "state RUNNING → FAILED"},
+		{name: "payments-sink", typ: eventstream.Deleted, summary: "deleted"},
+		{name: "refunds-sink", typ: eventstream.Created, summary: "created"},
+	}
+	if len(observed) != len(tests) {
+		t.Fatalf("Observe() = %d events, want %d: %+v", len(observed), len(tests), observed)
+	}
+	for i, tt := range tests {
+		event := observed[i]
+		if event.Kind != "connectors" || event.Name != tt.name || event.Type != tt.typ || event.Field != tt.field {
+			t.Errorf("event %d = %s %s %s %s, want connectors %s %s %s", i,
+				event.Kind, event.Name, event.Type, event.Field, tt.name, tt.typ, tt.field)
+		}
+		if got := event.Summary(); got != tt.summary {
+			t.Errorf("event %d Summary() = %q, want %q", i, got, tt.summary)
+		}
+	}
+
+	if observed[2].Before == "" || observed[2].After != "" {
+		t.Error("a deleted resource does not keep its last manifest in Before only")
+	}
+	if diff := events.Diff(observed[1]); !strings.Contains(diff, "-  state: RUNNING") || !strings.Contains(diff, "+  state: FAILED") {
+		t.Errorf("Diff() of the state transition =\n%s\nwant the state line removed and added", diff)
+	}
+
+	if again, _ := stream.Observe("connectors", []map[string]any{
+		connectorDoc("orders-sink", "FAILED", 3),
+		connectorDoc("refunds-sink", "RUNNING", 1),
+	}, now); len(again) != 0 {
+		t.Errorf("Observe() of an unchanged listing = %+v, want no events", again)
+	}
+	if got := len(stream.Events()); got != len(tests) {
+		t.Errorf("Events() has %d events, want %d", got, len(tests))
+	}
+}
+
+func TestStream_PersistsPerContext(t *testing.T) {
+	t.Setenv("K4A_HOME", t.TempDir())
+
+	stream, err := eventstream.Open("dev/eu")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	now := time.Now()
+	_, _ = stream.Observe("topics", nil, now)
+	if _, err = stream.Observe("topics", []map[string]any{
+		{"metadata": map[string]any{"name": "orders"}},
+	}, now); err != nil {
+		t.Fatalf("Observe() error = %v", err)
+	}
+
+	if _, err = os.Stat(filepath.Join(eventstream.Dir(), "dev_eu.jsonl")); err != nil {
+		t.Errorf("no event file for the context: %v", err)
+	}
+
+	reopened, err := eventstream.Open("dev/eu")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	got := reopened.Events()
+	if len(got) != 1 || got[0].Name != "orders" || got[0].Type != eventstream.Created || !got[0].Time.Equal(now) {
+		t.Errorf("Events() after reopening = %+v, want the topic created", got)
+	}
+
+	other, err := eventstream.Open("prod")
+	if err != nil || len(other.Events()) != 0 {
+		t.Errorf("Open() of another context = %+v, %v, want no events", other.Events(), err)
+	}
+}
+
+func TestStream_StoresManifestsOncePerChange(t *testing.T) {
+	t.Setenv("K4A_HOME", t.TempDir())
+
+	stream, err := eventstream.Open("dev")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	now := time.Now()
+	_, _ = stream.Observe("connectors", []map[string]any{connectorDoc("orders-sink", "RUNNING", 1)}, now)
+	if _, err = stream.Observe("connectors", []map[string]any{connectorDoc("orders-sink", "FAILED", 3)}, now); err != nil {
+		t.Fatalf("Observe() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(eventstream.Dir(), "dev.jsonl"))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if got := strings.Count(string(data), `"before"`); got != 1 {
+		t.Errorf("event file holds %d manifests before the change, want 1:\n%s", got, data)
+	}
+
+	reopened, err := eventstream.Open("dev")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	got := reopened.Events()
+	if len(got) != 2 {
+		t.Fatalf("Events() after reopening = %+v, want 2 events", got)
+	}
+	for i, event := range got {
+		if event.Before == "" || event.After == "" || event.Before == event.After {
+			t.Errorf("event %d after reopening has no manifests of the change: %+v", i, event)
+		}
+	}
+}
+
+func TestStream_CompactsFile(t *testing.T) {
+	t.Setenv("K4A_HOME", t.TempDir())
+
+	topics := func(from, to int) []map[string]any {
+		var docs []map[string]any
+		for i := from; i < to; i++ {
+			docs = append(docs, map[string]any{"metadata": map[string]any{"name": fmt.Sprintf("topic-%d", i)}})
+		}
+		return docs
+	}
+	lines := func() int {
+		data, err := os.ReadFile(filepath.Join(eventstream.Dir(), "dev.jsonl"))
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		return strings.Count(string(data), "\n")
+	}
+
+	stream, err := eventstream.Open("dev")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	now := time.Now()
+	_, _ = stream.Observe("topics", nil, now)
+
+	if _, err = stream.Observe("topics", topics(0, 1500), now); err != nil {
+		t.Fatalf("Observe() error = %v", err)
+	}
+	if got := lines(); got != 1500 {
+		t.Errorf("event file has %d events, want 1500 appended", got)
+	}
+
+	if _, err = stream.Observe("topics", topics(0, 2100), now); err != nil {
+		t.Fatalf("Observe() error = %v", err)
+	}
+	if got := lines(); got != eventstream.MaxEvents {
+		t.Errorf("event file has %d events, want it compacted to %d", got, eventstream.MaxEvents)
+	}
+	if got := len(stream.Events()); got != eventstream.MaxEvents {
+		t.Errorf("Events() has %d events, want %d", got, eventstream.MaxEvents)
+	}
+}
+
+func TestPoll_ConsumerGroups(t *testing.T) {
+	fake := kafkactl.NewFakeExecutor()
+	fake.Add(kafkactl.Result{Stdout: `---
+apiVersion: v1
+kind: ConsumerGroup
+metadata:
+  name: orders-app
+status:
+  state: Stable
+  members: 2
+  offsets:
+    - topic: orders
+      partition: 0
+      currentOffset: 42
+      endOffset: 50
+`}, "get", "consumer-groups", "-o", "yaml")
+
+	docs, err := eventstream.Poll(context.Background(), kafkactl.NewClientWithExecutor(testConfig(), fake), "consumer-groups")
+	if err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("Poll() = %d documents, want 1", len(docs))
+	}
+	status := docs[0]["status"].(map[string]any)
+	if status["state"] != "Stable" || status["members"] != 2 {
+		t.Errorf("status = %v, want state Stable with 2 members", status)
+	}
+	if _, ok := status["offsets"]; ok {
+		t.Error("Poll() kept the offsets, which change with every record consumed")
+	}
+}
+
+func TestEventsView(t *testing.T) {
+	stream := eventstream.New()
+	now := time.Now()
+	_, _ = stream.Observe("topics", []map[string]any{{"metadata": map[string]any{"name": "orders"}}}, now)
+	_, _ = stream.Observe("topics", nil, now)
+
+	view := events.New(stream)
+	if got := view.View(); got != "No changes observed yet" {
+		t.Errorf("View() before loading = %q", got)
+	}
+
+	updated, _ := view.Update(view.Init()())
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	got := updated.View()
+	for _, want := range []string{"topics orders: deleted", "-  name: orders"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("View() after enter does not contain %q:\n%s", want, got)
+		}
+	}
+}
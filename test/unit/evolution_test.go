@@ -0,0 +1,386 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/smart-fellas/k4a/internal/evolution"
+)
+
+const avroOrderV1 = `{
+  "type": "record",
+  "name": "Order",
+  "namespace": "com.example",
+  "fields": [
+    {"name": "id", "type": "string"},
+    {"name": "amount", "type": "int", "doc": "Amount in cents"},
+    {"name": "customer", "type": "string", "default": ""},
+    {"name": "note", "type": ["null", "string"], "default": null},
+    {"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["NEW", "PAID"]}},
+    {"name": "address", "type": {"type": "record", "name": "Address", "fields": [
+      {"name": "street", "type": "string"}
+    ]}}
+  ]
+}`
+
+const avroOrderV2 = `{
+  "type": "record",
+  "name": "Order",
+  "namespace": "com.example",
+  "fields": [
+    {"name": "id", "type": "string"},
+    {"name": "amount", "type": "long", "doc": "Amount in cents, with tax"},
+    {"name": "client", "type": "string", "aliases": ["customer"], "default": "unknown"},
+    {"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["NEW", "PAID", "SHIPPED"]}},
+    {"name": "address", "type": {"type": "record", "name": "Address", "fields": [
+      {"name": "street", "type": "string"},
+      {"name": "city", "type": "string"}
+    ]}}
+  ]
+}`
+
+func TestAnalyze_Avro(t *testing.T) {
+	report, err := evolution.Analyze("AVRO", avroOrderV1, avroOrderV2)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	want := []evolution.Change{
+		{Kind: evolution.TypeChanged, Path: "amount", Old: "int", New: "long", Compatibility: evolution.Backward},
+		{Kind: evolution.DocChanged, Path: "amount", Old: "Amount in cents", New: "Amount in cents, with tax", Compatibility: evolution.Full},
+		{Kind: evolution.FieldRenamed, Path: "client", Old: "customer", New: "client", Compatibility: evolution.Full},
+		{Kind: evolution.DefaultChanged, Path: "client", Old: `""`, New: `"unknown"`, Compatibility: evolution.Full},
+		{Kind: evolution.SymbolsChanged, Path: "status", Old: "NEW,PAID", New: "NEW,PAID,SHIPPED", Compatibility: evolution.Backward},
+		{Kind: evolution.FieldAdded, Path: "address.city", New: "string", Compatibility: evolution.Forward},
+		{Kind: evolution.FieldRemoved, Path: "note", Old: "null|string, default null", Compatibility: evolution.Full},
+	}
+	assertChanges(t, report.Changes, want)
+
+	if got := report.Compatibility(); got != evolution.None {
+		t.Errorf("Compatibility() = %v, want NONE", got)
+	}
+}
+
+func TestAnalyze_AvroUnions(t *testing.T) {
+	tests := []struct {
+		name  string
+		older string
+		newer string
+		want  evolution.Compatibility
+	}{
+		{
+			name:  "field made nullable",
+			older: `{"type":"record","name":"R","fields":[{"name":"a","type":"string"}]}`,
+			newer: `{"type":"record","name":"R","fields":[{"name":"a","type":["null","string"]}]}`,
+			want:  evolution.Backward,
+		},
+		{
+			name:  "string to bytes",
+			older: `{"type":"record","name":"R","fields":[{"name":"a","type":"string"}]}`,
+			newer: `{"type":"record","name":"R","fields":[{"name":"a","type":"bytes"}]}`,
+			want:  evolution.Full,
+		},
+		{
+			name:  "int to string",
+			older: `{"type":"record","name":"R","fields":[{"name":"a","type":"int"}]}`,
+			newer: `{"type":"record","name":"R","fields":[{"name":"a","type":"string"}]}`,
+			want:  evolution.None,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := evolution.Analyze("AVRO", tt.older, tt.newer)
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			if len(report.Changes) != 1 || report.Changes[0].Kind != evolution.TypeChanged {
+				t.Fatalf("Analyze() = %v, want a single type change", report.Changes)
+			}
+			if got := report.Compatibility(); got != tt.want {
+				t.Errorf("Compatibility() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyze_AvroRecordRenamed(t *testing.T) {
+	tests := []struct {
+		name  string
+		newer string
+		want  evolution.Compatibility
+	}{
+		{
+			name:  "without an alias",
+			newer: `{"type":"record","name":"S","fields":[{"name":"a","type":"string"}]}`,
+			want:  evolution.None,
+		},
+		{
+			name:  "with an alias for the old name",
+			newer: `{"type":"record","name":"S","namespace":"com.example","aliases":["com.example.R"],"fields":[{"name":"a","type":"string"}]}`,
+			want:  evolution.Backward,
+		},
+	}
+
+	older := `{"type":"record","name":"R","namespace":"com.example","fields":[{"name":"a","type":"string"}]}`
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := evolution.Analyze("AVRO", older, tt.newer)
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			want := []evolution.Change{{Kind: evolution.TypeChanged, Old: "R", New: "S", Compatibility: tt.want}}
+			assertChanges(t, report.Changes, want)
+		})
+	}
+}
+
+func TestAnalyze_JSONSchema(t *testing.T) {
+	older := `{
+  "type": "object",
+  "properties": {
+    "id": {"type": "string"},
+    "quantity": {"type": "integer", "description": "Items ordered"},
+    "legacy": {"type": "string"},
+    "code": {"type": "string"}
+  },
+  "required": ["id", "code"]
+}`
+	newer := `{
+  "type": "object",
+  "definitions": {"Address": {"type": "object", "properties": {"city": {"type": "string"}}}},
+  "properties": {
+    "id": {"type": "string"},
+    "quantity": {"type": "number", "description": "Items ordered", "default": 1},
+    "code": {"type": "string"},
+    "email": {"type": "string"},
+    "address": {"$ref": "#/definitions/Address"}
+  },
+  "required": ["id", "email"]
+}`
+
+	report, err := evolution.Analyze("JSON", older, newer)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	want := []evolution.Change{
+		{Kind: evolution.FieldAdded, Path: "address", New: "object", Compatibility: evolution.Forward},
+		{Kind: evolution.RequiredChanged, Path: "code", Old: "true", New: "false", Compatibility: evolution.Backward},
+		{Kind: evolution.FieldAdded, Path: "email", New: "string", Compatibility: evolution.Forward},
+		{Kind: evolution.TypeChanged, Path: "quantity", Old: "integer", New: "number", Compatibility: evolution.Backward},
+		{Kind: evolution.DefaultChanged, Path: "quantity", New: "1", Compatibility: evolution.Full},
+		{Kind: evolution.FieldRemoved, Path: "legacy", Old: "string", Compatibility: evolution.Backward},
+	}
+	assertChanges(t, report.Changes, want)
+}
+
+func TestAnalyze_JSONSchemaContentModel(t *testing.T) {
+	tests := []struct {
+		name   string
+		older  string
+		newer  string
+		change evolution.Change
+	}{
+		{
+			name:   "optional property added to a closed model",
+			older:  `{"type": "object", "properties": {"a": {"type": "string"}}, "additionalProperties": false}`,
+			newer:  `{"type": "object", "properties": {"a": {"type": "string"}, "b": {"type": "string"}}, "additionalProperties": false}`,
+			change: evolution.Change{Kind: evolution.FieldAdded, Path: "b", New: "string", Compatibility: evolution.Backward},
+		},
+		{
+			name:   "typed property added to an open model",
+			older:  `{"type": "object", "properties": {"a": {"type": "string"}}}`,
+			newer:  `{"type": "object", "properties": {"a": {"type": "string"}, "b": {"type": "string"}}}`,
+			change: evolution.Change{Kind: evolution.FieldAdded, Path: "b", New: "string", Compatibility: evolution.Forward},
+		},
+		{
+			name:   "untyped property added to an open model",
+			older:  `{"type": "object", "properties": {"a": {"type": "string"}}}`,
+			newer:  `{"type": "object", "properties": {"a": {"type": "string"}, "b": {}}}`,
+			change: evolution.Change{Kind: evolution.FieldAdded, Path: "b", New: "any", Compatibility: evolution.Full},
+		},
+		{
+			name:   "property added that matches the additional properties",
+			older:  `{"type": "object", "properties": {"a": {"type": "string"}}, "additionalProperties": {"type": "string"}}`,
+			newer:  `{"type": "object", "properties": {"a": {"type": "string"}, "b": {"type": "string"}}, "additionalProperties": {"type": "string"}}`,
+			change: evolution.Change{Kind: evolution.FieldAdded, Path: "b", New: "string", Compatibility: evolution.Full},
+		},
+		{
+			name:   "required property added to a closed model",
+			older:  `{"type": "object", "properties": {"a": {"type": "string"}}, "additionalProperties": false}`,
+			newer:  `{"type": "object", "properties": {"a": {"type": "string"}, "b": {"type": "string"}}, "required": ["b"], "additionalProperties": false}`,
+			change: evolution.Change{Kind: evolution.FieldAdded, Path: "b", New: "string", Compatibility: evolution.None},
+		},
+		{
+			name:   "optional property removed from a closed model",
+			older:  `{"type": "object", "properties": {"a": {"type": "string"}, "b": {"type": "string"}}, "additionalProperties": false}`,
+			newer:  `{"type": "object", "properties": {"a": {"type": "string"}}, "additionalProperties": false}`,
+			change: evolution.Change{Kind: evolution.FieldRemoved, Path: "b", Old: "string", Compatibility: evolution.Forward},
+		},
+		{
+			name:   "required property removed from a closed model",
+			older:  `{"type": "object", "properties": {"a": {"type": "string"}, "b": {"type": "string"}}, "required": ["b"], "additionalProperties": false}`,
+			newer:  `{"type": "object", "properties": {"a": {"type": "string"}}, "additionalProperties": false}`,
+			change: evolution.Change{Kind: evolution.FieldRemoved, Path: "b", Old: "string", Compatibility: evolution.None},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := evolution.Analyze("JSON", tt.older, tt.newer)
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			assertChanges(t, report.Changes, []evolution.Change{tt.change})
+		})
+	}
+}
+
+func TestAnalyze_RecursiveSchemas(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaType string
+		older      string
+		newer      string
+		change     evolution.Change
+	}{
+		{
+			name:       "Avro linked list",
+			schemaType: "AVRO",
+			older: `{"type": "record", "name": "Node", "fields": [
+  {"name": "value", "type": "int"},
+  {"name": "next", "type": ["null", "Node"], "default": null}
+]}`,
+			newer: `{"type": "record", "name": "Node", "fields": [
+  {"name": "value", "type": "int"},
+  {"name": "label", "type": "string", "default": ""},
+  {"name": "next", "type": ["null", "Node"], "default": null}
+]}`,
+			change: evolution.Change{Kind: evolution.FieldAdded, Path: "label", New: "string, default \"\"", Compatibility: evolution.Full},
+		},
+		{
+			name:       "JSON Schema tree",
+			schemaType: "JSON",
+			older: `{"$ref": "#/definitions/Node", "definitions": {"Node": {"type": "object", "properties": {
+  "value": {"type": "integer"},
+  "children": {"type": "array", "items": {"$ref": "#/definitions/Node"}}
+}}}}`,
+			newer: `{"$ref": "#/definitions/Node", "definitions": {"Node": {"type": "object", "properties": {
+  "value": {"type": "integer"},
+  "label": {"type": "string"},
+  "children": {"type": "array", "items": {"$ref": "#/definitions/Node"}}
+}}}}`,
+			change: evolution.Change{Kind: evolution.FieldAdded, Path: "label", New: "string", Compatibility: evolution.Forward},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := evolution.Analyze(tt.schemaType, tt.older, tt.newer)
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			assertChanges(t, report.Changes, []evolution.Change{tt.change})
+		})
+	}
+}
+
+func TestAnalyze_Protobuf(t *testing.T) {
+	older := `syntax = "proto3";
+package com.example;
+
+message Order {
+  string id = 1;
+  int32 quantity = 2;
+  // Customer name
+  string customer = 3;
+  string note = 4;
+  Address address = 5;
+
+  message Address {
+    string street = 1;
+  }
+}`
+	newer := `syntax = "proto3";
+package com.example;
+
+import "google/protobuf/timestamp.proto";
+
+message Order {
+  string id = 1;
+  int64 quantity = 2;
+  // Customer display name
+  string client = 3;
+  Address address = 5;
+  repeated string tags = 6 [deprecated = true];
+  oneof payment {
+    string card = 7;
+    string iban = 8;
+  }
+
+  message Address {
+    bytes street = 1;
+  }
+}
+
+service Orders {
+  rpc Get (Order) returns (Order) {}
+}`
+
+	report, err := evolution.Analyze("PROTOBUF", older, newer)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	want := []evolution.Change{
+		{Kind: evolution.TypeChanged, Path: "Order.quantity", Old: "int32", New: "int64", Compatibility: evolution.Full},
+		{Kind: evolution.FieldRenamed, Path: "Order.client", Old: "Order.customer", New: "Order.client", Compatibility: evolution.Full},
+		{Kind: evolution.DocChanged, Path: "Order.client", Old: "Customer name", New: "Customer display name", Compatibility: evolution.Full},
+		{Kind: evolution.FieldRemoved, Path: "Order.note", Old: "string = 4", Compatibility: evolution.Full},
+		{Kind: evolution.FieldAdded, Path: "Order.tags", New: "repeated string = 6", Compatibility: evolution.Full},
+		{Kind: evolution.FieldAdded, Path: "Order.card", New: "string = 7", Compatibility: evolution.Full},
+		{Kind: evolution.FieldAdded, Path: "Order.iban", New: "string = 8", Compatibility: evolution.Full},
+		{Kind: evolution.TypeChanged, Path: "Order.Address.street", Old: "string", New: "bytes", Compatibility: evolution.Full},
+	}
+	assertChanges(t, report.Changes, want)
+}
+
+func TestReport_Accepts(t *testing.T) {
+	report := evolution.Report{Changes: []evolution.Change{
+		{Kind: evolution.FieldAdded, Path: "a", Compatibility: evolution.Full},
+		{Kind: evolution.FieldRemoved, Path: "b", Compatibility: evolution.Backward},
+	}}
+
+	tests := []struct {
+		level string
+		want  bool
+	}{
+		{level: "BACKWARD", want: true},
+		{level: "BACKWARD_TRANSITIVE", want: true},
+		{level: "FORWARD", want: false},
+		{level: "FULL", want: false},
+		{level: "NONE", want: true},
+		{level: "", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			if got := report.Accepts(tt.level); got != tt.want {
+				t.Errorf("Accepts(%q) = %v, want %v", tt.level, got, tt.want)
+			}
+		})
+	}
+}
+
+func assertChanges(t *testing.T, got, want []evolution.Change) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("Analyze() found %d changes, want %d:\n%v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
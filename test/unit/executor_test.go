@@ -0,0 +1,342 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/config"
+	"github.com/smart-fellas/k4a/internal/eventlog"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/views/topics"
+)
+
+const topicsCassette = "../fixtures/cassettes/topics.yaml"
+
+func testConfig() *config.Config {
+	return &config.Config{
+		CurrentContext: "test",
+		Contexts: []config.Context{
+			{Name: "test", Context: config.ContextDetails{Namespace: "team-orders"}},
+		},
+	}
+}
+
+func TestFakeExecutor_Replay(t *testing.T) {
+	fake, err := kafkactl.NewReplayExecutor(topicsCassette)
+	if err != nil {
+		t.Fatalf("NewReplayExecutor() error = %v", err)
+	}
+	client := kafkactl.NewClientWithExecutor(testConfig(), fake)
+
+	topicList, err := client.ListTopics(t.Context())
+	if err != nil {
+		t.Fatalf("ListTopics() error = %v", err)
+	}
+	if len(topicList) != 2 {
+		t.Errorf("ListTopics() returned %d topics, want 2", len(topicList))
+	}
+
+	_, err = client.GetResourceYAML(t.Context(), "topic", "missing-topic")
+	if err == nil || !strings.Contains(err.Error(), "exit status 1") || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("GetResourceYAML() error = %v, want exit status and stderr", err)
+	}
+
+	if _, err = client.ListSchemas(t.Context()); err == nil {
+		t.Error("ListSchemas() expected error for an unrecorded run")
+	}
+
+	if calls := fake.Calls(); len(calls) != 3 {
+		t.Errorf("Calls() = %v, want 3 runs", calls)
+	}
+}
+
+func TestFakeExecutor_Matching(t *testing.T) {
+	fake := kafkactl.NewFakeExecutor(
+		kafkactl.Interaction{Args: []string{"get", "topics"}, Result: kafkactl.Result{Stdout: "first"}},
+		kafkactl.Interaction{Args: []string{"get", "topics"}, Result: kafkactl.Result{Stdout: "second"}},
+		kafkactl.Interaction{Args: []string{"apply", "-f", kafkactl.Wildcard}, Result: kafkactl.Result{Stdout: "applied"}},
+	)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "first match", args: []string{"get", "topics"}, want: "first"},
+		{name: "next match", args: []string{"get", "topics"}, want: "second"},
+		{name: "last match repeats", args: []string{"get", "topics"}, want: "second"},
+		{name: "wildcard argument", args: []string{"apply", "-f", "/tmp/k4a-123.yaml"}, want: "applied"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fake.Execute(t.Context(), tt.args, nil)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got.Stdout != tt.want {
+				t.Errorf("Execute() stdout = %q, want %q", got.Stdout, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ListTopics(t *testing.T) {
+	executor, err := kafkactl.NewReplayExecutor(topicsCassette)
+	if err != nil {
+		t.Fatalf("NewReplayExecutor() error = %v", err)
+	}
+	client := kafkactl.NewClientWithExecutor(testConfig(), executor)
+
+	topicList, err := client.ListTopics(t.Context())
+	if err != nil {
+		t.Fatalf("ListTopics() error = %v", err)
+	}
+	if len(topicList) != 2 {
+		t.Fatalf("ListTopics() returned %d topics, want 2", len(topicList))
+	}
+
+	first := topicList[0]
+	if first.Metadata.Name != "orders-created-v1" || first.Spec.Partitions != 12 {
+		t.Errorf("ListTopics()[0] = %s with %d partitions, want orders-created-v1 with 12",
+			first.Metadata.Name, first.Spec.Partitions)
+	}
+	if first.Spec.Configs["cleanup.policy"] != "delete" {
+		t.Errorf("ListTopics()[0] cleanup.policy = %q, want delete", first.Spec.Configs["cleanup.policy"])
+	}
+	if first.Raw["kind"] != "Topic" {
+		t.Errorf("ListTopics()[0].Raw = %v, want the decoded document", first.Raw)
+	}
+}
+
+func TestClient_ConnectorTasks(t *testing.T) {
+	executor := kafkactl.NewFakeExecutor()
+	executor.Add(kafkactl.Result{Stdout: `---
+apiVersion: v1
+kind: Connector
+metadata:
+  name: orders-sink
+spec:
+  connectCluster: connect-1
+status:
+  state: RUNNING
+  worker_id: connect-1:8083
+  tasks:
+    - id: 0
+      state: RUNNING
+      worker_id: connect-1:8083
+    - id: 1
+      state: FAILED
+      worker_id: connect-2:8083
+      trace: |
+        org.apache.kafka.connect.errors.ConnectException: boom
+          at Task.run
+`}, "get", "connector", "orders-sink", "-o", "yaml")
+	client := kafkactl.NewClientWithExecutor(testConfig(), executor)
+
+	connector, err := client.GetConnector(t.Context(), "orders-sink")
+	if err != nil {
+		t.Fatalf("GetConnector() error = %v", err)
+	}
+	if connector.Status.State != "RUNNING" || connector.Status.WorkerID != "connect-1:8083" {
+		t.Errorf("Status = %s on %s, want RUNNING on connect-1:8083", connector.Status.State, connector.Status.WorkerID)
+	}
+	if len(connector.Status.Tasks) != 2 {
+		t.Fatalf("Status.Tasks has %d tasks, want 2", len(connector.Status.Tasks))
+	}
+	failed := connector.Status.Tasks[1]
+	if failed.State != "FAILED" || !strings.Contains(failed.Trace, "ConnectException: boom") {
+		t.Errorf("Status.Tasks[1] = %+v, want a FAILED task with its trace", failed)
+	}
+}
+
+func TestClient_SetSchemaCompatibility(t *testing.T) {
+	// kafkactl takes the level as the enum value, underscores included
+	for _, level := range []string{"BACKWARD_TRANSITIVE", "FORWARD_TRANSITIVE", "FULL_TRANSITIVE", "NONE", "GLOBAL"} {
+		t.Run(level, func(t *testing.T) {
+			executor := kafkactl.NewFakeExecutor()
+			executor.Add(kafkactl.Result{}, "schema", level, "orders-value")
+			client := kafkactl.NewClientWithExecutor(testConfig(), executor)
+
+			if err := client.SetSchemaCompatibility(t.Context(), "orders-value", level); err != nil {
+				t.Errorf("SetSchemaCompatibility() error = %v, want kafkactl schema %s orders-value", err, level)
+			}
+		})
+	}
+}
+
+func TestClient_ListSchemaVersions(t *testing.T) {
+	executor := kafkactl.NewFakeExecutor()
+	executor.Add(kafkactl.Result{Stdout: `---
+apiVersion: v1
+kind: Schema
+metadata:
+  name: orders-value
+spec:
+  id: 12
+  version: 2
+  schema: '{"type":"string"}'
+---
+apiVersion: v1
+kind: Schema
+metadata:
+  name: orders-value
+spec:
+  id: 7
+  version: 1
+  schema: '{"type":"int"}'
+`}, "get", "schema", "orders-value", "--all-versions", "-o", "yaml")
+	executor.Add(kafkactl.Result{Stdout: "---\n"}, "get", "schema", "empty-value", "--all-versions", "-o", "yaml")
+	client := kafkactl.NewClientWithExecutor(testConfig(), executor)
+
+	versions, err := client.ListSchemaVersions(t.Context(), "orders-value")
+	if err != nil {
+		t.Fatalf("ListSchemaVersions() error = %v", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("ListSchemaVersions() returned %d versions, want 2", len(versions))
+	}
+	if versions[0].Spec.Version != 1 || versions[0].Spec.ID != 7 || versions[1].Spec.Version != 2 {
+		t.Errorf("ListSchemaVersions() = v%d, v%d, want v1, v2 in registration order", versions[0].Spec.Version, versions[1].Spec.Version)
+	}
+
+	if _, err = client.ListSchemaVersions(t.Context(), "empty-value"); err == nil {
+		t.Error("ListSchemaVersions() expected an error for a subject without versions")
+	}
+}
+
+func TestRecordingExecutor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.yaml")
+
+	next := kafkactl.NewFakeExecutor()
+	next.Add(kafkactl.Result{Stdout: "ok"}, "apply", "-f", "*")
+	next.Add(kafkactl.Result{Stderr: "boom", ExitCode: 2}, "delete", "topic", "orders")
+
+	client := kafkactl.NewClientWithExecutor(testConfig(), kafkactl.NewRecordingExecutor(next, path))
+	if _, err := client.ApplyManifest(t.Context(), []byte("kind: Topic\n")); err != nil {
+		t.Fatalf("ApplyManifest() error = %v", err)
+	}
+	if err := client.DeleteResource(t.Context(), "topic", "orders"); err == nil {
+		t.Fatal("DeleteResource() expected error")
+	}
+
+	cassette, err := kafkactl.LoadCassette(path)
+	if err != nil {
+		t.Fatalf("LoadCassette() error = %v", err)
+	}
+	if len(cassette.Interactions) != 2 {
+		t.Fatalf("recorded %d interactions, want 2", len(cassette.Interactions))
+	}
+	if got := strings.Join(cassette.Interactions[0].Args, " "); got != "apply -f *" {
+		t.Errorf("temporary file was recorded as %q", got)
+	}
+	if cassette.Interactions[1].ExitCode != 2 || cassette.Interactions[1].Stderr != "boom" {
+		t.Errorf("failed run recorded as %+v", cassette.Interactions[1].Result)
+	}
+
+	// The cassette replays the session
+	replay, err := kafkactl.NewReplayExecutor(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed := kafkactl.NewClientWithExecutor(testConfig(), replay)
+	if _, err = replayed.ApplyManifest(t.Context(), []byte("kind: Topic\n")); err != nil {
+		t.Errorf("replayed ApplyManifest() error = %v", err)
+	}
+}
+
+func TestRecordingExecutor_SaveFailure(t *testing.T) {
+	// The cassette cannot be written under a missing directory
+	path := filepath.Join(t.TempDir(), "missing", "session.yaml")
+
+	next := kafkactl.NewFakeExecutor()
+	next.Add(kafkactl.Result{Stdout: "Success Topic/orders (created)"}, "apply", "-f", "*")
+
+	log := eventlog.New()
+	recorder := kafkactl.NewRecordingExecutor(next, path)
+	recorder.LogTo(log)
+
+	client := kafkactl.NewClientWithExecutor(testConfig(), recorder)
+	if _, err := client.ApplyManifest(t.Context(), []byte("kind: Topic\n")); err != nil {
+		t.Fatalf("ApplyManifest() error = %v, want the apply to succeed", err)
+	}
+
+	entries := log.Entries()
+	if len(entries) != 1 || entries[0].Level != eventlog.Error || !strings.Contains(entries[0].Message, "Failed to record kafkactl apply") {
+		t.Errorf("log entries = %+v, want the failed save", entries)
+	}
+}
+
+func TestClient_Timeouts(t *testing.T) {
+	settingsPath := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(settingsPath, []byte("timeouts:\n  get: 20ms\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("K4A_CONFIG", settingsPath)
+	settings, err := config.LoadSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake := kafkactl.NewFakeExecutor(
+		kafkactl.Interaction{Args: []string{"get", "topics", "-o", "yaml"}, Delay: time.Second},
+		kafkactl.Interaction{Args: []string{"delete", "topic", "orders"}, Delay: 50 * time.Millisecond},
+	)
+	client := kafkactl.NewClientWithExecutor(testConfig(), fake)
+	client.SetSettings(settings)
+
+	_, err = client.ListTopics(t.Context())
+	if !kafkactl.IsTimeout(err) {
+		t.Errorf("ListTopics() error = %v, want a timeout", err)
+	}
+
+	// Cancelling abandons loads but not changes
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if _, err = client.ListTopics(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("ListTopics() with cancelled context error = %v, want context.Canceled", err)
+	}
+	if err = client.DeleteResource(ctx, "topic", "orders"); err != nil {
+		t.Errorf("DeleteResource() with cancelled context error = %v, want it to run", err)
+	}
+}
+
+func TestTopicsView_LoadAndFilter(t *testing.T) {
+	fake, err := kafkactl.NewReplayExecutor(topicsCassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	view := topics.New(kafkactl.NewClientWithExecutor(testConfig(), fake), nil)
+	view.SetSize(120, 30)
+
+	updated, _ := view.Update(view.Init()())
+	view = updated.(topics.Model)
+	if out := view.View(); !strings.Contains(out, "orders-created-v1") || !strings.Contains(out, "payments-v1") {
+		t.Fatalf("View() does not list both topics:\n%s", out)
+	}
+
+	keys := []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("/")},
+		{Type: tea.KeyRunes, Runes: []rune("spec.partitions>=12")},
+		{Type: tea.KeyEnter},
+	}
+	for _, key := range keys {
+		updated, _ = view.Update(key)
+		view = updated.(topics.Model)
+	}
+
+	filter, matched, total := view.FilterStatus()
+	if filter != "spec.partitions>=12" || matched != 1 || total != 2 {
+		t.Errorf("FilterStatus() = %q %d/%d, want spec.partitions>=12 1/2", filter, matched, total)
+	}
+	if out := view.View(); strings.Contains(out, "payments-v1") {
+		t.Errorf("filtered View() still lists payments-v1:\n%s", out)
+	}
+}
@@ -0,0 +1,201 @@
+package unit
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/ui/components/confirm"
+	"github.com/smart-fellas/k4a/internal/ui/components/form"
+)
+
+func newTestForm() form.Model {
+	return form.New("Test",
+		form.Field{Key: "name", Label: "Name", Required: true},
+		form.Field{Key: "count", Label: "Count", Kind: form.Int, Default: "3", Validate: form.Positive},
+		form.Field{Key: "retention", Label: "Retention", Kind: form.Duration, Default: "7d"},
+		form.Field{Key: "policy", Label: "Policy", Kind: form.Select, Options: []string{"delete", "compact"}},
+		form.Field{Key: "enabled", Label: "Enabled", Kind: form.Bool, Default: "true"},
+		form.Field{Key: "tags", Label: "Tags", Kind: form.MultiSelect, Options: []string{"pii", "gdpr", "internal"}, Default: "gdpr"},
+	)
+}
+
+func pressKeys(f form.Model, keys ...tea.KeyMsg) form.Model {
+	for _, key := range keys {
+		f, _ = f.Update(key)
+	}
+	return f
+}
+
+func TestForm_Values(t *testing.T) {
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+	right := tea.KeyMsg{Type: tea.KeyRight}
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+
+	f := pressKeys(newTestForm(),
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("orders")},
+		tab, tab,
+		tea.KeyMsg{Type: tea.KeyBackspace},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")},
+		tab, right,
+		tab, right,
+		tab, space, right, right, space,
+		tea.KeyMsg{Type: tea.KeyEnter},
+	)
+
+	if !f.Submitted() {
+		t.Fatalf("Submitted() = false:\n%s", f.View())
+	}
+
+	values := f.Values()
+	if got := values.String("name"); got != "orders" {
+		t.Errorf("name = %q, want orders", got)
+	}
+	if got := values.Int("count"); got != 3 {
+		t.Errorf("count = %d, want 3", got)
+	}
+	if got := values.Duration("retention"); got != 7*60*60*1000 {
+		t.Errorf("retention = %d, want 7h in ms", got)
+	}
+	if got := values.String("policy"); got != "compact" {
+		t.Errorf("policy = %q, want compact", got)
+	}
+	if values.Bool("enabled") {
+		t.Error("enabled = true, want it toggled off")
+	}
+	if got := values.List("tags"); !slices.Equal(got, []string{"pii", "gdpr", "internal"}) {
+		t.Errorf("tags = %v, want pii, gdpr and internal", got)
+	}
+}
+
+func TestForm_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(*form.Model)
+		wantErr string
+	}{
+		{
+			name:    "required",
+			setup:   func(*form.Model) {},
+			wantErr: "name is required",
+		},
+		{
+			name: "int",
+			setup: func(f *form.Model) {
+				f.SetValue("name", "orders")
+				f.SetValue("count", "three")
+			},
+			wantErr: "must be a whole number",
+		},
+		{
+			name: "field validator",
+			setup: func(f *form.Model) {
+				f.SetValue("name", "orders")
+				f.SetValue("count", "0")
+			},
+			wantErr: "must be a positive number",
+		},
+		{
+			name: "duration",
+			setup: func(f *form.Model) {
+				f.SetValue("name", "orders")
+				f.SetValue("retention", "a week")
+			},
+			wantErr: `invalid duration "a week"`,
+		},
+		{
+			name: "form check",
+			setup: func(f *form.Model) {
+				f.SetValue("name", "orders")
+				f.SetCheck(func(values form.Values) map[string]error {
+					if values.String("policy") == "delete" && values.Bool("enabled") {
+						return map[string]error{"policy": errors.New("cannot delete while enabled")}
+					}
+					return nil
+				})
+			},
+			wantErr: "cannot delete while enabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestForm()
+			tt.setup(&f)
+			f = pressKeys(f, tea.KeyMsg{Type: tea.KeyEnter})
+
+			if f.Submitted() {
+				t.Fatal("Submitted() = true, want the form rejected")
+			}
+			if out := f.View(); !strings.Contains(out, tt.wantErr) {
+				t.Errorf("View() does not show %q:\n%s", tt.wantErr, out)
+			}
+		})
+	}
+}
+
+func TestForm_CancelAndResume(t *testing.T) {
+	f := pressKeys(newTestForm(), tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("orders")}, tea.KeyMsg{Type: tea.KeyEsc})
+	if !f.Cancelled() {
+		t.Fatal("Cancelled() = false after esc")
+	}
+
+	f.Resume()
+	f = pressKeys(f, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("-v1")}, tea.KeyMsg{Type: tea.KeyEnter})
+	if !f.Submitted() || f.Value("name") != "orders-v1" {
+		t.Errorf("resumed form: Submitted() = %v, name = %q, want orders-v1 submitted", f.Submitted(), f.Value("name"))
+	}
+}
+
+func TestConfirm_YesNo(t *testing.T) {
+	tests := []struct {
+		key           tea.KeyMsg
+		wantConfirmed bool
+		wantCancelled bool
+	}{
+		{key: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}, wantConfirmed: true},
+		{key: tea.KeyMsg{Type: tea.KeyEnter}, wantConfirmed: true},
+		{key: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}, wantCancelled: true},
+		{key: tea.KeyMsg{Type: tea.KeyEsc}, wantCancelled: true},
+		{key: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key.String(), func(t *testing.T) {
+			m := confirm.NewYesNo("Restart connector orders-sink", "", "test", "team-orders")
+			m, _ = m.Update(tt.key)
+			if m.Confirmed() != tt.wantConfirmed || m.Cancelled() != tt.wantCancelled {
+				t.Errorf("Confirmed() = %v, Cancelled() = %v, want %v, %v",
+					m.Confirmed(), m.Cancelled(), tt.wantConfirmed, tt.wantCancelled)
+			}
+		})
+	}
+}
+
+func TestConfirm_Over(t *testing.T) {
+	m := confirm.NewYesNo("Restart?", "", "test", "team-orders")
+	m.SetSize(80, 20)
+
+	background := make([]string, 20)
+	for i := range background {
+		background[i] = strings.Repeat("#", 80)
+	}
+	out := m.Over(strings.Join(background, "\n"))
+
+	lines := strings.Split(out, "\n")
+	if len(lines) != 20 {
+		t.Fatalf("Over() has %d lines, want 20", len(lines))
+	}
+	if lines[0] != background[0] || lines[19] != background[19] {
+		t.Error("Over() changed the background above or below the modal")
+	}
+	if !strings.Contains(out, "Restart?") {
+		t.Errorf("Over() does not show the modal:\n%s", out)
+	}
+	middle := lines[10]
+	if !strings.HasPrefix(middle, "#") || !strings.HasSuffix(middle, "#") {
+		t.Errorf("Over() does not keep the background around the modal: %q", middle)
+	}
+}
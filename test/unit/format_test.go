@@ -188,3 +188,70 @@ func TestPadRight(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{input: "7d", want: 604800000},
+		{input: "12h", want: 43200000},
+		{input: "30m", want: 1800000},
+		{input: "45s", want: 45000},
+		{input: "500ms", want: 500},
+		{input: "2w", want: 1209600000},
+		{input: "86400000", want: 86400000},
+		{input: " 1D ", want: 86400000},
+		{input: "-1", want: -1},
+		{input: "", wantErr: true},
+		{input: "7 days", wantErr: true},
+		{input: "-5d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := utils.ParseDuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamedFormatter(t *testing.T) {
+	tests := []struct {
+		format  string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{format: "", input: "604800000", want: "604800000"},
+		{format: "duration", input: "604800000", want: "7.0d"},
+		{format: "duration", input: "-1", want: "-1"},
+		{format: "bytes", input: "1073741824", want: "1.0 GiB"},
+		{format: "bytes", input: "unlimited", want: "unlimited"},
+		{format: "truncate:10", input: "this is a very long string", want: "this is..."},
+		{format: "truncate:0", wantErr: true},
+		{format: "truncate", wantErr: true},
+		{format: "upper", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+" "+tt.input, func(t *testing.T) {
+			format, err := utils.NamedFormatter(tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NamedFormatter(%q) error = %v, wantErr %v", tt.format, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := format(tt.input); got != tt.want {
+				t.Errorf("NamedFormatter(%q)(%q) = %q, want %q", tt.format, tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package unit
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/smart-fellas/k4a/internal/utils"
@@ -16,6 +17,9 @@ func TestExtractValue(t *testing.T) {
 			},
 		},
 		"count": 42,
+		"configs": map[string]any{
+			"cleanup.policy": "compact",
+		},
 	}
 
 	tests := []struct {
@@ -58,6 +62,12 @@ func TestExtractValue(t *testing.T) {
 			path:    "name.value",
 			wantErr: true,
 		},
+		{
+			name:    "key containing dots",
+			path:    "configs.cleanup.policy",
+			want:    "compact",
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -253,10 +263,102 @@ func TestFilterResources(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := utils.FilterResources(resources, tt.search)
+			got := utils.FilterResources(resources, tt.search, func(doc map[string]any) map[string]any { return doc })
 			if len(got) != tt.want {
 				t.Errorf("FilterResources() returned %d resources, want %d", len(got), tt.want)
 			}
 		})
 	}
 }
+
+func TestCompileFilter(t *testing.T) {
+	topic := func(name string, partitions int, cleanup string) map[string]any {
+		return map[string]any{
+			"metadata": map[string]any{
+				"name": name,
+			},
+			"spec": map[string]any{
+				"partitions": partitions,
+				"configs": map[string]any{
+					"cleanup.policy": cleanup,
+				},
+			},
+		}
+	}
+
+	resources := []map[string]any{
+		topic("orders-created-v1", 12, "delete"),
+		topic("orders-updated-v2", 24, "compact"),
+		topic("payments-v1", 6, "compact"),
+	}
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "substring",
+			expr: "orders",
+			want: []string{"orders-created-v1", "orders-updated-v2"},
+		},
+		{
+			name: "regex",
+			expr: "/-v1$/",
+			want: []string{"orders-created-v1", "payments-v1"},
+		},
+		{
+			name: "numeric selector",
+			expr: "spec.partitions>=12",
+			want: []string{"orders-created-v1", "orders-updated-v2"},
+		},
+		{
+			name: "dotted config key",
+			expr: "spec.configs.cleanup.policy=compact",
+			want: []string{"orders-updated-v2", "payments-v1"},
+		},
+		{
+			name: "combined selectors",
+			expr: "spec.partitions>10,spec.configs.cleanup.policy=compact",
+			want: []string{"orders-updated-v2"},
+		},
+		{
+			name: "regex selector",
+			expr: "metadata.name=~^pay",
+			want: []string{"payments-v1"},
+		},
+		{
+			name: "missing field only matches negation",
+			expr: "spec.description!=x",
+			want: []string{"orders-created-v1", "orders-updated-v2", "payments-v1"},
+		},
+		{
+			name:    "invalid regex",
+			expr:    "/[/",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := utils.CompileFilter(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CompileFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var got []string
+			for _, resource := range resources {
+				if filter.Match(resource) {
+					got = append(got, utils.ExtractString(resource, "metadata.name", ""))
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("CompileFilter(%q) matched %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/smart-fellas/k4a/internal/kafkaconfig"
+)
+
+func TestHumanize(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		value  string
+		want   string
+	}{
+		{name: "retention in days", config: "retention.ms", value: "604800000", want: "7.0d"},
+		{name: "infinite retention", config: "retention.ms", value: "-1", want: "unlimited"},
+		{name: "segment size", config: "segment.bytes", value: "1073741824", want: "1.0 GiB"},
+		{name: "unlimited size", config: "retention.bytes", value: "-1", want: "unlimited"},
+		{name: "local retention", config: "local.retention.ms", value: "-2", want: "as retention.ms"},
+		{name: "never", config: "flush.ms", value: "9223372036854775807", want: "never"},
+		{name: "plain config", config: "cleanup.policy", value: "compact", want: "compact"},
+		{name: "unknown config", config: "confluent.tier.enable", value: "86400000", want: "86400000"},
+		{name: "not a number", config: "retention.ms", value: "7d", want: "7d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kafkaconfig.Humanize(tt.config, tt.value); got != tt.want {
+				t.Errorf("Humanize(%q, %q) = %q, want %q", tt.config, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_IsDefault(t *testing.T) {
+	tests := []struct {
+		config string
+		value  string
+		want   bool
+	}{
+		{config: "retention.ms", value: "604800000", want: true},
+		{config: "retention.ms", value: "86400000", want: false},
+		{config: "min.cleanable.dirty.ratio", value: "0.50", want: true},
+		{config: "cleanup.policy", value: "delete", want: true},
+		{config: "cleanup.policy", value: "compact", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.config+"="+tt.value, func(t *testing.T) {
+			config, ok := kafkaconfig.Lookup(tt.config)
+			if !ok {
+				t.Fatalf("Lookup(%q) found nothing", tt.config)
+			}
+			if got := config.IsDefault(tt.value); got != tt.want {
+				t.Errorf("IsDefault(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	if _, ok := kafkaconfig.Lookup("no.such.config"); ok {
+		t.Error("Lookup(no.such.config) found a config")
+	}
+}
@@ -1,6 +1,7 @@
 package unit
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/smart-fellas/k4a/internal/config"
@@ -27,6 +28,58 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestClient_BoundContext(t *testing.T) {
+	cfg := &config.Config{
+		CurrentContext: "dev",
+		Contexts: []config.Context{
+			{Name: "dev", Context: config.ContextDetails{API: "http://dev:8080", Namespace: "dev-ns"}},
+			{Name: "prod", Context: config.ContextDetails{API: "http://prod:8080", Namespace: "prod-ns", UserToken: "secret"}},
+			{Name: "local", Context: config.ContextDetails{Namespace: "local-ns"}},
+		},
+	}
+
+	dev := kafkactl.NewClient(cfg)
+	if err := cfg.UseContext("prod"); err != nil {
+		t.Fatal(err)
+	}
+	prod := kafkactl.NewClient(cfg)
+
+	// A client keeps its context when the config's current context changes
+	if dev.ContextName() != "dev" || dev.Namespace() != "dev-ns" {
+		t.Errorf("dev client bound to %s/%s", dev.ContextName(), dev.Namespace())
+	}
+	if prod.ContextName() != "prod" || prod.Namespace() != "prod-ns" {
+		t.Errorf("prod client bound to %s/%s", prod.ContextName(), prod.Namespace())
+	}
+
+	want := []string{
+		"KAFKACTL_CURRENT_NAMESPACE=prod-ns",
+		"KAFKACTL_API=http://prod:8080",
+		"KAFKACTL_USER_TOKEN=secret",
+	}
+	if got := prod.Env(); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Env() = %v, want %v", got, want)
+	}
+
+	// A context without a token must not use the one of another context
+	want = []string{
+		"KAFKACTL_CURRENT_NAMESPACE=dev-ns",
+		"KAFKACTL_API=http://dev:8080",
+		"KAFKACTL_USER_TOKEN=",
+	}
+	if got := dev.Env(); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Env() = %v, want %v", got, want)
+	}
+
+	if err := cfg.UseContext("local"); err != nil {
+		t.Fatal(err)
+	}
+	want = []string{"KAFKACTL_CURRENT_NAMESPACE=local-ns"}
+	if got := kafkactl.NewClient(cfg).Env(); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Env() = %v, want %v", got, want)
+	}
+}
+
 func TestClient_ParseYAMLList(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -118,29 +171,207 @@ func TestClient_Methods(t *testing.T) {
 		// They will fail if kafkactl is not installed, which is expected
 
 		// Test that methods can be called (will fail without kafkactl installed)
-		_, err := client.GetTopics()
+		_, err := client.ListTopics(t.Context())
 		if err == nil {
 			t.Skip("kafkactl is installed, skipping error test")
 		}
 
-		_, err = client.GetSchemas()
+		_, err = client.ListSchemas(t.Context())
 		if err == nil {
 			t.Skip("kafkactl is installed, skipping error test")
 		}
 
-		_, err = client.GetConnectors()
+		_, err = client.ListConnectors(t.Context())
 		if err == nil {
 			t.Skip("kafkactl is installed, skipping error test")
 		}
 
-		_, err = client.GetConsumerGroups("test-topic")
+		_, err = client.GetConsumerGroups(t.Context(), "test-topic")
 		if err == nil {
 			t.Skip("kafkactl is installed, skipping error test")
 		}
 
-		_, err = client.GetResourceYAML("topics", "test-topic")
+		_, err = client.GetResourceYAML(t.Context(), "topics", "test-topic")
 		if err == nil {
 			t.Skip("kafkactl is installed, skipping error test")
 		}
 	})
 }
+
+func TestSummarizeConsumerGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		doc         map[string]any
+		wantState   string
+		wantMembers int
+		wantTopics  []string
+		wantLag     int64
+	}{
+		{
+			name: "members as list with assignments",
+			doc: map[string]any{
+				"metadata": map[string]any{"name": "orders-app"},
+				"status": map[string]any{
+					"state": "Stable",
+					"members": []any{
+						map[string]any{
+							"memberId": "m-1",
+							"assignments": []any{
+								map[string]any{"topic": "orders", "partition": 0},
+							},
+						},
+						map[string]any{"memberId": "m-2"},
+					},
+					"offsets": []any{
+						map[string]any{"topic": "orders", "partition": 0, "currentOffset": 10, "endOffset": 15},
+						map[string]any{"topic": "payments", "partition": 0, "currentOffset": 3, "endOffset": 3, "lag": 7},
+					},
+				},
+			},
+			wantState:   "Stable",
+			wantMembers: 2,
+			wantTopics:  []string{"orders", "payments"},
+			wantLag:     12,
+		},
+		{
+			name: "members as count",
+			doc: map[string]any{
+				"metadata": map[string]any{"name": "billing"},
+				"status":   map[string]any{"state": "Empty", "members": 0},
+			},
+			wantState:   "Empty",
+			wantMembers: 0,
+			wantLag:     0,
+		},
+		{
+			name: "missing status",
+			doc: map[string]any{
+				"metadata": map[string]any{"name": "unknown"},
+			},
+			wantState: "Unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := kafkactl.SummarizeConsumerGroup(tt.doc)
+			if got.Status.State != tt.wantState {
+				t.Errorf("State = %v, want %v", got.Status.State, tt.wantState)
+			}
+			if got.Status.Members != tt.wantMembers {
+				t.Errorf("Members = %v, want %v", got.Status.Members, tt.wantMembers)
+			}
+			if len(got.Status.Topics) != len(tt.wantTopics) {
+				t.Fatalf("Topics = %v, want %v", got.Status.Topics, tt.wantTopics)
+			}
+			for i := range tt.wantTopics {
+				if got.Status.Topics[i] != tt.wantTopics[i] {
+					t.Errorf("Topics[%d] = %v, want %v", i, got.Status.Topics[i], tt.wantTopics[i])
+				}
+			}
+			if got.TotalLag() != tt.wantLag {
+				t.Errorf("TotalLag() = %v, want %v", got.TotalLag(), tt.wantLag)
+			}
+		})
+	}
+}
+
+func TestResetOffsetsOptions_Args(t *testing.T) {
+	tests := []struct {
+		name string
+		opts kafkactl.ResetOffsetsOptions
+		want string
+	}{
+		{
+			name: "earliest on all topics",
+			opts: kafkactl.ResetOffsetsOptions{Group: "app", Method: kafkactl.ResetToEarliest, DryRun: true},
+			want: "reset-offsets --group app --all-topics --to-earliest --dry-run",
+		},
+		{
+			name: "shift by on one topic",
+			opts: kafkactl.ResetOffsetsOptions{Group: "app", Topic: "orders", Method: kafkactl.ResetShiftBy, Value: "-10"},
+			want: "reset-offsets --group app --topic orders --shift-by -10",
+		},
+		{
+			name: "value ignored for to-latest",
+			opts: kafkactl.ResetOffsetsOptions{Group: "app", Topic: "orders", Method: kafkactl.ResetToLatest, Value: "5"},
+			want: "reset-offsets --group app --topic orders --to-latest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(tt.opts.Args(), " ")
+			if got != tt.want {
+				t.Errorf("Args() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseResetOffsetsOutput(t *testing.T) {
+	output := `CONSUMER_GROUP  TOPIC   PARTITION  NEW_OFFSET
+app             orders  0          42
+app             orders  1          17
+`
+
+	got := kafkactl.ParseResetOffsetsOutput([]byte(output))
+	if len(got) != 2 {
+		t.Fatalf("ParseResetOffsetsOutput() returned %d rows, want 2", len(got))
+	}
+	if got[1].Topic != "orders" || got[1].Partition != 1 || got[1].NewOffset != 17 {
+		t.Errorf("ParseResetOffsetsOutput()[1] = %+v", got[1])
+	}
+
+	if empty := kafkactl.ParseResetOffsetsOutput([]byte("no offsets to reset")); len(empty) != 0 {
+		t.Errorf("ParseResetOffsetsOutput() without table returned %d rows, want 0", len(empty))
+	}
+}
+
+func TestStripServerFields(t *testing.T) {
+	manifest := `apiVersion: v1
+kind: Topic
+metadata:
+  name: orders
+  namespace: sales
+  cluster: local
+  creationTimestamp: "2024-01-01T00:00:00Z"
+  generation: 3
+  labels:
+    team: sales
+spec:
+  partitions: 3
+status:
+  phase: Success
+`
+
+	got, err := kafkactl.StripServerFields([]byte(manifest))
+	if err != nil {
+		t.Fatalf("StripServerFields() error = %v", err)
+	}
+
+	var doc map[string]any
+	if err = yaml.Unmarshal(got, &doc); err != nil {
+		t.Fatalf("StripServerFields() produced invalid YAML: %v", err)
+	}
+
+	if _, ok := doc["status"]; ok {
+		t.Error("status was not removed")
+	}
+
+	metadata, _ := doc["metadata"].(map[string]any)
+	for _, field := range []string{"cluster", "creationTimestamp", "generation"} {
+		if _, ok := metadata[field]; ok {
+			t.Errorf("metadata.%s was not removed", field)
+		}
+	}
+	for _, field := range []string{"name", "namespace", "labels"} {
+		if _, ok := metadata[field]; !ok {
+			t.Errorf("metadata.%s was removed", field)
+		}
+	}
+
+	if !strings.HasPrefix(string(got), "apiVersion: v1\nkind: Topic\n") {
+		t.Errorf("field order not preserved:\n%s", got)
+	}
+}
@@ -1,6 +1,7 @@
 package unit
 
 import (
+	"slices"
 	"testing"
 	"time"
 
@@ -158,3 +159,168 @@ func TestBaseResource_FullResource(t *testing.T) {
 		t.Errorf("APIVersion = %v, want %v", resource.APIVersion, "kafka.michelin.io/v1")
 	}
 }
+
+func TestDecode_Topic(t *testing.T) {
+	doc := map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Topic",
+		"metadata":   map[string]any{"name": "orders", "namespace": "team-orders", "cluster": "local"},
+		"spec": map[string]any{
+			"partitions":        6,
+			"replicationFactor": 3,
+			"configs":           map[string]any{"retention.ms": 604800000, "cleanup.policy": "delete"},
+			"futureField":       "kept",
+		},
+		"status": map[string]any{"phase": "Success"},
+	}
+
+	topic, err := models.Decode[models.Topic](doc)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	if topic.Metadata.Name != "orders" || topic.Metadata.Cluster != "local" {
+		t.Errorf("Metadata = %+v, want name orders on cluster local", topic.Metadata)
+	}
+	if topic.Spec.Partitions != 6 || topic.Spec.ReplicationFactor != 3 {
+		t.Errorf("Spec = %+v, want 6 partitions and replication factor 3", topic.Spec)
+	}
+	if got := topic.Spec.Configs["retention.ms"]; got != "604800000" {
+		t.Errorf("Configs[retention.ms] = %q, want %q", got, "604800000")
+	}
+	if topic.Status.Phase != "Success" {
+		t.Errorf("Status.Phase = %q, want Success", topic.Status.Phase)
+	}
+
+	spec, ok := topic.Raw["spec"].(map[string]any)
+	if !ok || spec["futureField"] != "kept" {
+		t.Errorf("Raw lost a field unknown to the model: %v", topic.Raw)
+	}
+}
+
+func TestDecode_ConnectorStatus(t *testing.T) {
+	doc := map[string]any{
+		"kind":     "Connector",
+		"metadata": map[string]any{"name": "orders-sink"},
+		"spec": map[string]any{
+			"connectCluster": "connect-1",
+			"config":         map[string]any{"connector.class": "io.confluent.S3SinkConnector", "tasks.max": 2},
+		},
+		"status": map[string]any{
+			"state": "RUNNING",
+			"tasks": []any{
+				map[string]any{"id": 0, "state": "RUNNING", "worker_id": "w1:8083"},
+				map[string]any{"id": 1, "state": "FAILED", "trace": "boom"},
+			},
+		},
+	}
+
+	connector, err := models.Decode[models.Connector](doc)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	if connector.Spec.Config["tasks.max"] != "2" {
+		t.Errorf("Config[tasks.max] = %q, want %q", connector.Spec.Config["tasks.max"], "2")
+	}
+	if connector.Status.State != "RUNNING" {
+		t.Errorf("Status.State = %q, want RUNNING", connector.Status.State)
+	}
+	if len(connector.Status.Tasks) != 2 || connector.Status.Tasks[1].Trace != "boom" {
+		t.Errorf("Status.Tasks = %+v, want two tasks with the failure trace", connector.Status.Tasks)
+	}
+}
+
+func TestValidationConstraint(t *testing.T) {
+	minPartitions, maxPartitions := 3.0, 6.0
+
+	tests := []struct {
+		name       string
+		constraint models.ValidationConstraint
+		value      string
+		wantErr    bool
+		wantString string
+	}{
+		{
+			name:       "in range",
+			constraint: models.ValidationConstraint{ValidationType: "Range", Min: &minPartitions, Max: &maxPartitions},
+			value:      "6",
+			wantString: "3 to 6",
+		},
+		{
+			name:       "out of range",
+			constraint: models.ValidationConstraint{ValidationType: "Range", Min: &minPartitions, Max: &maxPartitions},
+			value:      "12",
+			wantErr:    true,
+			wantString: "3 to 6",
+		},
+		{
+			name:       "minimum only",
+			constraint: models.ValidationConstraint{ValidationType: "Range", Min: &minPartitions},
+			value:      "1",
+			wantErr:    true,
+			wantString: "at least 3",
+		},
+		{
+			name:       "valid list",
+			constraint: models.ValidationConstraint{ValidationType: "ValidList", ValidStrings: []string{"delete", "compact"}},
+			value:      "delete,compact",
+			wantString: "list of delete, compact",
+		},
+		{
+			name:       "valid string",
+			constraint: models.ValidationConstraint{ValidationType: "ValidString", ValidStrings: []string{"delete", "compact"}},
+			value:      "delete,compact",
+			wantErr:    true,
+			wantString: "one of delete, compact",
+		},
+		{
+			name:       "non empty string",
+			constraint: models.ValidationConstraint{ValidationType: "NonEmptyString"},
+			value:      " ",
+			wantErr:    true,
+			wantString: "not empty",
+		},
+		{
+			name:       "unknown type",
+			constraint: models.ValidationConstraint{ValidationType: "CompositeValidator"},
+			value:      "anything",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.constraint.Validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got := tt.constraint.String(); got != tt.wantString {
+				t.Errorf("String() = %q, want %q", got, tt.wantString)
+			}
+		})
+	}
+}
+
+func TestOwnedPrefixes_PatternType(t *testing.T) {
+	owner := func(resource, patternType string) models.AccessControlEntry {
+		return models.AccessControlEntry{Spec: models.AccessControlEntrySpec{
+			ResourceType:        "TOPIC",
+			Resource:            resource,
+			ResourcePatternType: patternType,
+			Permission:          "OWNER",
+			GrantedTo:           "team-orders",
+		}}
+	}
+	acls := []models.AccessControlEntry{
+		owner("orders-", "PREFIXED"),
+		owner("orders", "LITERAL"),
+		{Spec: models.AccessControlEntrySpec{ResourceType: "TOPIC", Resource: "payments-", ResourcePatternType: "PREFIXED", Permission: "READ", GrantedTo: "team-orders"}},
+	}
+
+	if got := models.OwnedPrefixes(acls, "team-orders", "TOPIC"); !slices.Equal(got, []string{"orders-"}) {
+		t.Errorf("OwnedPrefixes() = %v, want [orders-]", got)
+	}
+	if got := models.OwnedNames(acls, "team-orders", "TOPIC"); !slices.Equal(got, []string{"orders"}) {
+		t.Errorf("OwnedNames() = %v, want [orders]", got)
+	}
+}
@@ -0,0 +1,116 @@
+package unit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/views/resources"
+)
+
+func TestParseAPIResources(t *testing.T) {
+	output := `KIND                           NAMES                                    NAMESPACED
+AccessControlEntry             acls, acl, access-control-entries        true
+Namespace                      namespaces,namespace,ns                  false
+RoleBinding                    rolebindings,rolebinding,rb              true
+`
+
+	want := []kafkactl.APIResource{
+		{Kind: "AccessControlEntry", Names: []string{"acls", "acl", "access-control-entries"}, Namespaced: true},
+		{Kind: "Namespace", Names: []string{"namespaces", "namespace", "ns"}},
+		{Kind: "RoleBinding", Names: []string{"rolebindings", "rolebinding", "rb"}, Namespaced: true},
+	}
+	got := kafkactl.ParseAPIResources(output)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseAPIResources() = %+v, want %+v", got, want)
+	}
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "rb", want: true},
+		{name: "RoleBinding", want: true},
+		{name: "rolebindings", want: true},
+		{name: "roles", want: false},
+	}
+	for _, tt := range tests {
+		if got := want[2].Matches(tt.name); got != tt.want {
+			t.Errorf("Matches(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := want[2].ResourceType(); got != "rolebindings" {
+		t.Errorf("ResourceType() = %q, want rolebindings", got)
+	}
+}
+
+func TestResourceView(t *testing.T) {
+	t.Setenv("K4A_HOME", t.TempDir())
+
+	fake := kafkactl.NewFakeExecutor()
+	fake.Add(kafkactl.Result{Stdout: `---
+apiVersion: v1
+kind: RoleBinding
+metadata:
+  name: team-orders-admins
+spec:
+  role:
+    resourceTypes: [topics, connectors]
+    verbs: [GET, PUT, DELETE]
+  subject:
+    subjectName: GROUP_ORDERS_ADMINS
+---
+apiVersion: v1
+kind: RoleBinding
+metadata:
+  name: team-orders-readers
+spec:
+  role:
+    resourceTypes: [topics]
+    verbs: [GET]
+  subject:
+    subjectName: GROUP_ORDERS
+`}, "get", "rolebindings", "-o", "yaml")
+	fake.Add(kafkactl.Result{Stdout: "kind: RoleBinding\nmetadata:\n  name: team-orders-readers\n"},
+		"get", "rolebindings", "team-orders-readers", "-o", "yaml")
+
+	resource := kafkactl.APIResource{Kind: "RoleBinding", Names: []string{"rolebindings", "rb"}}
+	client := kafkactl.NewClientWithExecutor(testConfig(), fake)
+	view := resources.New(client, nil, resource, resources.ColumnsFor(resource.Kind))
+	view.SetSize(160, 30)
+	updated, _ := view.Update(view.Init()())
+	view = updated.(resources.Model)
+
+	out := view.View()
+	for _, want := range []string{"Subject", "GROUP_ORDERS_ADMINS", "topics, connectors", "GET, PUT, DELETE"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("View() does not contain %q:\n%s", want, out)
+		}
+	}
+
+	// Filter by a field, then describe the only row left
+	updated, _ = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	view = updated.(resources.Model)
+	for _, r := range "spec.subject.subjectName=GROUP_ORDERS" {
+		updated, _ = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		view = updated.(resources.Model)
+	}
+	updated, _ = view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	view = updated.(resources.Model)
+	if expr, matched, total := view.FilterStatus(); matched != 1 || total != 2 {
+		t.Fatalf("FilterStatus() = %q %d/%d, want 1 of 2 rolebindings", expr, matched, total)
+	}
+
+	updated, cmd := view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	view = updated.(resources.Model)
+	if cmd == nil {
+		t.Fatal("d returned no command to load the manifest")
+	}
+	updated, _ = view.Update(cmd())
+	view = updated.(resources.Model)
+	if out := view.View(); !strings.Contains(out, "RoleBinding team-orders-readers") || !strings.Contains(out, "name: team-orders-readers") {
+		t.Errorf("View() after d does not show the manifest:\n%s", out)
+	}
+}
@@ -0,0 +1,175 @@
+package unit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/footer"
+	"github.com/smart-fellas/k4a/internal/ui/views/schemas"
+)
+
+const ordersValueV1 = `---
+apiVersion: v1
+kind: Schema
+metadata:
+  name: orders-value
+spec:
+  id: 7
+  version: 1
+  compatibility: BACKWARD
+  schema: '{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}]}'
+`
+
+func TestSchemaFromFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		wantType string
+		wantErr  bool
+	}{
+		{name: "avro", path: write("orders.avsc", `{"type":"string"}`), wantType: ""},
+		{name: "json schema", path: write("orders.json", `{"type":"object"}`), wantType: "JSON"},
+		{name: "protobuf", path: write("orders.proto", `syntax = "proto3";`), wantType: "PROTOBUF"},
+		{name: "unknown extension", path: write("orders.txt", "text"), wantErr: true},
+		{name: "empty file", path: write("empty.avsc", "\n"), wantErr: true},
+		{name: "missing file", path: filepath.Join(dir, "missing.avsc"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := kafkactl.SchemaFromFile(tt.path, "orders-value", "team-orders")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SchemaFromFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if schema.Kind != "Schema" || schema.Metadata.Name != "orders-value" || schema.Metadata.Namespace != "team-orders" {
+				t.Errorf("SchemaFromFile() = %+v, want the orders-value Schema of team-orders", schema.BaseResource)
+			}
+			if schema.Spec.SchemaType != tt.wantType {
+				t.Errorf("SchemaType = %q, want %q", schema.Spec.SchemaType, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestSchemasView_Apply(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orders-value.avsc")
+	newer := `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"},{"name":"amount","type":"int"}]}`
+	if err := os.WriteFile(path, []byte(newer), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fake := kafkactl.NewFakeExecutor()
+	fake.Add(kafkactl.Result{Stdout: ordersValueV1}, "get", "schemas", "-o", "yaml")
+	fake.Add(kafkactl.Result{Stdout: ordersValueV1}, "get", "schema", "orders-value", "--all-versions", "-o", "yaml")
+	fake.Add(kafkactl.Result{Stdout: "Success Schema/orders-value (created)"}, "apply", "-f", "*")
+
+	view := schemas.New(kafkactl.NewClientWithExecutor(testConfig(), fake), nil)
+	view.SetSize(120, 40)
+	view.StartApply(path, "")
+	if !view.CapturingInput() {
+		t.Fatal("CapturingInput() = false while the apply form is open")
+	}
+
+	// Submitting the form checks the file against the latest version
+	updated, cmd := view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	view = updated.(schemas.Model)
+	updated, _ = view.Update(cmd())
+	view = updated.(schemas.Model)
+
+	out := view.View()
+	for _, want := range []string{"Register orders-value although the check failed", "rejected", "amount added", "orders-value v1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("View() does not contain %q:\n%s", want, out)
+		}
+	}
+
+	// Confirming applies the manifest
+	updated, cmd = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	view = updated.(schemas.Model)
+	updated, _ = view.Update(cmd())
+	view = updated.(schemas.Model)
+
+	calls := fake.Calls()
+	if got := strings.Join(calls[len(calls)-1][:2], " "); got != "apply -f" {
+		t.Errorf("last kafkactl run = %v, want apply -f", calls[len(calls)-1])
+	}
+	if view.CapturingInput() || strings.Contains(view.View(), "Register") {
+		t.Errorf("apply flow still open after applying:\n%s", view.View())
+	}
+}
+
+func TestSchemasView_SetCompatibility(t *testing.T) {
+	t.Setenv("K4A_HOME", t.TempDir())
+
+	fake := kafkactl.NewFakeExecutor()
+	fake.Add(kafkactl.Result{Stdout: ordersValueV1 + `---
+apiVersion: v1
+kind: Schema
+metadata:
+  name: payments-value
+spec:
+  id: 9
+  version: 3
+  compatibility: BACKWARD
+`}, "get", "schemas", "-o", "yaml")
+	fake.Add(kafkactl.Result{}, "schema", "FULL", "orders-value")
+	fake.Add(kafkactl.Result{Stderr: "Subject payments-value is not owned", ExitCode: 1},
+		"schema", "FULL", "payments-value")
+
+	view := schemas.New(kafkactl.NewClientWithExecutor(testConfig(), fake), nil)
+	view.SetSize(120, 40)
+	updated, _ := view.Update(view.Init()())
+	view = updated.(schemas.Model)
+
+	// Select both subjects and pick FULL, four levels below BACKWARD
+	keys := []tea.KeyMsg{
+		{Type: tea.KeySpace, Runes: []rune(" ")},
+		{Type: tea.KeyDown},
+		{Type: tea.KeySpace, Runes: []rune(" ")},
+		{Type: tea.KeyRunes, Runes: []rune("C")},
+		{Type: tea.KeyDown},
+		{Type: tea.KeyDown},
+		{Type: tea.KeyDown},
+		{Type: tea.KeyDown},
+	}
+	for _, key := range keys {
+		updated, _ = view.Update(key)
+		view = updated.(schemas.Model)
+	}
+	if out := view.View(); !strings.Contains(out, "Compatibility of 2 subjects") || !strings.Contains(out, "> FULL") {
+		t.Fatalf("View() does not show FULL picked for 2 subjects:\n%s", view.View())
+	}
+
+	updated, cmd := view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	view = updated.(schemas.Model)
+	if !view.CapturingInput() {
+		t.Error("CapturingInput() = false while the levels are being set")
+	}
+	_, cmd = view.Update(cmd())
+
+	var message footer.MessageMsg
+	for _, c := range cmd().(tea.BatchMsg) {
+		if msg, ok := c().(footer.MessageMsg); ok {
+			message = msg
+		}
+	}
+	if message.Level != footer.LevelError || !strings.Contains(message.Text, "Set FULL on 1 of 2 subjects; failed: payments-value") {
+		t.Errorf("footer message = %+v, want a summary with payments-value failed", message)
+	}
+}
@@ -0,0 +1,140 @@
+package unit
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/config"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/components/sorter"
+	"github.com/smart-fellas/k4a/internal/ui/views/topics"
+)
+
+func TestSorter_Sort(t *testing.T) {
+	t.Setenv("K4A_HOME", t.TempDir())
+
+	columns := []table.Column{{Title: ""}, {Title: "Subject"}, {Title: "Version"}}
+	rows := [][]string{
+		{"", "payments-value", "10"},
+		{"", "Orders-value", "9"},
+		{"", "audit-value", "latest"},
+		{"", "customers-value", "2"},
+	}
+	cell := func(row []string, column string) string {
+		return row[slices.IndexFunc(columns, func(c table.Column) bool { return c.Title == column })]
+	}
+	subjects := func(rows [][]string) []string {
+		var names []string
+		for _, row := range rows {
+			names = append(names, row[1])
+		}
+		return names
+	}
+
+	s := sorter.New("test", nil, columns, "Version")
+	press := func(key string) {
+		t.Helper()
+		var handled bool
+		s, _, handled = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		if !handled {
+			t.Fatalf("Update(%s) was not handled", key)
+		}
+	}
+
+	tests := []struct {
+		key       string
+		wantTitle string
+		wantOrder []string
+	}{
+		{key: "s", wantTitle: "Subject ▲", wantOrder: []string{"audit-value", "customers-value", "Orders-value", "payments-value"}},
+		{key: "s", wantTitle: "Version ▲", wantOrder: []string{"customers-value", "Orders-value", "payments-value", "audit-value"}},
+		{key: "S", wantTitle: "Version ▼", wantOrder: []string{"audit-value", "payments-value", "Orders-value", "customers-value"}},
+		{key: "s", wantOrder: []string{"payments-value", "Orders-value", "audit-value", "customers-value"}},
+	}
+
+	for _, tt := range tests {
+		press(tt.key)
+
+		sorted := slices.Clone(rows)
+		sorter.Sort(s, sorted, cell)
+		if got := subjects(sorted); !slices.Equal(got, tt.wantOrder) {
+			t.Errorf("after %s: order = %v, want %v", tt.key, got, tt.wantOrder)
+		}
+
+		var titles []string
+		for _, column := range s.Columns() {
+			titles = append(titles, column.Title)
+		}
+		if tt.wantTitle != "" && !slices.Contains(titles, tt.wantTitle) {
+			t.Errorf("after %s: titles = %q, want %q", tt.key, titles, tt.wantTitle)
+		}
+		if tt.wantTitle == "" && strings.ContainsAny(strings.Join(titles, ""), "▲▼") {
+			t.Errorf("after %s: titles = %q, want no sort indicator", tt.key, titles)
+		}
+	}
+}
+
+func TestSorter_State(t *testing.T) {
+	t.Setenv("K4A_HOME", t.TempDir())
+
+	columns := []table.Column{{Title: "Subject"}, {Title: "Version"}}
+	state := &config.State{Sort: map[string]config.SortOrder{"test": {Column: "Version", Descending: true}}}
+
+	s := sorter.New("test", state, columns, "Version")
+	if got := s.Columns()[1].Title; got != "Version ▼" {
+		t.Errorf("Columns()[1].Title = %q, want the order restored from the state", got)
+	}
+
+	s, _, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")})
+	if got := state.Sort["test"]; got.Column != "Version" || got.Descending {
+		t.Errorf("state.Sort[test] = %+v, want Version ascending", got)
+	}
+	if saved, err := config.LoadState(); err != nil || saved.Sort["test"] != state.Sort["test"] {
+		t.Errorf("state file = %+v, %v, want the new order saved", saved, err)
+	}
+}
+
+func TestTopicsView_SortSurvivesRestart(t *testing.T) {
+	t.Setenv("K4A_HOME", t.TempDir())
+
+	fake, err := kafkactl.NewReplayExecutor(topicsCassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := kafkactl.NewClientWithExecutor(testConfig(), fake)
+
+	state, err := config.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	view := topics.New(client, state)
+	view.SetSize(160, 30)
+	updated, _ := view.Update(view.Init()())
+	view = updated.(topics.Model)
+
+	// Sort by partitions, fewest first: payments-v1 has 6, orders-created-v1 12
+	for _, key := range []string{"s", "s"} {
+		updated, _ = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		view = updated.(topics.Model)
+	}
+	out := view.View()
+	if !strings.Contains(out, "Partitions ▲") || strings.Index(out, "payments-v1") > strings.Index(out, "orders-created-v1") {
+		t.Fatalf("View() is not sorted by partitions:\n%s", out)
+	}
+
+	// A new view, as after a restart, keeps the order once loaded
+	if state, err = config.LoadState(); err != nil {
+		t.Fatal(err)
+	}
+	restarted := topics.New(client, state)
+	restarted.SetSize(160, 30)
+	updated, _ = restarted.Update(restarted.Init()())
+	restarted = updated.(topics.Model)
+	out = restarted.View()
+	if !strings.Contains(out, "Partitions ▲") || strings.Index(out, "payments-v1") > strings.Index(out, "orders-created-v1") {
+		t.Errorf("restarted View() is not sorted by partitions:\n%s", out)
+	}
+}
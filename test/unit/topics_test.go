@@ -0,0 +1,266 @@
+package unit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/ui/views/topics"
+	"github.com/smart-fellas/k4a/pkg/models"
+)
+
+const ordersOwnerACL = `---
+apiVersion: v1
+kind: AccessControlEntry
+metadata:
+  name: team-orders-owner
+  namespace: team-orders
+spec:
+  resourceType: TOPIC
+  resource: orders-
+  resourcePatternType: PREFIXED
+  permission: OWNER
+  grantedTo: team-orders
+`
+
+const ordersNamespace = `---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: team-orders
+spec:
+  kafkaUser: u-orders
+  topicValidator:
+    validationConstraints:
+      partitions:
+        validation-type: Range
+        min: 3
+        max: 6
+      replication.factor:
+        validation-type: Range
+        min: 3
+        max: 3
+`
+
+func TestTopicsView_Create(t *testing.T) {
+	fake := kafkactl.NewFakeExecutor()
+	fake.Add(kafkactl.Result{Stdout: "---\n"}, "get", "topics", "-o", "yaml")
+	fake.Add(kafkactl.Result{Stdout: ordersOwnerACL}, "get", "acls", "-o", "yaml")
+	fake.Add(kafkactl.Result{Stdout: ordersNamespace}, "get", "namespace", "team-orders", "-o", "yaml")
+	fake.Add(kafkactl.Result{Stdout: "Success Topic/orders-shipped-v1 (created)"}, "apply", "-f", "*")
+
+	view := topics.New(kafkactl.NewClientWithExecutor(testConfig(), fake), nil)
+	view.SetSize(120, 40)
+	updated, _ := view.Update(view.Init()())
+	view = updated.(topics.Model)
+
+	// Opening the form loads the owned prefixes and the topic validator
+	updated, cmd := view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	view = updated.(topics.Model)
+	for _, c := range cmd().(tea.BatchMsg) {
+		updated, _ = view.Update(c())
+		view = updated.(topics.Model)
+	}
+	if !view.CapturingInput() {
+		t.Fatal("CapturingInput() = false while the create form is open")
+	}
+
+	update := func(keys ...tea.KeyMsg) {
+		t.Helper()
+		for _, key := range keys {
+			updated, _ = view.Update(key)
+			view = updated.(topics.Model)
+		}
+	}
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	// The name is prefilled with the owned prefix; 12 partitions break the
+	// validator's range
+	update(
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("shipped-v1")},
+		tab,
+		tea.KeyMsg{Type: tea.KeyBackspace},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("12")},
+		enter,
+	)
+	if out := view.View(); !strings.Contains(out, "must be at most 6") {
+		t.Fatalf("View() does not reject 12 partitions:\n%s", out)
+	}
+
+	update(
+		tea.KeyMsg{Type: tea.KeyBackspace},
+		tea.KeyMsg{Type: tea.KeyBackspace},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("6")},
+		enter,
+	)
+	out := view.View()
+	for _, want := range []string{"name: orders-shipped-v1", "namespace: team-orders", "partitions: 6", `retention.ms: "604800000"`, `min.insync.replicas: "2"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("preview does not contain %q:\n%s", want, out)
+		}
+	}
+
+	// Confirming applies the manifest and reloads the topics
+	updated, cmd = view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	view = updated.(topics.Model)
+	updated, _ = view.Update(cmd())
+	view = updated.(topics.Model)
+
+	applied := false
+	for _, call := range fake.Calls() {
+		applied = applied || strings.Join(call[:2], " ") == "apply -f"
+	}
+	if !applied {
+		t.Errorf("kafkactl runs = %v, want an apply -f", fake.Calls())
+	}
+	if view.CapturingInput() {
+		t.Error("create form still open after applying")
+	}
+}
+
+func TestTopicsView_CreateRejectsUnownedName(t *testing.T) {
+	fake := kafkactl.NewFakeExecutor()
+	fake.Add(kafkactl.Result{Stdout: "---\n"}, "get", "topics", "-o", "yaml")
+	fake.Add(kafkactl.Result{Stdout: ordersOwnerACL}, "get", "acls", "-o", "yaml")
+	fake.Add(kafkactl.Result{Stderr: "Namespace not found", ExitCode: 1}, "get", "namespace", "team-orders", "-o", "yaml")
+
+	view := topics.New(kafkactl.NewClientWithExecutor(testConfig(), fake), nil)
+	view.SetSize(120, 40)
+	updated, _ := view.Update(view.Init()())
+	view = updated.(topics.Model)
+
+	updated, cmd := view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	view = updated.(topics.Model)
+	for _, c := range cmd().(tea.BatchMsg) {
+		updated, _ = view.Update(c())
+		view = updated.(topics.Model)
+	}
+
+	// Replace the prefilled prefix with a name outside of it
+	keys := []tea.KeyMsg{{Type: tea.KeyCtrlU}, {Type: tea.KeyRunes, Runes: []rune("payments-v2")}, {Type: tea.KeyEnter}}
+	for _, key := range keys {
+		updated, _ = view.Update(key)
+		view = updated.(topics.Model)
+	}
+
+	if out := view.View(); !strings.Contains(out, "must start with an owned prefix: orders-") {
+		t.Errorf("View() does not reject payments-v2:\n%s", out)
+	}
+}
+
+func TestTopicsView_Configs(t *testing.T) {
+	t.Setenv("K4A_HOME", t.TempDir())
+
+	fake, err := kafkactl.NewReplayExecutor(topicsCassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	view := topics.New(kafkactl.NewClientWithExecutor(testConfig(), fake), nil)
+	view.SetSize(160, 30)
+	updated, _ := view.Update(view.Init()())
+	view = updated.(topics.Model)
+
+	press := func(keys ...tea.KeyMsg) {
+		for _, key := range keys {
+			updated, _ = view.Update(key)
+			view = updated.(topics.Model)
+		}
+	}
+
+	if out := view.View(); !strings.Contains(out, "7.0d") {
+		t.Errorf("View() does not show the retention in days:\n%s", out)
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	if out := view.View(); !strings.Contains(out, "604800000") {
+		t.Errorf("View() does not show the raw retention after u:\n%s", out)
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+
+	// payments-v1 is compacted, unlike the broker default
+	press(tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	out := view.View()
+	for _, want := range []string{"Configs of payments-v1", "* cleanup.policy", "(default delete)", "keeps the latest record of each key"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("config panel does not contain %q:\n%s", want, out)
+		}
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if strings.Contains(view.View(), "Configs of") {
+		t.Error("config panel still open after esc")
+	}
+}
+
+func TestTopicsView_SkipsMalformedManifests(t *testing.T) {
+	fake := kafkactl.NewFakeExecutor()
+	fake.Add(kafkactl.Result{Stdout: `---
+apiVersion: v1
+kind: Topic
+metadata:
+  name: orders
+spec:
+  partitions: 6
+---
+apiVersion: v1
+kind: Topic
+metadata:
+  name: broken
+spec:
+  partitions: many
+`}, "get", "topics", "-o", "yaml")
+	client := kafkactl.NewClientWithExecutor(testConfig(), fake)
+
+	list, err := client.ListTopics(t.Context())
+	if !errors.Is(err, models.ErrDecode) || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("ListTopics() error = %v, want the decode failure of broken", err)
+	}
+	if len(list) != 1 || list[0].Metadata.Name != "orders" {
+		t.Errorf("ListTopics() = %+v, want orders alone", list)
+	}
+
+	view := topics.New(client, nil)
+	view.SetSize(120, 40)
+	updated, cmd := view.Update(view.Init()())
+	view = updated.(topics.Model)
+	if out := view.View(); !strings.Contains(out, "orders") {
+		t.Errorf("View() does not list orders:\n%s", out)
+	}
+	if cmd == nil || !strings.Contains(fmt.Sprint(cmd()), "Some topics could not be read") {
+		t.Error("the topics left out are not reported in the footer")
+	}
+}
+
+func TestTopicsView_CreateLiteralOwnerIsNotAPrefix(t *testing.T) {
+	fake := kafkactl.NewFakeExecutor()
+	fake.Add(kafkactl.Result{Stdout: "---\n"}, "get", "topics", "-o", "yaml")
+	fake.Add(kafkactl.Result{Stdout: strings.ReplaceAll(strings.ReplaceAll(ordersOwnerACL,
+		"resource: orders-", "resource: orders"), "PREFIXED", "LITERAL")}, "get", "acls", "-o", "yaml")
+	fake.Add(kafkactl.Result{Stderr: "Namespace not found", ExitCode: 1}, "get", "namespace", "team-orders", "-o", "yaml")
+
+	view := topics.New(kafkactl.NewClientWithExecutor(testConfig(), fake), nil)
+	view.SetSize(120, 40)
+	updated, _ := view.Update(view.Init()())
+	view = updated.(topics.Model)
+
+	updated, cmd := view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	view = updated.(topics.Model)
+	for _, c := range cmd().(tea.BatchMsg) {
+		updated, _ = view.Update(c())
+		view = updated.(topics.Model)
+	}
+
+	keys := []tea.KeyMsg{{Type: tea.KeyCtrlU}, {Type: tea.KeyRunes, Runes: []rune("orders-anything")}, {Type: tea.KeyEnter}}
+	for _, key := range keys {
+		updated, _ = view.Update(key)
+		view = updated.(topics.Model)
+	}
+
+	if out := view.View(); !strings.Contains(out, "not owned by the namespace; owned topics: orders") {
+		t.Errorf("View() does not reject orders-anything:\n%s", out)
+	}
+}
@@ -0,0 +1,187 @@
+package unit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/smart-fellas/k4a/internal/config"
+	"github.com/smart-fellas/k4a/internal/kafkactl"
+	"github.com/smart-fellas/k4a/internal/trash"
+	"github.com/smart-fellas/k4a/internal/ui/components/deletion"
+	"github.com/smart-fellas/k4a/internal/ui/views/restore"
+)
+
+func TestTrash_SaveListRemove(t *testing.T) {
+	t.Setenv("K4A_TRASH_DIR", t.TempDir())
+
+	manifest := []byte("apiVersion: v1\nkind: Topic\nmetadata:\n  name: orders-v1\n")
+	path, err := trash.Save("dev", "topic", "orders-v1", manifest)
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read saved manifest: %v", err)
+	}
+	if string(saved) != string(manifest) {
+		t.Errorf("Saved manifest = %q, want %q", saved, manifest)
+	}
+
+	entries, err := trash.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("List() returned %d entries, want 1", len(entries))
+	}
+
+	entry := entries[0]
+	if entry.Context != "dev" || entry.Kind != "topic" || entry.Name != "orders-v1" {
+		t.Errorf("List() entry = %+v", entry)
+	}
+	if entry.DeletedAt.IsZero() {
+		t.Error("List() entry has no deletion time")
+	}
+
+	if err = trash.Remove(entry); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	entries, err = trash.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("List() after Remove() returned %d entries, want 0", len(entries))
+	}
+}
+
+func TestTrash_ListMissingDir(t *testing.T) {
+	t.Setenv("K4A_TRASH_DIR", t.TempDir()+"/missing")
+
+	entries, err := trash.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("List() returned %d entries, want 0", len(entries))
+	}
+}
+
+func TestTrash_SaveKeepsEveryBackup(t *testing.T) {
+	t.Setenv("K4A_TRASH_DIR", t.TempDir())
+
+	// Deleting, restoring and deleting again within a second keeps both
+	for _, version := range []string{"v1", "v2"} {
+		if _, err := trash.Save("prod/eu", "topic", "orders", []byte("version: "+version+"\n")); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	entries, err := trash.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("List() returned %d entries, want 2", len(entries))
+	}
+	for _, entry := range entries {
+		if entry.Context != trash.ContextDir("prod/eu") || entry.Name != "orders" {
+			t.Errorf("List() entry = %+v, want orders of prod/eu", entry)
+		}
+	}
+}
+
+func TestDelete_FailureKeepsNoBackup(t *testing.T) {
+	t.Setenv("K4A_TRASH_DIR", t.TempDir())
+
+	fake := kafkactl.NewFakeExecutor()
+	fake.Add(kafkactl.Result{Stdout: "kind: Topic\nmetadata:\n  name: orders-v1\n"}, "get", "topic", "orders-v1", "-o", "yaml")
+	fake.Add(kafkactl.Result{Stderr: "Topic orders-v1 is not owned", ExitCode: 1}, "delete", "topic", "orders-v1")
+
+	_, err := deletion.Delete(context.Background(), kafkactl.NewClientWithExecutor(testConfig(), fake), "topic", "orders-v1")
+	if err == nil {
+		t.Fatal("Delete() error = nil, want the failed delete")
+	}
+
+	entries, err := trash.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("List() after a failed delete = %+v, want no entry to restore", entries)
+	}
+}
+
+func TestDelete_TimeoutKeepsBackup(t *testing.T) {
+	t.Setenv("K4A_TRASH_DIR", t.TempDir())
+	settingsPath := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(settingsPath, []byte("timeouts:\n  delete: 20ms\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("K4A_CONFIG", settingsPath)
+	settings, err := config.LoadSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake := kafkactl.NewFakeExecutor(
+		kafkactl.Interaction{
+			Args:   []string{"get", "topic", "orders-v1", "-o", "yaml"},
+			Result: kafkactl.Result{Stdout: "kind: Topic\nmetadata:\n  name: orders-v1\n"},
+		},
+		kafkactl.Interaction{Args: []string{"delete", "topic", "orders-v1"}, Delay: time.Second},
+	)
+	client := kafkactl.NewClientWithExecutor(testConfig(), fake)
+	client.SetSettings(settings)
+
+	// The delete may have reached the server, so the backup is the only copy
+	_, err = deletion.Delete(context.Background(), client, "topic", "orders-v1")
+	if !kafkactl.IsTimeout(err) {
+		t.Fatalf("Delete() error = %v, want a timeout", err)
+	}
+
+	entries, err := trash.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != "orders-v1" {
+		t.Errorf("List() after a timed out delete = %+v, want the backup of orders-v1", entries)
+	}
+}
+
+func TestRestoreView_Confirmation(t *testing.T) {
+	t.Setenv("K4A_TRASH_DIR", t.TempDir())
+	if _, err := trash.Save("test", "topic", "orders-v1", []byte("kind: Topic\nmetadata:\n  name: orders-v1\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	fake := kafkactl.NewFakeExecutor()
+	fake.Add(kafkactl.Result{Stdout: "Success Topic/orders-v1 (created)"}, "apply", "-f", "*")
+
+	view := restore.New(kafkactl.NewClientWithExecutor(testConfig(), fake))
+	view.SetSize(120, 40)
+	updated, _ := view.Update(view.Init()())
+	view = updated.(restore.Model)
+
+	updated, _ = view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	view = updated.(restore.Model)
+	if out := view.View(); !view.CapturingInput() || !strings.Contains(out, "Restore topic orders-v1") {
+		t.Fatalf("View() does not ask to restore orders-v1:\n%s", out)
+	}
+
+	updated, cmd := view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	view = updated.(restore.Model)
+	if view.CapturingInput() || cmd == nil {
+		t.Fatal("y did not start the restore")
+	}
+	cmd()
+	if calls := fake.Calls(); len(calls) != 1 || calls[0][0] != "apply" {
+		t.Errorf("Calls() = %v, want the manifest applied", calls)
+	}
+}